@@ -0,0 +1,246 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/dsync"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var errLockOwnerOnline = AdminError{
+	Code:       "XMinioAdminLockOwnerOnline",
+	Message:    "The locks of a server which is online cannot be released, its locks are released when they are unlocked",
+	StatusCode: http.StatusBadRequest,
+}
+
+// validateLocksReq validates an admin request on the locks of the cluster.
+func validateLocksReq(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) *erasureServerPools {
+	objectAPI, _ := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return nil
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil
+	}
+	return z
+}
+
+// getClusterLockers returns the lockers of all the lock servers of the cluster.
+func (z *erasureServerPools) getClusterLockers() []dsync.NetLocker {
+	var lockers []dsync.NetLocker
+	seen := make(map[string]struct{})
+	for _, pool := range z.serverPools {
+		for _, setLockers := range pool.erasureLockers {
+			for _, locker := range setLockers {
+				if locker == nil {
+					continue
+				}
+				if _, ok := seen[locker.String()]; !ok {
+					seen[locker.String()] = struct{}{}
+					lockers = append(lockers, locker)
+				}
+			}
+		}
+	}
+	return lockers
+}
+
+func lockInfoToLockEntry(l dsync.LockInfo, server string, now time.Time) *madmin.LockEntry {
+	entry := &madmin.LockEntry{
+		Timestamp:  l.Timestamp,
+		Resource:   l.Resource,
+		ServerList: []string{server},
+		Source:     l.Source,
+		Owner:      l.Owner,
+		ID:         l.UID,
+		Quorum:     l.Quorum,
+		Elapsed:    now.Sub(l.Timestamp),
+	}
+	if l.Writer {
+		entry.Type = "WRITE"
+	} else {
+		entry.Type = "READ"
+	}
+	return entry
+}
+
+// listClusterLocks returns the locks held by the online lock servers which
+// can report them, oldest first. The locks held by less lock servers than
+// their quorum are only returned if stale is true.
+func listClusterLocks(ctx context.Context, lockers []dsync.NetLocker, stale bool) madmin.LockEntries {
+	serverLocks := make([][]dsync.LockInfo, len(lockers))
+	var wg sync.WaitGroup
+	for i, locker := range lockers {
+		lister, ok := locker.(dsync.LockLister)
+		if !ok || !locker.IsOnline() {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, lister dsync.LockLister) {
+			defer wg.Done()
+			locks, err := lister.ListLocks(ctx)
+			if err != nil {
+				logger.GetReqInfo(ctx).SetTags("peerAddress", lister.String())
+				logger.LogIf(ctx, err)
+				return
+			}
+			serverLocks[i] = locks
+		}(i, lister)
+	}
+	wg.Wait()
+
+	now := UTCNow()
+	entryMap := make(map[string]*madmin.LockEntry)
+	for i, locks := range serverLocks {
+		for _, l := range locks {
+			key := l.UID + "/" + l.Resource
+			if entry, ok := entryMap[key]; ok {
+				entry.ServerList = append(entry.ServerList, lockers[i].String())
+			} else {
+				entryMap[key] = lockInfoToLockEntry(l, lockers[i].String(), now)
+			}
+		}
+	}
+
+	var lockEntries madmin.LockEntries
+	for _, entry := range entryMap {
+		if stale || len(entry.ServerList) >= entry.Quorum {
+			lockEntries = append(lockEntries, *entry)
+		}
+	}
+	sort.Sort(lockEntries)
+	return lockEntries
+}
+
+// ListLocksHandler - GET /minio/admin/v3/locks?owner=server1:9000&prefix=bucket/&older-than=1h&count=10&stale=true
+// ----------
+// Returns the locks held in the cluster, oldest first, with their owner,
+// source and age. The lock servers are asked through the lock backend.
+func (a adminAPIHandlers) ListLocksHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListLocks")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := validateLocksReq(ctx, w, r, iampolicy.TopLocksAdminAction)
+	if z == nil {
+		return
+	}
+
+	query := r.URL.Query()
+	var (
+		count     int
+		olderThan time.Duration
+		err       error
+	)
+	if v := query.Get("count"); v != "" {
+		if count, err = strconv.Atoi(v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidQueryParams), r.URL)
+			return
+		}
+	}
+	if v := query.Get("older-than"); v != "" {
+		if olderThan, err = time.ParseDuration(v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidQueryParams), r.URL)
+			return
+		}
+	}
+	owner, prefix := query.Get("owner"), query.Get("prefix")
+
+	var locks madmin.LockEntries
+	for _, entry := range listClusterLocks(ctx, z.getClusterLockers(), query.Get("stale") == "true") {
+		if owner != "" && entry.Owner != owner {
+			continue
+		}
+		if !strings.HasPrefix(entry.Resource, prefix) || entry.Elapsed < olderThan {
+			continue
+		}
+		locks = append(locks, entry)
+	}
+	if count > 0 && len(locks) > count {
+		locks = locks[:count]
+	}
+
+	data, err := json.Marshal(locks)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReleaseLocksHandler - POST /minio/admin/v3/locks/release?owner=server1:9000
+// ----------
+// Forcibly releases the locks requested by a server which went down on all
+// the lock servers, the locks of an online server are not released.
+func (a adminAPIHandlers) ReleaseLocksHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReleaseLocks")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := validateLocksReq(ctx, w, r, iampolicy.ForceUnlockAdminAction)
+	if z == nil {
+		return
+	}
+
+	owner := r.URL.Query().Get("owner")
+	if owner == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidQueryParams), r.URL)
+		return
+	}
+	if owner == GetLocalPeer(globalEndpoints) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errLockOwnerOnline), r.URL)
+		return
+	}
+	if ep, ok := globalRemoteEndpoints[owner]; ok && isServerResolvable(ep, time.Second) == nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errLockOwnerOnline), r.URL)
+		return
+	}
+
+	// Release the locks on as many lock servers as possible,
+	// a failing lock server does not keep the others locked.
+	var errs []error
+	for _, locker := range z.getClusterLockers() {
+		if !locker.IsOnline() {
+			// The locks of an offline lock server are gone once it restarts.
+			continue
+		}
+		if _, err := locker.ForceUnlock(ctx, dsync.LockArgs{Owner: owner}); err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", locker.String())
+			logger.LogIf(ctx, err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errs[0]), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
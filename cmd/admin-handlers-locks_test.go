@@ -0,0 +1,92 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/pkg/dsync"
+)
+
+// namedLocker is a local locker named after a server.
+type namedLocker struct {
+	*localLocker
+	name string
+}
+
+func (l namedLocker) String() string {
+	return l.name
+}
+
+func TestListClusterLocks(t *testing.T) {
+	ctx := context.Background()
+	var lockers []dsync.NetLocker
+	for _, name := range []string{"server1:9000", "server2:9000", "server3:9000"} {
+		lockers = append(lockers, namedLocker{newLocker(), name})
+	}
+
+	lock := func(lockers []dsync.NetLocker, args dsync.LockArgs) {
+		for _, locker := range lockers {
+			if ok, err := locker.Lock(ctx, args); !ok || err != nil {
+				t.Fatalf("unable to lock %v on %s: %v", args.Resources, locker, err)
+			}
+		}
+	}
+	lock(lockers, dsync.LockArgs{UID: "1", Owner: "server1:9000", Source: "a.go", Quorum: 2, Resources: []string{"bucket/a"}})
+	lock(lockers[:1], dsync.LockArgs{UID: "2", Owner: "server2:9000", Source: "b.go", Quorum: 2, Resources: []string{"bucket/b"}})
+
+	locks := listClusterLocks(ctx, lockers, false)
+	if len(locks) != 1 {
+		t.Fatalf("expected 1 lock, got %+v", locks)
+	}
+	if l := locks[0]; l.Resource != "bucket/a" || l.Owner != "server1:9000" || l.Source != "a.go" ||
+		l.Type != "WRITE" || len(l.ServerList) != 3 || l.Elapsed < 0 {
+		t.Fatalf("unexpected lock %+v", l)
+	}
+
+	// The lock held by less lock servers than its quorum is stale.
+	if locks = listClusterLocks(ctx, lockers, true); len(locks) != 2 {
+		t.Fatalf("expected 2 locks, got %+v", locks)
+	}
+
+	for _, locker := range lockers {
+		if _, err := locker.ForceUnlock(ctx, dsync.LockArgs{Owner: "server1:9000"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if locks = listClusterLocks(ctx, lockers, true); len(locks) != 1 || locks[0].Owner != "server2:9000" {
+		t.Fatalf("expected the lock of server2:9000 only, got %+v", locks)
+	}
+}
+
+func TestLockBackendREST(t *testing.T) {
+	backend, ok := dsync.Backend(lockBackendREST)
+	if !ok {
+		t.Fatalf("expected the %s lock backend to be registered", lockBackendREST)
+	}
+
+	defer func(ls *localLocker) { globalLockServer = ls }(globalLockServer)
+	globalLockServer = newLocker()
+	if locker := backend(&url.URL{Scheme: "http", Host: "server1:9000"}, true); locker != dsync.NetLocker(globalLockServer) {
+		t.Fatalf("expected the local lock server, got %s", locker)
+	}
+	if _, ok := backend(&url.URL{Scheme: "http", Host: "server2:9000"}, false).(*lockRESTClient); !ok {
+		t.Fatal("expected a lock REST client")
+	}
+}
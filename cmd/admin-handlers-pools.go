@@ -0,0 +1,330 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// validatePoolsReq validates an admin request on server pools, returning
+// the server pools and the index of the pool of the request if any.
+func validatePoolsReq(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) (*erasureServerPools, int) {
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1
+	}
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, action)
+	if objAPI == nil {
+		return nil, -1
+	}
+
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1
+	}
+
+	pool, ok := mux.Vars(r)["pool"]
+	if !ok {
+		return z, -1
+	}
+	idx, err := z.GetPoolIdxByCmdLine(pool)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return nil, -1
+	}
+	return z, idx
+}
+
+// reloadPoolMetaOnPeers notifies peers to reload the status of the pools.
+func reloadPoolMetaOnPeers(ctx context.Context) {
+	for _, nerr := range globalNotificationSys.ReloadPoolMeta(ctx) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+// StartDecommission - POST /minio/admin/v3/pools/decommission?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Starts decommissioning the pool, the objects of the pool are moved to the
+// remaining pools and the pool is not written to anymore.
+func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if z == nil {
+		return
+	}
+
+	if err := z.Decommission(ctx, idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	reloadPoolMetaOnPeers(ctx)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CancelDecommission - POST /minio/admin/v3/pools/cancel?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Cancels decommissioning the pool, the pool is written to again.
+func (a adminAPIHandlers) CancelDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if z == nil {
+		return
+	}
+
+	if err := z.DecommissionCancel(ctx, idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	reloadPoolMetaOnPeers(ctx)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// StatusPool - GET /minio/admin/v3/pools/status?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Returns the status of the pool, including the progress of its decommission.
+func (a adminAPIHandlers) StatusPool(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StatusPool")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if z == nil {
+		return
+	}
+
+	status, err := z.PoolStatus(ctx, idx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListPools - GET /minio/admin/v3/pools/list
+// ----------
+// Returns the status of all pools.
+func (a adminAPIHandlers) ListPools(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListPools")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if z == nil {
+		return
+	}
+
+	pools := make([]madmin.PoolStatus, len(z.serverPools))
+	for idx := range z.serverPools {
+		status, err := z.PoolStatus(ctx, idx)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		pools[idx] = status
+	}
+
+	data, err := json.Marshal(pools)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RebalanceStart - POST /minio/admin/v3/rebalance/start?threshold=0.05&sleepFactor=2&maxSleep=1s
+// ----------
+// Starts rebalancing the pools, objects are moved out of the pools with less
+// free space than the goal. The parameters are optional. Returns the ID of
+// the rebalance.
+func (a adminAPIHandlers) RebalanceStart(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsReq(ctx, w, r, iampolicy.RebalanceAdminAction)
+	if z == nil {
+		return
+	}
+
+	var opts madmin.RebalanceOpts
+	var err error
+	query := r.URL.Query()
+	if v := query.Get("threshold"); v != "" {
+		if opts.Threshold, err = strconv.ParseFloat(v, 64); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+	if v := query.Get("sleepFactor"); v != "" {
+		if opts.SleepFactor, err = strconv.ParseFloat(v, 64); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+	if v := query.Get("maxSleep"); v != "" {
+		if opts.MaxSleep, err = time.ParseDuration(v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	id, err := z.StartRebalance(ctx, opts)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	reloadPoolMetaOnPeers(ctx)
+
+	data, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{
+		ID: id,
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RebalanceStatus - GET /minio/admin/v3/rebalance/status
+// ----------
+// Returns the status of the last rebalance.
+func (a adminAPIHandlers) RebalanceStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if z == nil {
+		return
+	}
+
+	status, err := z.RebalanceStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RebalanceStop - POST /minio/admin/v3/rebalance/stop
+// ----------
+// Stops the rebalance, the pools are written to again.
+func (a adminAPIHandlers) RebalanceStop(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStop")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsReq(ctx, w, r, iampolicy.RebalanceAdminAction)
+	if z == nil {
+		return
+	}
+
+	if err := z.StopRebalance(ctx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	reloadPoolMetaOnPeers(ctx)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// OfflineDrive - POST /minio/admin/v3/drives/offline?endpoint=http://server1/disk1
+// ----------
+// Marks the drive offline so that it can be replaced, the drive is not used
+// until it is replaced.
+func (a adminAPIHandlers) OfflineDrive(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "OfflineDrive")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsReq(ctx, w, r, iampolicy.ReplaceDriveAdminAction)
+	if z == nil {
+		return
+	}
+
+	if err := z.OfflineDrive(ctx, mux.Vars(r)["endpoint"]); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	reloadPoolMetaOnPeers(ctx)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ReplaceDrive - POST /minio/admin/v3/drives/replace?endpoint=http://server1/disk1
+// ----------
+// Brings back the drive marked offline once it was swapped, its erasure set
+// is healed onto it right away.
+func (a adminAPIHandlers) ReplaceDrive(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplaceDrive")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsReq(ctx, w, r, iampolicy.ReplaceDriveAdminAction)
+	if z == nil {
+		return
+	}
+
+	if err := z.ReplaceDrive(ctx, mux.Vars(r)["endpoint"]); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	reloadPoolMetaOnPeers(ctx)
+	writeSuccessResponseHeadersOnly(w)
+}
@@ -893,6 +893,7 @@ func getAggregatedBackgroundHealState(ctx context.Context) (madmin.BgHealState,
 		LastHealActivity:  bgHealStates[0].LastHealActivity,
 		NextHealRound:     bgHealStates[0].NextHealRound,
 		HealDisks:         bgHealStates[0].HealDisks,
+		HealingDisks:      bgHealStates[0].HealingDisks,
 	}
 
 	bgHealStates = bgHealStates[1:]
@@ -900,6 +901,7 @@ func getAggregatedBackgroundHealState(ctx context.Context) (madmin.BgHealState,
 	for _, state := range bgHealStates {
 		aggregatedHealStateResult.ScannedItemsCount += state.ScannedItemsCount
 		aggregatedHealStateResult.HealDisks = append(aggregatedHealStateResult.HealDisks, state.HealDisks...)
+		aggregatedHealStateResult.HealingDisks = append(aggregatedHealStateResult.HealingDisks, state.HealingDisks...)
 		if !state.LastHealActivity.IsZero() && aggregatedHealStateResult.LastHealActivity.Before(state.LastHealActivity) {
 			aggregatedHealStateResult.LastHealActivity = state.LastHealActivity
 			// The node which has the last heal activity means its
@@ -92,13 +92,26 @@ type allHealState struct {
 	// map of heal path to heal sequence
 	healSeqMap     map[string]*healSequence
 	healLocalDisks map[Endpoint]struct{}
+
+	// Local disks replaced through the admin API, they
+	// are healed before the other disks.
+	replacedLocalDisks map[Endpoint]struct{}
+
+	// Wakes up the healing of local disks.
+	healLocalDisksCh chan struct{}
+
+	// map of endpoint to the progress of healing the disk
+	healStatus map[string]madmin.HealingDisk
 }
 
 // newHealState - initialize global heal state management
 func newHealState(cleanup bool) *allHealState {
 	hstate := &allHealState{
-		healSeqMap:     make(map[string]*healSequence),
-		healLocalDisks: map[Endpoint]struct{}{},
+		healSeqMap:         make(map[string]*healSequence),
+		healLocalDisks:     map[Endpoint]struct{}{},
+		replacedLocalDisks: map[Endpoint]struct{}{},
+		healLocalDisksCh:   make(chan struct{}, 1),
+		healStatus:         make(map[string]madmin.HealingDisk),
 	}
 	if cleanup {
 		go hstate.periodicHealSeqsClean(GlobalContext)
@@ -130,7 +143,64 @@ func (ahs *allHealState) popHealLocalDisks(healLocalDisks ...Endpoint) {
 
 	for _, ep := range healLocalDisks {
 		delete(ahs.healLocalDisks, ep)
+		delete(ahs.replacedLocalDisks, ep)
+	}
+}
+
+// pushReplacedLocalDisks queues local disks replaced through the admin
+// API to be healed before the other disks, the healing starts right away.
+func (ahs *allHealState) pushReplacedLocalDisks(replacedLocalDisks ...Endpoint) {
+	ahs.Lock()
+	for _, ep := range replacedLocalDisks {
+		ahs.healLocalDisks[ep] = struct{}{}
+		ahs.replacedLocalDisks[ep] = struct{}{}
+	}
+	ahs.Unlock()
+
+	select {
+	case ahs.healLocalDisksCh <- struct{}{}:
+	default:
+	}
+}
+
+// isReplacedLocalDisk returns true if the disk was replaced through the admin API.
+func (ahs *allHealState) isReplacedLocalDisk(ep Endpoint) bool {
+	ahs.RLock()
+	defer ahs.RUnlock()
+
+	_, ok := ahs.replacedLocalDisks[ep]
+	return ok
+}
+
+// updateHealStatus records the progress of healing a disk.
+func (ahs *allHealState) updateHealStatus(d madmin.HealingDisk) {
+	ahs.Lock()
+	defer ahs.Unlock()
+
+	ahs.healStatus[d.Endpoint] = d
+}
+
+// popHealStatus removes the progress of a disk once healed.
+func (ahs *allHealState) popHealStatus(endpoint string) {
+	ahs.Lock()
+	defer ahs.Unlock()
+
+	delete(ahs.healStatus, endpoint)
+}
+
+// getHealStatus returns the progress of the disks being healed.
+func (ahs *allHealState) getHealStatus() []madmin.HealingDisk {
+	ahs.RLock()
+	defer ahs.RUnlock()
+
+	disks := make([]madmin.HealingDisk, 0, len(ahs.healStatus))
+	for _, d := range ahs.healStatus {
+		disks = append(disks, d)
 	}
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].Endpoint < disks[j].Endpoint
+	})
+	return disks
 }
 
 func (ahs *allHealState) pushHealLocalDisks(healLocalDisks ...Endpoint) {
@@ -74,6 +74,20 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 			/// Health operations
 
+			// Pool decommission operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decommission").HandlerFunc(httpTraceAll(adminAPI.StartDecommission)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/cancel").HandlerFunc(httpTraceAll(adminAPI.CancelDecommission)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/pools/status").HandlerFunc(httpTraceAll(adminAPI.StatusPool)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/pools/list").HandlerFunc(httpTraceAll(adminAPI.ListPools))
+
+			// Drive replacement operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drives/offline").HandlerFunc(httpTraceAll(adminAPI.OfflineDrive)).Queries("endpoint", "{endpoint:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drives/replace").HandlerFunc(httpTraceAll(adminAPI.ReplaceDrive)).Queries("endpoint", "{endpoint:.*}")
+
+			// Pool rebalance operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/start").HandlerFunc(httpTraceAll(adminAPI.RebalanceStart))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rebalance/status").HandlerFunc(httpTraceAll(adminAPI.RebalanceStatus))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/stop").HandlerFunc(httpTraceAll(adminAPI.RebalanceStop))
 		}
 
 		// Profiling operations
@@ -147,6 +161,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 				HandlerFunc(httpTraceHdrs(adminAPI.SetPolicyForUserOrGroup)).
 				Queries("policyName", "{policyName:.*}", "userOrGroup", "{userOrGroup:.*}", "isGroup", "{isGroup:true|false}")
 
+			// Simulate a request against the policies of a user
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/simulate-policy").HandlerFunc(httpTraceHdrs(adminAPI.SimulatePolicy))
+
 			// Remove user IAM
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-user").HandlerFunc(httpTraceHdrs(adminAPI.RemoveUser)).Queries("accessKey", "{accessKey:.*}")
 
@@ -177,6 +194,20 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-quota").HandlerFunc(
 				httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler)).Queries("bucket", "{bucket:.*}")
 
+			// GetBucketStorageClassConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-storage-class").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketStorageClassConfigHandler)).Queries("bucket", "{bucket:.*}")
+			// PutBucketStorageClassConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-storage-class").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketStorageClassConfigHandler)).Queries("bucket", "{bucket:.*}")
+
+			// GetBucketCompressionConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-compression").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketCompressionConfigHandler)).Queries("bucket", "{bucket:.*}")
+			// PutBucketCompressionConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-compression").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketCompressionConfigHandler)).Queries("bucket", "{bucket:.*}")
+
 			// Bucket replication operations
 			// GetBucketTargetHandler
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-remote-targets").HandlerFunc(
@@ -187,11 +218,33 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			// RemoveRemoteTargetHandler
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-remote-target").HandlerFunc(
 				httpTraceHdrs(adminAPI.RemoveRemoteTargetHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+
+			// Replication resync of existing objects
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication-resync").HandlerFunc(
+				httpTraceHdrs(adminAPI.StartReplicationResyncHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-resync").HandlerFunc(
+				httpTraceHdrs(adminAPI.ReplicationResyncStatusHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/replication-resync").HandlerFunc(
+				httpTraceHdrs(adminAPI.CancelReplicationResyncHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			// BucketReplicationMetricsHandler
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-metrics").HandlerFunc(
+				httpTraceHdrs(adminAPI.BucketReplicationMetricsHandler)).Queries("bucket", "{bucket:.*}")
+			// BucketReplicationDiffHandler
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication-diff").HandlerFunc(
+				httpTraceHdrs(adminAPI.BucketReplicationDiffHandler)).Queries("bucket", "{bucket:.*}")
+
+			// Remote tier operations
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier").HandlerFunc(httpTraceHdrs(adminAPI.AddTierHandler))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier").HandlerFunc(httpTraceHdrs(adminAPI.ListTierHandler))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/tier/{tier}").HandlerFunc(httpTraceHdrs(adminAPI.EditTierHandler))
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/tier/{tier}").HandlerFunc(httpTraceHdrs(adminAPI.RemoveTierHandler))
 		}
 
 		if globalIsDistErasure {
 			// Top locks
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/top/locks").HandlerFunc(httpTraceHdrs(adminAPI.TopLocksHandler))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/locks").HandlerFunc(httpTraceHdrs(adminAPI.ListLocksHandler))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/locks/release").HandlerFunc(httpTraceHdrs(adminAPI.ReleaseLocksHandler))
 			// Force unlocks paths
 			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/force-unlock").
 				Queries("paths", "{paths:.*}").HandlerFunc(httpTraceHdrs(adminAPI.ForceUnlockHandler))
@@ -37,6 +37,10 @@ type DeletedObject struct {
 	VersionPurgeStatus VersionPurgeStatusType `xml:"VersionPurgeStatus,omitempty"`
 	// PurgeTransitioned is nonempty if object is in transition tier
 	PurgeTransitioned string `xml:"PurgeTransitioned,omitempty"`
+	// Remote tier and name of the object on it, set only when the
+	// object is in transition tier
+	TransitionTier      string `xml:"-"`
+	TransitionedObjName string `xml:"-"`
 }
 
 // DeleteMarkerMTime is an embedded type containing time.Time for XML marshal
@@ -65,6 +69,10 @@ type ObjectToDelete struct {
 	DeleteMarkerVersionID string `xml:"DeleteMarkerVersionId"`
 	// PurgeTransitioned is nonempty if object is in transition tier
 	PurgeTransitioned string `xml:"PurgeTransitioned"`
+	// Remote tier and name of the object on it, set only when the
+	// object is in transition tier
+	TransitionTier      string `xml:"-"`
+	TransitionedObjName string `xml:"-"`
 }
 
 // createBucketConfiguration container for bucket configuration request from client.
@@ -33,8 +33,11 @@ import (
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/cors"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/website"
 
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
@@ -105,7 +108,9 @@ const (
 	ErrNoSuchLifecycleConfiguration
 	ErrNoSuchBucketSSEConfig
 	ErrNoSuchCORSConfiguration
+	ErrCORSForbidden
 	ErrNoSuchWebsiteConfiguration
+	ErrInvalidTargetBucketForLogging
 	ErrReplicationConfigurationNotFoundError
 	ErrRemoteDestinationNotFoundError
 	ErrReplicationDestinationMissingLock
@@ -121,6 +126,8 @@ const (
 	ErrReplicationSourceNotVersionedError
 	ErrReplicationNeedsVersioningError
 	ErrReplicationBucketNeedsVersioningError
+	ErrReplicationResyncInProgress
+	ErrNoSuchReplicationResync
 	ErrObjectRestoreAlreadyInProgress
 	ErrNoSuchKey
 	ErrNoSuchUpload
@@ -220,6 +227,9 @@ const (
 
 	// S3 extended errors.
 	ErrContentSHA256Mismatch
+	ErrContentChecksumMismatch
+	ErrInvalidChecksum
+	ErrInvalidObjectAttributes
 
 	// Add new extended error codes here.
 
@@ -263,6 +273,10 @@ const (
 	// Bucket Quota error codes
 	ErrAdminBucketQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
+	// Bucket storage class error codes
+	ErrAdminNoSuchStorageClassConfiguration
+	// Bucket compression error codes
+	ErrAdminNoSuchCompressionConfiguration
 
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
@@ -813,11 +827,21 @@ var errorCodes = errorCodeMap{
 		Description:    "The CORS configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evaluation of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrNoSuchWebsiteConfiguration: {
 		Code:           "NoSuchWebsiteConfiguration",
 		Description:    "The specified bucket does not have a website configuration",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist or is not writable by the requester",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrReplicationConfigurationNotFoundError: {
 		Code:           "ReplicationConfigurationNotFoundError",
 		Description:    "The replication configuration was not found",
@@ -893,6 +917,16 @@ var errorCodes = errorCodeMap{
 		Description:    "Versioning must be 'Enabled' on the bucket to add a replication target",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrReplicationResyncInProgress: {
+		Code:           "XMinioAdminReplicationResyncInProgress",
+		Description:    "A replication resync to the remote target is already in progress",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrNoSuchReplicationResync: {
+		Code:           "XMinioAdminNoSuchReplicationResync",
+		Description:    "No replication resync was started for the remote target",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrNoSuchObjectLockConfiguration: {
 		Code:           "NoSuchObjectLockConfiguration",
 		Description:    "The specified object does not have a ObjectLock configuration",
@@ -1086,6 +1120,21 @@ var errorCodes = errorCodeMap{
 		Description:    "The provided 'x-amz-content-sha256' header does not match what was computed.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrContentChecksumMismatch: {
+		Code:           "BadDigest",
+		Description:    "The x-amz-checksum header you specified did not match what was computed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidRequest",
+		Description:    "The x-amz-checksum header or the checksum algorithm you specified is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidObjectAttributes: {
+		Code:           "InvalidArgument",
+		Description:    "The x-amz-object-attributes header you specified is missing or has an invalid attribute name.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	/// MinIO extensions.
 	ErrStorageFull: {
@@ -1214,6 +1263,16 @@ var errorCodes = errorCodeMap{
 		Description:    "The quota configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrAdminNoSuchStorageClassConfiguration: {
+		Code:           "XMinioAdminNoSuchStorageClassConfiguration",
+		Description:    "The storage class configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminNoSuchCompressionConfiguration: {
+		Code:           "XMinioAdminNoSuchCompressionConfiguration",
+		Description:    "The compression configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1836,6 +1895,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrObjectLockInvalidHeaders
 	case objectlock.ErrMalformedXML:
 		apiErr = ErrMalformedXML
+	case hash.ErrInvalidChecksum:
+		apiErr = ErrInvalidChecksum
 	}
 
 	// Compression errors
@@ -1918,6 +1979,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrSignatureDoesNotMatch
 	case hash.SHA256Mismatch:
 		apiErr = ErrContentSHA256Mismatch
+	case hash.ChecksumMismatch:
+		apiErr = ErrContentChecksumMismatch
 	case ObjectTooLarge:
 		apiErr = ErrEntityTooLarge
 	case ObjectTooSmall:
@@ -1934,12 +1997,20 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchLifecycleConfiguration
 	case BucketSSEConfigNotFound:
 		apiErr = ErrNoSuchBucketSSEConfig
+	case BucketCorsNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
 		apiErr = ErrAdminNoSuchQuotaConfiguration
+	case BucketStorageClassConfigNotFound:
+		apiErr = ErrAdminNoSuchStorageClassConfiguration
+	case BucketCompressionConfigNotFound:
+		apiErr = ErrAdminNoSuchCompressionConfiguration
 	case BucketReplicationConfigNotFound:
 		apiErr = ErrReplicationConfigurationNotFoundError
 	case BucketRemoteDestinationNotFound:
@@ -1964,6 +2035,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrRemoteTargetNotVersionedError
 	case BucketReplicationSourceNotVersioned:
 		apiErr = ErrReplicationSourceNotVersionedError
+	case BucketReplicationResyncInProgress:
+		apiErr = ErrReplicationResyncInProgress
+	case BucketReplicationResyncNotFound:
+		apiErr = ErrNoSuchReplicationResync
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
 	case *event.ErrInvalidEventName:
@@ -2082,6 +2157,24 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case cors.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case logging.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case website.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case tags.Error:
 			apiErr = APIError{
 				Code:           e.Code(),
@@ -164,6 +164,11 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		return err
 	}
 
+	// Set the additional checksum, which only applies to the whole object.
+	if opts.WantChecksum && rs == nil && opts.PartNumber == 0 {
+		setChecksumHeaders(w, getObjectChecksum(objInfo))
+	}
+
 	if rs == nil && opts.PartNumber > 0 {
 		rs = partNumberToRangeSpec(objInfo, opts.PartNumber)
 	}
@@ -31,6 +31,7 @@ import (
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
 )
 
 const (
@@ -343,6 +344,46 @@ type CompleteMultipartUploadResponse struct {
 	Bucket   string
 	Key      string
 	ETag     string
+	Checksum
+}
+
+// Checksum container for the additional checksum of an object or a part,
+// at most one of the checksums is set.
+type Checksum struct {
+	ChecksumCRC32  string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumSHA1   string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256,omitempty"`
+}
+
+// GetObjectAttributesResponse container for GetObjectAttributes response,
+// only the requested attributes are set.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesOutput" json:"-"`
+
+	ETag         string                 `xml:",omitempty"`
+	Checksum     *Checksum              `xml:",omitempty"`
+	ObjectParts  *ObjectAttributesParts `xml:",omitempty"`
+	StorageClass string                 `xml:",omitempty"`
+	ObjectSize   *int64                 `xml:",omitempty"`
+}
+
+// ObjectAttributesParts container for the parts of a multipart object in
+// GetObjectAttributes response.
+type ObjectAttributesParts struct {
+	IsTruncated          bool
+	MaxParts             int
+	NextPartNumberMarker int
+	PartNumberMarker     int
+	PartsCount           int
+	Parts                []ObjectAttributesPart `xml:"Part"`
+}
+
+// ObjectAttributesPart container for a part in GetObjectAttributes response.
+type ObjectAttributesPart struct {
+	Checksum
+	PartNumber int
+	Size       int64
 }
 
 // DeleteError structure.
@@ -612,6 +653,25 @@ func generateCopyObjectPartResponse(etag string, lastModified time.Time) CopyObj
 	}
 }
 
+// generates Checksum for the additional checksum of an object or a part.
+func generateChecksumResponse(c *hash.Checksum) Checksum {
+	var checksum Checksum
+	if c == nil {
+		return checksum
+	}
+	switch c.Type {
+	case hash.ChecksumCRC32:
+		checksum.ChecksumCRC32 = c.Encoded
+	case hash.ChecksumCRC32C:
+		checksum.ChecksumCRC32C = c.Encoded
+	case hash.ChecksumSHA1:
+		checksum.ChecksumSHA1 = c.Encoded
+	case hash.ChecksumSHA256:
+		checksum.ChecksumSHA256 = c.Encoded
+	}
+	return checksum
+}
+
 // generates InitiateMultipartUploadResponse for given bucket, key and uploadID.
 func generateInitiateMultipartUploadResponse(bucket, key, uploadID string) InitiateMultipartUploadResponse {
 	return InitiateMultipartUploadResponse{
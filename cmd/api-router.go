@@ -89,6 +89,17 @@ func registerAPIRouter(router *mux.Router) {
 	// API Router
 	apiRouter := router.PathPrefix(SlashSeparator).Subrouter()
 
+	// Website Router, registered ahead of the bucket DNS style
+	// routers since `<bucket>.s3-website.<domain>` would otherwise
+	// be routed as a bucket named `<bucket>.s3-website`.
+	for _, domainName := range globalDomainNames {
+		website := apiRouter.Host("{bucket:.+}." + websiteDomainPrefix + domainName).Subrouter()
+		website.Methods(http.MethodGet, http.MethodHead).Path("/{object:.*}").HandlerFunc(
+			collectAPIStats("website", maxClients(httpTraceHdrs(api.WebsiteHandler))))
+		website.NewRoute().HandlerFunc(
+			collectAPIStats("methodnotallowed", httpTraceAll(methodNotAllowedHandler("Website"))))
+	}
+
 	var routers []*mux.Router
 	for _, domainName := range globalDomainNames {
 		if IsKubernetes() {
@@ -165,6 +176,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetObjectLegalHold
 		bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobjectlegalhold", maxClients(httpTraceAll(api.GetObjectLegalHoldHandler)))).Queries("legal-hold", "")
+		// GetObjectAttributes
+		bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectattributes", maxClients(httpTraceHdrs(api.GetObjectAttributesHandler)))).Queries("attributes", "")
 		// GetObject
 		bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobject", maxClients(httpTraceHdrs(api.GetObjectHandler))))
@@ -201,6 +215,15 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketObjectLockConfig
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketobjectlockconfiguration", maxClients(httpTraceAll(api.GetBucketObjectLockConfigHandler)))).Queries("object-lock", "")
+		// GetBucketCors
+		bucket.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketcors", maxClients(httpTraceAll(api.GetBucketCorsHandler)))).Queries("cors", "")
+		// GetBucketWebsite
+		bucket.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketwebsite", maxClients(httpTraceAll(api.GetBucketWebsiteHandler)))).Queries("website", "")
+		// GetBucketLogging
+		bucket.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketlogging", maxClients(httpTraceAll(api.GetBucketLoggingHandler)))).Queries("logging", "")
 		// GetBucketReplicationConfig
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketreplicationconfiguration", maxClients(httpTraceAll(api.GetBucketReplicationConfigHandler)))).Queries("replication", "")
@@ -222,30 +245,18 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketACL -- this is a dummy call.
 		bucket.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketacl", maxClients(httpTraceAll(api.PutBucketACLHandler)))).Queries("acl", "")
-		// GetBucketCors - this is a dummy call.
-		bucket.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketcors", maxClients(httpTraceAll(api.GetBucketCorsHandler)))).Queries("cors", "")
-		// GetBucketWebsiteHandler - this is a dummy call.
-		bucket.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketwebsite", maxClients(httpTraceAll(api.GetBucketWebsiteHandler)))).Queries("website", "")
 		// GetBucketAccelerateHandler - this is a dummy call.
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketaccelerate", maxClients(httpTraceAll(api.GetBucketAccelerateHandler)))).Queries("accelerate", "")
 		// GetBucketRequestPaymentHandler - this is a dummy call.
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketrequestpayment", maxClients(httpTraceAll(api.GetBucketRequestPaymentHandler)))).Queries("requestPayment", "")
-		// GetBucketLoggingHandler - this is a dummy call.
-		bucket.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketlogging", maxClients(httpTraceAll(api.GetBucketLoggingHandler)))).Queries("logging", "")
 		// GetBucketLifecycleHandler - this is a dummy call.
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketlifecycle", maxClients(httpTraceAll(api.GetBucketLifecycleHandler)))).Queries("lifecycle", "")
 		// GetBucketTaggingHandler
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbuckettagging", maxClients(httpTraceAll(api.GetBucketTaggingHandler)))).Queries("tagging", "")
-		//DeleteBucketWebsiteHandler
-		bucket.Methods(http.MethodDelete).HandlerFunc(
-			collectAPIStats("deletebucketwebsite", maxClients(httpTraceAll(api.DeleteBucketWebsiteHandler)))).Queries("website", "")
 		// DeleteBucketTaggingHandler
 		bucket.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebuckettagging", maxClients(httpTraceAll(api.DeleteBucketTaggingHandler)))).Queries("tagging", "")
@@ -268,6 +279,15 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketLifecycle
 		bucket.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketlifecycle", maxClients(httpTraceAll(api.PutBucketLifecycleHandler)))).Queries("lifecycle", "")
+		// PutBucketCors
+		bucket.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketcors", maxClients(httpTraceAll(api.PutBucketCorsHandler)))).Queries("cors", "")
+		// PutBucketWebsite
+		bucket.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketwebsite", maxClients(httpTraceAll(api.PutBucketWebsiteHandler)))).Queries("website", "")
+		// PutBucketLogging
+		bucket.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketlogging", maxClients(httpTraceAll(api.PutBucketLoggingHandler)))).Queries("logging", "")
 		// PutBucketReplicationConfig
 		bucket.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketreplicationconfiguration", maxClients(httpTraceAll(api.PutBucketReplicationConfigHandler)))).Queries("replication", "")
@@ -311,6 +331,12 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketReplication
 		bucket.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketreplicationconfiguration", maxClients(httpTraceAll(api.DeleteBucketReplicationConfigHandler)))).Queries("replication", "")
+		// DeleteBucketCors
+		bucket.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketcors", maxClients(httpTraceAll(api.DeleteBucketCorsHandler)))).Queries("cors", "")
+		// DeleteBucketWebsite
+		bucket.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketwebsite", maxClients(httpTraceAll(api.DeleteBucketWebsiteHandler)))).Queries("website", "")
 		// DeleteBucketLifecycle
 		bucket.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketlifecycle", maxClients(httpTraceAll(api.DeleteBucketLifecycleHandler)))).Queries("lifecycle", "")
@@ -346,7 +372,9 @@ func registerAPIRouter(router *mux.Router) {
 
 }
 
-// corsHandler handler for CORS (Cross Origin Resource Sharing)
+// corsHandler handler for CORS (Cross Origin Resource Sharing),
+// buckets with a CORS configuration are served as per their own
+// rules, all other requests use the global CORS settings.
 func corsHandler(handler http.Handler) http.Handler {
 	commonS3Headers := []string{
 		xhttp.Date,
@@ -370,7 +398,7 @@ func corsHandler(handler http.Handler) http.Handler {
 		"*",
 	}
 
-	return cors.New(cors.Options{
+	globalCorsHandler := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			for _, allowedOrigin := range globalAPIConfig.getCorsAllowOrigins() {
 				if wildcard.MatchSimple(allowedOrigin, origin) {
@@ -392,4 +420,12 @@ func corsHandler(handler http.Handler) http.Handler {
 		ExposedHeaders:   commonS3Headers,
 		AllowCredentials: true,
 	}).Handler(handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if config := getBucketCorsConfig(r); config != nil {
+			serveBucketCors(w, r, config, handler)
+			return
+		}
+		globalCorsHandler.ServeHTTP(w, r)
+	})
 }
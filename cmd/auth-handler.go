@@ -36,6 +36,7 @@ import (
 	"github.com/minio/minio/pkg/auth"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 	"github.com/minio/minio/pkg/hash"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 )
@@ -338,13 +339,28 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		logger.GetReqInfo(ctx).AccessKey = cred.AccessKey
 	}
 
+	// Tags of the existing object are only loaded when a policy has
+	// a condition on them.
+	needsExistingObjectTags := objectName != "" && action.SupportsConditionKey(condition.S3ExistingObjectTag)
+
 	if action != policy.ListAllMyBucketsAction && cred.AccessKey == "" {
+		conditionValues := getConditionValues(r, locationConstraint, "", nil)
+		if needsExistingObjectTags {
+			if p, err := globalPolicySys.Get(bucketName); err == nil && hasConditionKey(p.ConditionKeys(), condition.S3ExistingObjectTag) {
+				userTags, err := getExistingObjectTags(ctx, r, bucketName, objectName)
+				if err != nil {
+					return accessKey, owner, toAPIErrorCode(ctx, err)
+				}
+				setExistingObjectTagValues(conditionValues, userTags)
+			}
+		}
+
 		// Anonymous checks are not meant for ListBuckets action
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          action,
 			BucketName:      bucketName,
-			ConditionValues: getConditionValues(r, locationConstraint, "", nil),
+			ConditionValues: conditionValues,
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
@@ -359,7 +375,7 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 				AccountName:     cred.AccessKey,
 				Action:          policy.ListBucketAction,
 				BucketName:      bucketName,
-				ConditionValues: getConditionValues(r, locationConstraint, "", nil),
+				ConditionValues: conditionValues,
 				IsOwner:         false,
 				ObjectName:      objectName,
 			}) {
@@ -371,11 +387,20 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		return cred.AccessKey, owner, ErrAccessDenied
 	}
 
+	conditionValues := getConditionValues(r, "", cred.AccessKey, claims)
+	if needsExistingObjectTags && !owner && globalIAMSys.hasExistingObjectTagCondition(claims) {
+		userTags, err := getExistingObjectTags(ctx, r, bucketName, objectName)
+		if err != nil {
+			return cred.AccessKey, owner, toAPIErrorCode(ctx, err)
+		}
+		setExistingObjectTagValues(conditionValues, userTags)
+	}
+
 	if globalIAMSys.IsAllowed(iampolicy.Args{
 		AccountName:     cred.AccessKey,
 		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
-		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+		ConditionValues: conditionValues,
 		ObjectName:      objectName,
 		IsOwner:         owner,
 		Claims:          claims,
@@ -391,7 +416,7 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 			AccountName:     cred.AccessKey,
 			Action:          iampolicy.ListBucketAction,
 			BucketName:      bucketName,
-			ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+			ConditionValues: conditionValues,
 			ObjectName:      objectName,
 			IsOwner:         owner,
 			Claims:          claims,
@@ -404,6 +429,31 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 	return cred.AccessKey, owner, ErrAccessDenied
 }
 
+// getExistingObjectTags - returns the tags of the object of the request,
+// empty if the object does not exist. Any other error is returned, so that
+// a policy conditioned on the tags is not evaluated without them.
+func getExistingObjectTags(ctx context.Context, r *http.Request, bucket, object string) (string, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return "", errServerNotInitialized
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		return "", err
+	}
+
+	objInfo, err := objectAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		if objInfo.DeleteMarker || isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return objInfo.UserTags, nil
+}
+
 // Verify if request has valid AWS Signature Version '2'.
 func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
 	if isRequestSignatureV2(r) {
@@ -27,18 +27,171 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/color"
 	"github.com/minio/minio/pkg/console"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 const (
 	defaultMonitorNewDiskInterval = time.Second * 10
 	healingTrackerFilename        = ".healing.bin"
+
+	// Interval at which the progress of healing a disk is saved on it.
+	healingTrackerSaveInterval = time.Minute
 )
 
 //go:generate msgp -file $GOFILE -unexported
 type healingTracker struct {
 	ID string
 
-	// future add more tracking capabilities
+	Endpoint   string
+	PoolIndex  int
+	SetIndex   int
+	DiskIndex  int
+	Replaced   bool
+	Started    time.Time
+	LastUpdate time.Time
+	Bucket     string
+
+	ObjectsHealed uint64
+	ObjectsFailed uint64
+	BytesDone     uint64
+	BytesFailed   uint64
+	BytesTotal    uint64
+
+	// Buckets healed entirely, skipped when healing is resumed.
+	HealedBuckets []string
+
+	disk  StorageAPI `msg:"-"`
+	saved time.Time  `msg:"-"`
+}
+
+// newHealingTracker returns the tracker of healing the disk at
+// the position of the erasure set.
+func newHealingTracker(disk StorageAPI, diskID string, poolIdx, setIdx, diskIdx int) *healingTracker {
+	now := UTCNow()
+	return &healingTracker{
+		ID:         diskID,
+		Endpoint:   disk.Endpoint().String(),
+		PoolIndex:  poolIdx,
+		SetIndex:   setIdx,
+		DiskIndex:  diskIdx,
+		Started:    now,
+		LastUpdate: now,
+		disk:       disk,
+	}
+}
+
+// loadHealingTracker reads the tracker saved on the disk.
+func loadHealingTracker(ctx context.Context, disk StorageAPI) (*healingTracker, error) {
+	htrackerBytes, err := disk.ReadAll(ctx, minioMetaBucket,
+		pathJoin(bucketMetaPrefix, slashSeparator, healingTrackerFilename))
+	if err != nil {
+		return nil, err
+	}
+	var h healingTracker
+	if _, err = h.UnmarshalMsg(htrackerBytes); err != nil {
+		return nil, err
+	}
+	h.disk = disk
+	return &h, nil
+}
+
+// save writes the tracker to its disk.
+func (h *healingTracker) save(ctx context.Context) error {
+	htrackerBytes, err := h.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	h.saved = UTCNow()
+	return h.disk.WriteAll(ctx, minioMetaBucket,
+		pathJoin(bucketMetaPrefix, slashSeparator, healingTrackerFilename),
+		htrackerBytes)
+}
+
+// update publishes the progress of the heal, it is saved on the
+// disk every healingTrackerSaveInterval.
+func (h *healingTracker) update(ctx context.Context) {
+	h.LastUpdate = UTCNow()
+	globalBackgroundHealState.updateHealStatus(h.toHealingDisk())
+	if h.LastUpdate.Sub(h.saved) >= healingTrackerSaveInterval {
+		logger.LogIf(ctx, h.save(ctx))
+	}
+}
+
+// isBucketHealed returns true if the bucket was healed entirely.
+func (h *healingTracker) isBucketHealed(bucket string) bool {
+	for _, b := range h.HealedBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// bucketHealed records that the bucket was healed entirely and saves the
+// tracker, healing resumes after it when the server is restarted.
+func (h *healingTracker) bucketHealed(ctx context.Context, bucket string) {
+	h.HealedBuckets = append(h.HealedBuckets, bucket)
+	h.LastUpdate = UTCNow()
+	globalBackgroundHealState.updateHealStatus(h.toHealingDisk())
+	logger.LogIf(ctx, h.save(ctx))
+}
+
+// objectHealed records the result of healing an object of the size.
+func (h *healingTracker) objectHealed(size int64, err error) {
+	if err != nil {
+		h.ObjectsFailed++
+		h.BytesFailed += uint64(size)
+		return
+	}
+	h.ObjectsHealed++
+	h.BytesDone += uint64(size)
+}
+
+// toHealingDisk returns the progress of the heal to report through madmin,
+// the completion time is extrapolated from the bytes healed so far.
+func (h *healingTracker) toHealingDisk() madmin.HealingDisk {
+	d := madmin.HealingDisk{
+		ID:            h.ID,
+		Endpoint:      h.Endpoint,
+		PoolIndex:     h.PoolIndex,
+		SetIndex:      h.SetIndex,
+		DiskIndex:     h.DiskIndex,
+		Replaced:      h.Replaced,
+		Started:       h.Started,
+		LastUpdate:    h.LastUpdate,
+		Bucket:        h.Bucket,
+		ObjectsHealed: h.ObjectsHealed,
+		ObjectsFailed: h.ObjectsFailed,
+		BytesDone:     h.BytesDone,
+		BytesFailed:   h.BytesFailed,
+		BytesTotal:    h.BytesTotal,
+	}
+	done := h.BytesDone + h.BytesFailed
+	if done > 0 && h.BytesTotal > done {
+		elapsed := h.LastUpdate.Sub(h.Started)
+		remaining := float64(elapsed) * float64(h.BytesTotal-done) / float64(done)
+		d.ETA = h.LastUpdate.Add(time.Duration(remaining))
+	}
+	return d
+}
+
+// estimateHealBytes estimates the size of the objects of an erasure set from
+// the space used on its drives, each drive holds a share of every object.
+func estimateHealBytes(ctx context.Context, disks []StorageAPI, dataDrives int) uint64 {
+	var used uint64
+	for _, disk := range disks {
+		if disk == nil {
+			continue
+		}
+		info, err := disk.DiskInfo(ctx)
+		if err != nil || info.Healing {
+			continue
+		}
+		if info.Used > used {
+			used = info.Used
+		}
+	}
+	return used * uint64(dataDrives)
 }
 
 func initAutoHeal(ctx context.Context, objAPI ObjectLayer) {
@@ -80,11 +233,16 @@ func initAutoHeal(ctx context.Context, objAPI ObjectLayer) {
 }
 
 func getLocalDisksToHeal() (disksToHeal Endpoints) {
+	z, _ := newObjectLayerFn().(*erasureServerPools)
 	for _, ep := range globalEndpoints {
 		for _, endpoint := range ep.Endpoints {
 			if !endpoint.IsLocal {
 				continue
 			}
+			if z != nil && z.isDriveOffline(endpoint) {
+				// Healed once replaced.
+				continue
+			}
 			// Try to connect to the current endpoint
 			// and reformat if the current disk is not formatted
 			disk, _, err := connectEndpoint(endpoint)
@@ -107,9 +265,19 @@ func initBackgroundHealing(ctx context.Context, objAPI ObjectLayer) {
 	globalBackgroundHealState.LaunchNewHealSequence(newBgHealSequence(), objAPI)
 }
 
+//msgp:ignore healDiskEntry
+
+// healDiskEntry is a local disk to heal at its position in the server pools.
+type healDiskEntry struct {
+	disk                     StorageAPI
+	poolIdx, setIdx, diskIdx int
+	replaced                 bool
+}
+
 // monitorLocalDisksAndHeal - ensures that detected new disks are healed
 //  1. Only the concerned erasure set will be listed and healed
 //  2. Only the node hosting the disk is responsible to perform the heal
+//  3. Disks replaced through the admin API are healed right away, first
 func monitorLocalDisksAndHeal(ctx context.Context, z *erasureServerPools, bgSeq *healSequence) {
 	// Perform automatic disk healing when a disk is replaced locally.
 	diskCheckTimer := time.NewTimer(defaultMonitorNewDiskInterval)
@@ -120,109 +288,143 @@ wait:
 		case <-ctx.Done():
 			return
 		case <-diskCheckTimer.C:
-			// Reset to next interval.
-			diskCheckTimer.Reset(defaultMonitorNewDiskInterval)
+		case <-globalBackgroundHealState.healLocalDisksCh:
+			if !diskCheckTimer.Stop() {
+				<-diskCheckTimer.C
+			}
+		}
+
+		// Reset to next interval.
+		diskCheckTimer.Reset(defaultMonitorNewDiskInterval)
 
-			var erasureSetInPoolDisksToHeal []map[int][]StorageAPI
+		healDisks := globalBackgroundHealState.getHealLocalDisks()
+		if len(healDisks) > 0 {
+			// Reformat disks
+			bgSeq.sourceCh <- healSource{bucket: SlashSeparator}
 
-			healDisks := globalBackgroundHealState.getHealLocalDisks()
-			if len(healDisks) > 0 {
-				// Reformat disks
-				bgSeq.sourceCh <- healSource{bucket: SlashSeparator}
+			// Ensure that reformatting disks is finished
+			bgSeq.sourceCh <- healSource{bucket: nopHeal}
 
-				// Ensure that reformatting disks is finished
-				bgSeq.sourceCh <- healSource{bucket: nopHeal}
+			logger.Info(fmt.Sprintf("Found drives to heal %d, proceeding to heal content...",
+				len(healDisks)))
+		}
 
-				logger.Info(fmt.Sprintf("Found drives to heal %d, proceeding to heal content...",
-					len(healDisks)))
+		if serverDebugLog {
+			console.Debugf(color.Green("healDisk:")+" disk check timer fired, attempting to heal %d drives\n", len(healDisks))
+		}
 
-				erasureSetInPoolDisksToHeal = make([]map[int][]StorageAPI, len(z.serverPools))
-				for i := range z.serverPools {
-					erasureSetInPoolDisksToHeal[i] = map[int][]StorageAPI{}
-				}
+		// heal only if new disks found.
+		var disksToHeal []healDiskEntry
+		for _, endpoint := range healDisks {
+			if z.isDriveOffline(endpoint) {
+				// The disk is healed once replaced.
+				globalBackgroundHealState.popHealLocalDisks(endpoint)
+				continue
 			}
 
-			if serverDebugLog {
-				console.Debugf(color.Green("healDisk:")+" disk check timer fired, attempting to heal %d drives\n", len(healDisks))
+			disk, format, err := connectEndpoint(endpoint)
+			if err != nil {
+				printEndpointError(endpoint, err, true)
+				continue
 			}
 
-			// heal only if new disks found.
-			for _, endpoint := range healDisks {
-				disk, format, err := connectEndpoint(endpoint)
-				if err != nil {
-					printEndpointError(endpoint, err, true)
-					continue
-				}
-
-				poolIdx := globalEndpoints.GetLocalPoolIdx(disk.Endpoint())
-				if poolIdx < 0 {
-					continue
-				}
-
-				// Calculate the set index where the current endpoint belongs
-				z.serverPools[poolIdx].erasureDisksMu.RLock()
-				// Protect reading reference format.
-				setIndex, _, err := findDiskIndex(z.serverPools[poolIdx].format, format)
-				z.serverPools[poolIdx].erasureDisksMu.RUnlock()
-				if err != nil {
-					printEndpointError(endpoint, err, false)
-					continue
-				}
-
-				erasureSetInPoolDisksToHeal[poolIdx][setIndex] = append(erasureSetInPoolDisksToHeal[poolIdx][setIndex], disk)
+			poolIdx := globalEndpoints.GetLocalPoolIdx(disk.Endpoint())
+			if poolIdx < 0 {
+				continue
 			}
 
-			buckets, _ := z.ListBuckets(ctx)
+			// Calculate the set index where the current endpoint belongs
+			z.serverPools[poolIdx].erasureDisksMu.RLock()
+			// Protect reading reference format.
+			setIndex, diskIndex, err := findDiskIndex(z.serverPools[poolIdx].format, format)
+			z.serverPools[poolIdx].erasureDisksMu.RUnlock()
+			if err != nil {
+				printEndpointError(endpoint, err, false)
+				continue
+			}
 
-			// Heal latest buckets first.
-			sort.Slice(buckets, func(i, j int) bool {
-				return buckets[i].Created.After(buckets[j].Created)
+			disksToHeal = append(disksToHeal, healDiskEntry{
+				disk:     disk,
+				poolIdx:  poolIdx,
+				setIdx:   setIndex,
+				diskIdx:  diskIndex,
+				replaced: globalBackgroundHealState.isReplacedLocalDisk(endpoint),
 			})
+		}
+
+		if len(disksToHeal) == 0 {
+			continue
+		}
 
-			for i, setMap := range erasureSetInPoolDisksToHeal {
-				for setIndex, disks := range setMap {
-					for _, disk := range disks {
-						logger.Info("Healing disk '%s' on %s pool", disk, humanize.Ordinal(i+1))
-
-						// So someone changed the drives underneath, healing tracker missing.
-						if !disk.Healing() {
-							logger.Info("Healing tracker missing on '%s', disk was swapped again on %s pool", disk, humanize.Ordinal(i+1))
-							diskID, err := disk.GetDiskID()
-							if err != nil {
-								logger.LogIf(ctx, err)
-								// reading format.json failed or not found, proceed to look
-								// for new disks to be healed again, we cannot proceed further.
-								goto wait
-							}
-
-							if err := saveHealingTracker(disk, diskID); err != nil {
-								logger.LogIf(ctx, err)
-								// Unable to write healing tracker, permission denied or some
-								// other unexpected error occurred. Proceed to look for new
-								// disks to be healed again, we cannot proceed further.
-								goto wait
-							}
-						}
-
-						lbDisks := z.serverPools[i].sets[setIndex].getOnlineDisks()
-						if err := healErasureSet(ctx, setIndex, buckets, lbDisks); err != nil {
-							logger.LogIf(ctx, err)
-							continue
-						}
-
-						logger.Info("Healing disk '%s' on %s pool complete", disk, humanize.Ordinal(i+1))
-
-						if err := disk.Delete(ctx, pathJoin(minioMetaBucket, bucketMetaPrefix),
-							healingTrackerFilename, false); err != nil && !errors.Is(err, errFileNotFound) {
-							logger.LogIf(ctx, err)
-							continue
-						}
-
-						// Only upon success pop the healed disk.
-						globalBackgroundHealState.popHealLocalDisks(disk.Endpoint())
-					}
-				}
+		// Heal the disks replaced through the admin API first.
+		sort.SliceStable(disksToHeal, func(i, j int) bool {
+			return disksToHeal[i].replaced && !disksToHeal[j].replaced
+		})
+
+		buckets, _ := z.ListBuckets(ctx)
+
+		// Heal latest buckets first.
+		sort.Slice(buckets, func(i, j int) bool {
+			return buckets[i].Created.After(buckets[j].Created)
+		})
+
+		for _, entry := range disksToHeal {
+			disk := entry.disk
+			pool := z.serverPools[entry.poolIdx]
+			logger.Info("Healing disk '%s' on %s pool", disk, humanize.Ordinal(entry.poolIdx+1))
+
+			// So someone changed the drives underneath, healing tracker missing.
+			if !disk.Healing() {
+				logger.Info("Healing tracker missing on '%s', disk was swapped again on %s pool", disk, humanize.Ordinal(entry.poolIdx+1))
+			}
+
+			diskID, err := disk.GetDiskID()
+			if err != nil {
+				logger.LogIf(ctx, err)
+				// reading format.json failed or not found, proceed to look
+				// for new disks to be healed again, we cannot proceed further.
+				goto wait
+			}
+
+			lbDisks := pool.sets[entry.setIdx].getOnlineDisks()
+
+			// Resume from the progress saved on the disk, a tracker
+			// without start time was saved when formatting the disk.
+			tracker, err := loadHealingTracker(ctx, disk)
+			if err == nil && tracker.ID == diskID && !tracker.Started.IsZero() {
+				logger.Info("Resuming healing disk '%s' on %s pool", disk, humanize.Ordinal(entry.poolIdx+1))
+				tracker.Endpoint = disk.Endpoint().String()
+				tracker.PoolIndex, tracker.SetIndex, tracker.DiskIndex = entry.poolIdx, entry.setIdx, entry.diskIdx
+				tracker.Replaced = tracker.Replaced || entry.replaced
+			} else {
+				tracker = newHealingTracker(disk, diskID, entry.poolIdx, entry.setIdx, entry.diskIdx)
+				tracker.Replaced = entry.replaced
+				tracker.BytesTotal = estimateHealBytes(ctx, lbDisks, pool.SetDriveCount()-pool.ParityCount())
+			}
+			if err := tracker.save(ctx); err != nil {
+				logger.LogIf(ctx, err)
+				// Unable to write healing tracker, permission denied or some
+				// other unexpected error occurred. Proceed to look for new
+				// disks to be healed again, we cannot proceed further.
+				goto wait
 			}
+
+			if err := healErasureSet(ctx, entry.setIdx, buckets, lbDisks, tracker); err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+
+			logger.Info("Healing disk '%s' on %s pool complete", disk, humanize.Ordinal(entry.poolIdx+1))
+
+			if err := disk.Delete(ctx, pathJoin(minioMetaBucket, bucketMetaPrefix),
+				healingTrackerFilename, false); err != nil && !errors.Is(err, errFileNotFound) {
+				logger.LogIf(ctx, err)
+				continue
+			}
+
+			// Only upon success pop the healed disk.
+			globalBackgroundHealState.popHealStatus(tracker.Endpoint)
+			globalBackgroundHealState.popHealLocalDisks(disk.Endpoint())
 		}
 	}
 }
@@ -0,0 +1,343 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/config/scrub"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Progress of the bitrot scrub of a drive is saved on the drive.
+	scrubStateFile = ".scrub.json"
+
+	// Interval at which a drive is checked for a scrub to start.
+	scrubCheckInterval = 10 * time.Minute
+
+	// Interval at which the progress of a scrub is saved.
+	scrubSaveInterval = time.Minute
+
+	// Window over which the verification rate of a drive is measured.
+	scrubThrottleWindow = time.Minute
+)
+
+var (
+	globalScrubConfig   scrub.Config
+	globalScrubConfigMu sync.Mutex
+
+	globalScrubStats scrubStats
+)
+
+var errScrubDisabled = errors.New("bitrot scrub was disabled")
+
+// scrubStats counts the object versions verified by the bitrot
+// scrub of the local drives since the server started.
+type scrubStats struct {
+	objects   uint64
+	bytes     uint64
+	corrupted uint64
+}
+
+// scrubState is the saved progress of scrubbing a drive.
+type scrubState struct {
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+
+	// Bucket and last object verified, the scrub resumes after them.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	Objects   uint64 `json:"objects"`
+	Bytes     uint64 `json:"bytes"`
+	Corrupted uint64 `json:"corrupted"`
+
+	saved time.Time
+}
+
+func getScrubConfig() scrub.Config {
+	globalScrubConfigMu.Lock()
+	defer globalScrubConfigMu.Unlock()
+	return globalScrubConfig
+}
+
+// loadScrubState reads the progress of scrubbing the drive, a drive
+// never scrubbed has an empty state.
+func loadScrubState(ctx context.Context, disk StorageAPI) (state scrubState, err error) {
+	data, err := disk.ReadAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, scrubStateFile))
+	if err != nil {
+		if errors.Is(err, errFileNotFound) {
+			return state, nil
+		}
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+// save writes the progress of scrubbing the drive to it.
+func (s *scrubState) save(ctx context.Context, disk StorageAPI) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	s.saved = UTCNow()
+	return disk.WriteAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, scrubStateFile), data)
+}
+
+// scrubThrottle paces the verification of a drive to the configured
+// number of files and bytes per second.
+type scrubThrottle struct {
+	start      time.Time
+	ops, bytes int64
+}
+
+// delay returns how long to wait once ops files of bytes have been
+// verified, for the rate since the start of the window to be allowed.
+func (t *scrubThrottle) delay(cfg scrub.Config, ops, bytes int64, now time.Time) time.Duration {
+	if t.start.IsZero() || now.Sub(t.start) >= scrubThrottleWindow {
+		t.start, t.ops, t.bytes = now, 0, 0
+	}
+	t.ops += ops
+	t.bytes += bytes
+
+	var d time.Duration
+	if cfg.MaxIOPS > 0 {
+		d = time.Duration(float64(t.ops) / float64(cfg.MaxIOPS) * float64(time.Second))
+	}
+	if cfg.MaxBandwidth > 0 {
+		if bd := time.Duration(float64(t.bytes) / float64(cfg.MaxBandwidth) * float64(time.Second)); bd > d {
+			d = bd
+		}
+	}
+	return t.start.Add(d).Sub(now)
+}
+
+func (t *scrubThrottle) wait(ctx context.Context, cfg scrub.Config, ops, bytes int64) {
+	d := t.delay(cfg, ops, bytes, time.Now())
+	if d <= 0 {
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// scrubShardSize returns the number of files and bytes of the
+// shards of the version on a drive.
+func scrubShardSize(fi FileInfo) (files, size int64) {
+	if fi.InlineData() {
+		return 1, fi.Erasure.ShardFileSize(fi.Size)
+	}
+	for _, part := range fi.Parts {
+		size += fi.Erasure.ShardFileSize(part.Size)
+	}
+	return int64(len(fi.Parts)), size
+}
+
+// getLocalDisk returns the connected disk of the local endpoint.
+func (z *erasureServerPools) getLocalDisk(endpoint Endpoint) StorageAPI {
+	for _, pool := range z.serverPools {
+		for setIdx := range pool.sets {
+			for _, disk := range pool.GetDisks(setIdx)() {
+				if disk != nil && disk.IsLocal() && disk.Endpoint().String() == endpoint.String() {
+					return disk
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// initBitrotScrub starts scrubbing the local drives in the background.
+func initBitrotScrub(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	for _, pool := range globalEndpoints {
+		for _, endpoint := range pool.Endpoints {
+			if endpoint.IsLocal {
+				go runBitrotScrub(ctx, z, endpoint)
+			}
+		}
+	}
+}
+
+// runBitrotScrub verifies the bitrot checksums of all object versions of a
+// local drive once every configured period, a scrub interrupted by a restart
+// is resumed. Corrupted and missing shards are healed.
+func runBitrotScrub(ctx context.Context, z *erasureServerPools, endpoint Endpoint) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	timer := time.NewTimer(time.Duration(r.Float64() * float64(dataCrawlStartDelay)))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		timer.Reset(scrubCheckInterval)
+
+		cfg := getScrubConfig()
+		if !cfg.Enabled {
+			continue
+		}
+
+		disk := z.getLocalDisk(endpoint)
+		if disk == nil || disk.Healing() {
+			// Drives are scrubbed once online and healed.
+			continue
+		}
+
+		state, err := loadScrubState(ctx, disk)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		now := UTCNow()
+		switch {
+		case state.Started.IsZero():
+			state = scrubState{Started: now}
+		case !state.Finished.IsZero():
+			if now.Sub(state.Started) < cfg.Period {
+				continue
+			}
+			state = scrubState{Started: now}
+		}
+
+		if err = scrubDisk(ctx, disk, &state); err != nil {
+			if err != errScrubDisabled && !errors.Is(err, context.Canceled) {
+				logger.LogIf(ctx, fmt.Errorf("Bitrot scrub of drive %s stopped: %w", disk, err))
+			}
+			continue
+		}
+
+		state.Finished = UTCNow()
+		state.Bucket, state.Object = "", ""
+		logger.LogIf(ctx, state.save(ctx, disk))
+		logger.Info("Bitrot scrub of drive %s complete, %d object versions verified, %d corrupted",
+			disk, state.Objects, state.Corrupted)
+	}
+}
+
+// scrubDisk verifies all buckets of the drive in lexical order, starting
+// from the bucket of the state. The progress is saved on the drive.
+func scrubDisk(ctx context.Context, disk StorageAPI, state *scrubState) error {
+	vols, err := disk.ListVols(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(vols, func(i, j int) bool {
+		return vols[i].Name < vols[j].Name
+	})
+
+	var throttle scrubThrottle
+	for _, vol := range vols {
+		// Skip the meta bucket and buckets already scrubbed.
+		if isReservedOrInvalidBucket(vol.Name, false) || vol.Name < state.Bucket {
+			continue
+		}
+		if vol.Name != state.Bucket {
+			state.Bucket, state.Object = vol.Name, ""
+			logger.LogIf(ctx, state.save(ctx, disk))
+		}
+		err = scrubBucket(ctx, disk, vol.Name, state, &throttle)
+		logger.LogIf(ctx, state.save(ctx, disk))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scrubBucket verifies the object versions of the bucket after the last
+// object of the state.
+func scrubBucket(ctx context.Context, disk StorageAPI, bucket string, state *scrubState, throttle *scrubThrottle) error {
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	walkCh, err := disk.WalkVersions(ctx, bucket, "", state.Object, true, endWalkCh)
+	if err != nil {
+		return err
+	}
+
+	for fivs := range walkCh {
+		for _, fi := range fivs.Versions {
+			cfg := getScrubConfig()
+			if !cfg.Enabled {
+				return errScrubDisabled
+			}
+			if fi.Deleted || fi.TransitionStatus == lifecycle.TransitionComplete || !fi.IsValid() {
+				// No data on the drive.
+				continue
+			}
+
+			files, size := scrubShardSize(fi)
+			err := disk.VerifyFile(ctx, bucket, fivs.Name, fi)
+			if errors.Is(err, errFileNotFound) {
+				// Ignore versions deleted in the meantime.
+				if _, rerr := disk.ReadVersion(ctx, bucket, fivs.Name, fi.VersionID, false); rerr != nil {
+					continue
+				}
+			}
+
+			state.Objects++
+			state.Bytes += uint64(size)
+			atomic.AddUint64(&globalScrubStats.objects, 1)
+			atomic.AddUint64(&globalScrubStats.bytes, uint64(size))
+
+			switch {
+			case err == nil:
+			case errors.Is(err, errFileCorrupt), errors.Is(err, errFileNotFound):
+				state.Corrupted++
+				atomic.AddUint64(&globalScrubStats.corrupted, 1)
+				logger.LogIf(ctx, fmt.Errorf("Bitrot scrub found a bad shard of %s/%s (version '%s') on drive %s: %w",
+					bucket, fivs.Name, fi.VersionID, disk, err))
+				healObject(bucket, fivs.Name, fi.VersionID, madmin.HealDeepScan)
+			default:
+				return err
+			}
+
+			throttle.wait(ctx, cfg, files, size)
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		state.Object = fivs.Name
+		if UTCNow().Sub(state.saved) >= scrubSaveInterval {
+			logger.LogIf(ctx, state.save(ctx, disk))
+		}
+	}
+	return nil
+}
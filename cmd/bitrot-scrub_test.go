@@ -0,0 +1,144 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config/scrub"
+)
+
+func TestScrubThrottleDelay(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := scrub.Config{MaxBandwidth: 1000, MaxIOPS: 10}
+
+	var throttle scrubThrottle
+	// 5 files take half a second at 10 files per second.
+	if d := throttle.delay(cfg, 5, 100, start); d != 500*time.Millisecond {
+		t.Fatalf("expected 500ms, got %v", d)
+	}
+	// 3000 bytes take three seconds at 1000 bytes per second.
+	if d := throttle.delay(cfg, 1, 2900, start.Add(time.Second)); d != 2*time.Second {
+		t.Fatalf("expected 2s, got %v", d)
+	}
+	// A new window starts after a minute.
+	if d := throttle.delay(cfg, 1, 0, start.Add(2*time.Minute)); d != 100*time.Millisecond {
+		t.Fatalf("expected 100ms, got %v", d)
+	}
+	// No limits.
+	if d := throttle.delay(scrub.Config{}, 1000, 1<<30, start.Add(2*time.Minute)); d != 0 {
+		t.Fatalf("expected no delay, got %v", d)
+	}
+}
+
+func TestScrubDisk(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	globalScrubConfigMu.Lock()
+	globalScrubConfig = scrub.Config{Enabled: true}
+	globalScrubConfigMu.Unlock()
+	defer func() {
+		globalScrubConfigMu.Lock()
+		globalScrubConfig = scrub.Config{}
+		globalScrubConfigMu.Unlock()
+	}()
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// Large enough to not be inlined in xl.meta.
+	data := bytes.Repeat([]byte("a"), smallFileThreshold*16)
+	for _, name := range []string{object, "other"} {
+		_, err = obj.PutObject(ctx, bucket, name, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	disk := obj.(*erasureServerPools).serverPools[0].sets[0].getDisks()[0]
+	fi, err := disk.ReadVersion(ctx, bucket, object, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var state scrubState
+	if err = scrubDisk(ctx, disk, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Objects != 2 || state.Corrupted != 0 {
+		t.Fatalf("expected 2 verified objects and none corrupted, got %+v", state)
+	}
+	_, size := scrubShardSize(fi)
+	if state.Bytes != uint64(2*size) {
+		t.Fatalf("expected %d bytes verified, got %d", 2*size, state.Bytes)
+	}
+
+	filePath := pathJoin(disk.String(), bucket, object, fi.DataDir, "part.1")
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_SYNC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte("oops")) // Will cause bitrot error
+	f.Close()
+
+	state = scrubState{}
+	if err = scrubDisk(ctx, disk, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Objects != 2 || state.Corrupted != 1 {
+		t.Fatalf("expected 2 verified objects and 1 corrupted, got %+v", state)
+	}
+
+	// The progress is saved on the drive.
+	saved, err := loadScrubState(ctx, disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Bucket != bucket || saved.Object != "other" || saved.Corrupted != 1 {
+		t.Fatalf("unexpected saved state %+v", saved)
+	}
+
+	// A resumed scrub skips the objects already verified.
+	state = scrubState{Bucket: bucket, Object: object}
+	if err = scrubDisk(ctx, disk, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Objects != 1 || state.Corrupted != 0 {
+		t.Fatalf("expected 1 verified object after resuming, got %+v", state)
+	}
+
+	// Disabling the scrub stops it.
+	globalScrubConfigMu.Lock()
+	globalScrubConfig.Enabled = false
+	globalScrubConfigMu.Unlock()
+	if err = scrubDisk(ctx, disk, &scrubState{}); err != errScrubDisabled {
+		t.Fatalf("expected %v, got %v", errScrubDisabled, err)
+	}
+}
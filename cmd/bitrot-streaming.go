@@ -25,6 +25,7 @@ import (
 	"io"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/ioutil"
 )
 
 type errHashMismatch struct {
@@ -37,7 +38,7 @@ func (err *errHashMismatch) Error() string {
 
 // Calculates bitrot in chunks and writes the hash into the stream.
 type streamingBitrotWriter struct {
-	iow       *io.PipeWriter
+	iow       io.WriteCloser
 	h         hash.Hash
 	shardSize int64
 	canClose  chan struct{} // Needed to avoid race explained in Close() call.
@@ -88,6 +89,15 @@ func newStreamingBitrotWriter(disk StorageAPI, volume, filePath string, length i
 	return bw
 }
 
+// Returns streaming bitrot writer implementation writing to w,
+// used for erasure shards inlined in xl.meta.
+func newStreamingBitrotWriterBuffer(w io.Writer, algo BitrotAlgorithm, shardSize int64) io.WriteCloser {
+	bw := &streamingBitrotWriter{ioutil.NopCloser(w), algo.New(), shardSize, make(chan struct{})}
+	// Nothing to wait for on Close() as w is written synchronously.
+	close(bw.canClose)
+	return bw
+}
+
 // ReadAt() implementation which verifies the bitrot hash available as part of the stream.
 type streamingBitrotReader struct {
 	disk       StorageAPI
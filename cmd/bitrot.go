@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"hash"
 	"io"
@@ -143,3 +144,54 @@ func bitrotShardFileSize(size int64, shardSize int64, algo BitrotAlgorithm) int6
 	}
 	return ceilFrac(size, shardSize)*int64(algo.New().Size()) + size
 }
+
+// bitrotVerify verifies the bitrot protected erasure shard of partSize bytes,
+// read from r holding size bytes.
+func bitrotVerify(r io.Reader, size, partSize int64, algo BitrotAlgorithm, sum []byte, shardSize int64) error {
+	if algo != HighwayHash256S {
+		h := algo.New()
+		if _, err := io.Copy(h, r); err != nil {
+			// Premature failure in reading the object,file is corrupt.
+			return errFileCorrupt
+		}
+		if !bytes.Equal(h.Sum(nil), sum) {
+			return errFileCorrupt
+		}
+		return nil
+	}
+
+	// Calculate the size of the bitrot file and compare
+	// it with the actual file size.
+	if size != bitrotShardFileSize(partSize, shardSize, algo) {
+		return errFileCorrupt
+	}
+
+	buf := make([]byte, shardSize)
+	h := algo.New()
+	hashBuf := make([]byte, h.Size())
+	for {
+		if size == 0 {
+			return nil
+		}
+		h.Reset()
+		n, err := io.ReadFull(r, hashBuf)
+		if err != nil {
+			// Read's failed for object with right size, file is corrupt.
+			return err
+		}
+		size -= int64(n)
+		if size < int64(len(buf)) {
+			buf = buf[:size]
+		}
+		n, err = io.ReadFull(r, buf)
+		if err != nil {
+			// Read's failed for object with right size, at different offsets.
+			return err
+		}
+		size -= int64(n)
+		h.Write(buf)
+		if !bytes.Equal(h.Sum(nil), hashBuf) {
+			return errFileCorrupt
+		}
+	}
+}
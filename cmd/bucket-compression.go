@@ -0,0 +1,85 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/minio/minio/cmd/config/compress"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const bucketCompressionConfigFile = "compression.json"
+
+// parseBucketCompression parses BucketCompression from json
+func parseBucketCompression(data []byte) (*madmin.BucketCompression, error) {
+	cCfg := &madmin.BucketCompression{}
+	if err := json.Unmarshal(data, cCfg); err != nil {
+		return nil, err
+	}
+	if !madmin.IsValidCompressionAlgorithm(cCfg.Algorithm) {
+		return nil, fmt.Errorf("Invalid compression algorithm %s", cCfg.Algorithm)
+	}
+	return cCfg, nil
+}
+
+// bucketCompressionConfig returns the compression settings and algorithm
+// for objects of the bucket, the compression configuration of the bucket
+// takes precedence over the global compression settings.
+func bucketCompressionConfig(bucket string) (cfg compress.Config, algorithm string) {
+	globalCompressConfigMu.Lock()
+	cfg = globalCompressConfig
+	globalCompressConfigMu.Unlock()
+
+	algorithm = madmin.CompressionS2
+	if bucket == "" || bucket == minioMetaBucket {
+		return cfg, algorithm
+	}
+	cCfg, err := globalBucketMetadataSys.GetCompressionConfig(bucket)
+	if err != nil {
+		return cfg, algorithm
+	}
+	cfg.Enabled = cCfg.Enabled
+	cfg.Extensions = cCfg.Extensions
+	cfg.MimeTypes = cCfg.MimeTypes
+	if cCfg.Algorithm != "" {
+		algorithm = cCfg.Algorithm
+	}
+	return cfg, algorithm
+}
+
+// bucketCompressionAlgorithm returns the algorithm objects of the bucket
+// are compressed with.
+func bucketCompressionAlgorithm(bucket string) string {
+	_, algorithm := bucketCompressionConfig(bucket)
+	return algorithm
+}
+
+// multipartCompressionAlgorithm returns the algorithm parts of a multipart
+// upload are compressed with, parts must use the compression scheme the
+// upload was started with.
+func multipartCompressionAlgorithm(bucket, scheme string) string {
+	algorithm := bucketCompressionAlgorithm(bucket)
+	if compressionScheme(algorithm) == scheme {
+		return algorithm
+	}
+	if scheme == compressionAlgorithmZstd {
+		return madmin.CompressionZstd
+	}
+	return madmin.CompressionS2
+}
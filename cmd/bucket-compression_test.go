@@ -0,0 +1,114 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseBucketCompression(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{}`, false},
+		{`{"enabled":true}`, false},
+		{`{"enabled":true,"algorithm":"zstd","extensions":[".log"],"mimeTypes":["text/*"]}`, false},
+		{`{"enabled":true,"algorithm":"s2-best"}`, false},
+		{`{"enabled":true,"algorithm":"gzip"}`, true},
+		{`{"enabled":`, true},
+	}
+	for i, testCase := range testCases {
+		_, err := parseBucketCompression([]byte(testCase.data))
+		if (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestCompressReaderAlgorithms(t *testing.T) {
+	parts := [][]byte{compressibleData(3 << 20), compressibleData(1 << 20)}
+	data := append(append([]byte{}, parts[0]...), parts[1]...)
+
+	for _, algorithm := range []string{madmin.CompressionS2, madmin.CompressionS2Better, madmin.CompressionS2Best, madmin.CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			oi := ObjectInfo{
+				UserDefined: map[string]string{
+					ReservedMetadataPrefix + "compression": compressionScheme(algorithm),
+					ReservedMetadataPrefix + "actual-size": strconv.Itoa(len(data)),
+				},
+			}
+			var compressed []byte
+			for i, part := range parts {
+				r, idxFn := newCompressReader(bytes.NewReader(part), int64(len(part)), algorithm)
+				b, err := ioutil.ReadAll(r)
+				r.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				var index []byte
+				if idxFn != nil {
+					index = idxFn()
+				}
+				if algorithm == madmin.CompressionZstd && index != nil {
+					t.Fatal("unexpected index of zstd compressed data")
+				}
+				compressed = append(compressed, b...)
+				oi.Parts = append(oi.Parts, ObjectPartInfo{
+					Number:     i + 1,
+					Size:       int64(len(b)),
+					ActualSize: int64(len(part)),
+					Index:      index,
+				})
+			}
+			oi.Size = int64(len(compressed))
+
+			for _, rs := range []*HTTPRangeSpec{
+				nil,
+				{Start: 2<<20 + 11, End: 2<<20 + 100},
+				{Start: 3<<20 + 5, End: int64(len(data)) - 1},
+			} {
+				fn, off, length, err := NewGetObjectReader(rs, oi, ObjectOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				gr, err := fn(bytes.NewReader(compressed[off:off+length]), http.Header{}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := ioutil.ReadAll(gr)
+				gr.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := data
+				if rs != nil {
+					want = data[rs.Start : rs.End+1]
+				}
+				if !bytes.Equal(got, want) {
+					t.Fatalf("range %v: decompressed data mismatch", rs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// bucketConfigTestCase - a request to a bucket configuration
+// sub-resource and its expected response.
+type bucketConfigTestCase struct {
+	method     string
+	bucketName string
+	accessKey  string
+	secretKey  string
+	// Sent body
+	body []byte
+	// Expected response
+	expectedRespStatus int
+	configResponse     []byte
+	errorResponse      APIErrorResponse
+	shouldPass         bool
+}
+
+// Test S3 Bucket CORS APIs
+func TestBucketCors(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketCorsHandlers, []string{"GetBucketCors", "PutBucketCors", "DeleteBucketCors", "GetObject"})
+}
+
+// Simple tests of bucket CORS: PUT, GET, DELETE and the CORS requests
+// evaluated against the configuration. Tests are related and the order
+// is important.
+func testBucketCorsHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+
+	corsConfig := `<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><AllowedOrigin>http://www.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`
+
+	// test cases with sample input and expected output.
+	testCases := []bucketConfigTestCase{
+		// No CORS configuration yet.
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusNotFound,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "NoSuchCORSConfiguration",
+				Message:  "The CORS configuration does not exist",
+			},
+			shouldPass: false,
+		},
+		// Wrong credentials
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          "abcd",
+			secretKey:          "abcd",
+			body:               []byte(corsConfig),
+			expectedRespStatus: http.StatusForbidden,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "InvalidAccessKeyId",
+				Message:  "The Access Key Id you provided does not exist in our records.",
+			},
+			shouldPass: false,
+		},
+		// Rule without an allowed origin
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "CORS rule should have at least one AllowedOrigin",
+			},
+			shouldPass: false,
+		},
+		// Unsupported method
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "Found unsupported HTTP method in CORS config. Unsupported method is PATCH",
+			},
+			shouldPass: false,
+		},
+		// Non-existent bucket
+		{
+			method:             http.MethodPut,
+			bucketName:         "non-existent-bucket",
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(corsConfig),
+			expectedRespStatus: http.StatusNotFound,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + "non-existent-bucket" + SlashSeparator,
+				Code:     "NoSuchBucket",
+				Message:  "The specified bucket does not exist",
+			},
+			shouldPass: false,
+		},
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(corsConfig),
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(``),
+			shouldPass:         true,
+		},
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(`<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><AllowedHeader>*</AllowedHeader><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedOrigin>http://www.example.com</AllowedOrigin><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`),
+			shouldPass:         true,
+		},
+	}
+	testBucketConfig(instanceType, apiRouter, t, getBucketCorsURL, testCases)
+
+	corsRouter := corsHandler(apiRouter)
+	corsTestCases := []struct {
+		method        string
+		origin        string
+		requestMethod string
+		// Expected response
+		expectedRespStatus int
+		expectedHeaders    map[string]string
+	}{
+		// Preflight of an allowed origin and method.
+		{
+			method:             http.MethodOptions,
+			origin:             "http://www.example.com",
+			requestMethod:      http.MethodPut,
+			expectedRespStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				xhttp.AccessControlAllowOrigin:      "http://www.example.com",
+				xhttp.AccessControlAllowMethods:     "GET, PUT",
+				xhttp.AccessControlAllowCredentials: "true",
+				xhttp.AccessControlExposeHeaders:    "ETag",
+				xhttp.AccessControlMaxAge:           "3000",
+			},
+		},
+		// Preflight of a method not allowed.
+		{
+			method:             http.MethodOptions,
+			origin:             "http://www.example.com",
+			requestMethod:      http.MethodDelete,
+			expectedRespStatus: http.StatusForbidden,
+		},
+		// Preflight of an origin not allowed.
+		{
+			method:             http.MethodOptions,
+			origin:             "http://www.example.org",
+			requestMethod:      http.MethodGet,
+			expectedRespStatus: http.StatusForbidden,
+		},
+		// Actual request of an allowed origin, the object does not exist.
+		{
+			method:             http.MethodGet,
+			origin:             "http://www.example.com",
+			expectedRespStatus: http.StatusNotFound,
+			expectedHeaders: map[string]string{
+				xhttp.AccessControlAllowOrigin:   "http://www.example.com",
+				xhttp.AccessControlExposeHeaders: "ETag",
+			},
+		},
+		// Actual request of an origin not allowed.
+		{
+			method:             http.MethodGet,
+			origin:             "http://www.example.org",
+			expectedRespStatus: http.StatusNotFound,
+			expectedHeaders: map[string]string{
+				xhttp.AccessControlAllowOrigin: "",
+			},
+		},
+	}
+
+	for i, testCase := range corsTestCases {
+		rec := httptest.NewRecorder()
+		var req *http.Request
+		var err error
+		if testCase.method == http.MethodOptions {
+			req, err = newTestRequest(testCase.method, getGetObjectURL("", bucketName, "object"), 0, nil)
+		} else {
+			req, err = newTestSignedRequestV4(testCase.method, getGetObjectURL("", bucketName, "object"),
+				0, nil, creds.AccessKey, creds.SecretKey, nil)
+		}
+		if err != nil {
+			t.Fatalf("CORS Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		req.Header.Set(xhttp.Origin, testCase.origin)
+		if testCase.requestMethod != "" {
+			req.Header.Set(xhttp.AccessControlRequestMethod, testCase.requestMethod)
+		}
+		corsRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("CORS Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		for k, v := range testCase.expectedHeaders {
+			if rec.Header().Get(k) != v {
+				t.Errorf("CORS Test %d: %s: Expected the header %s to be `%s`, but instead found `%s`", i+1, instanceType, k, v, rec.Header().Get(k))
+			}
+		}
+	}
+
+	testCases = []bucketConfigTestCase{
+		{
+			method:             http.MethodDelete,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusNoContent,
+			configResponse:     []byte(``),
+			shouldPass:         true,
+		},
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusNotFound,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "NoSuchCORSConfiguration",
+				Message:  "The CORS configuration does not exist",
+			},
+			shouldPass: false,
+		},
+	}
+	testBucketConfig(instanceType, apiRouter, t, getBucketCorsURL, testCases)
+}
+
+// testBucketConfig is a generic testing of requests to the bucket
+// configuration sub-resource returned by configURL.
+func testBucketConfig(instanceType string, apiRouter http.Handler, t *testing.T,
+	configURL func(endPoint, bucketName string) string, testCases []bucketConfigTestCase) {
+	for i, testCase := range testCases {
+		// initialize httptest Recorder, this records any mutations to response writer inside the handler.
+		rec := httptest.NewRecorder()
+		// construct HTTP request
+		req, err := newTestSignedRequestV4(testCase.method, configURL("", testCase.bucketName),
+			int64(len(testCase.body)), bytes.NewReader(testCase.body), testCase.accessKey, testCase.secretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.shouldPass && !bytes.Equal(testCase.configResponse, rec.Body.Bytes()) {
+			t.Errorf("Test %d: %s: Expected the response to be `%s`, but instead found `%s`", i+1, instanceType, string(testCase.configResponse), rec.Body.String())
+		}
+		errorResponse := APIErrorResponse{}
+		err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse)
+		if err != nil && !testCase.shouldPass {
+			t.Fatalf("Test %d: %s: Unable to marshal response body %s", i+1, instanceType, rec.Body.String())
+		}
+		if errorResponse.Resource != testCase.errorResponse.Resource {
+			t.Errorf("Test %d: %s: Expected the error resource to be `%s`, but instead found `%s`", i+1, instanceType, testCase.errorResponse.Resource, errorResponse.Resource)
+		}
+		if errorResponse.Message != testCase.errorResponse.Message {
+			t.Errorf("Test %d: %s: Expected the error message to be `%s`, but instead found `%s`", i+1, instanceType, testCase.errorResponse.Message, errorResponse.Message)
+		}
+		if errorResponse.Code != testCase.errorResponse.Code {
+			t.Errorf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.errorResponse.Code, errorResponse.Code)
+		}
+	}
+}
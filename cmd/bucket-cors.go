@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+// getBucketCorsConfig returns the CORS configuration of the bucket
+// addressed by a cross-origin request, nil if the request is not a
+// cross-origin request or the bucket has no CORS configuration.
+func getBucketCorsConfig(r *http.Request) *cors.Config {
+	if r.Header.Get(xhttp.Origin) == "" {
+		return nil
+	}
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil {
+		return nil
+	}
+	bucket, _ := path2BucketObject(resource)
+	if bucket == "" || bucket == minioReservedBucket || isMinioMetaBucketName(bucket) {
+		return nil
+	}
+	// Only look up the metadata cached for existing buckets, cross-origin
+	// requests are not authenticated and must not load metadata from disk.
+	meta, err := globalBucketMetadataSys.Get(bucket)
+	if err != nil {
+		return nil
+	}
+	return meta.corsConfig
+}
+
+// setCorsAllowOriginHeaders sets the allowed origin on the response
+// according to the matching rule.
+func setCorsAllowOriginHeaders(w http.ResponseWriter, origin string, rule cors.Rule) {
+	if rule.AllowsAnyOrigin() {
+		w.Header().Set(xhttp.AccessControlAllowOrigin, "*")
+		return
+	}
+	w.Header().Set(xhttp.AccessControlAllowOrigin, origin)
+	w.Header().Set(xhttp.AccessControlAllowCredentials, "true")
+}
+
+// serveBucketCors evaluates cross-origin requests against the bucket
+// CORS configuration. Preflight requests are answered directly, all
+// other requests are forwarded to h with the CORS response headers
+// set when a rule matches.
+func serveBucketCors(w http.ResponseWriter, r *http.Request, config *cors.Config, h http.Handler) {
+	origin := r.Header.Get(xhttp.Origin)
+	reqMethod := r.Header.Get(xhttp.AccessControlRequestMethod)
+
+	if r.Method == http.MethodOptions && reqMethod != "" {
+		w.Header().Add(xhttp.Vary, xhttp.Origin)
+		w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		var reqHeaders []string
+		for _, header := range strings.Split(r.Header.Get(xhttp.AccessControlRequestHeaders), ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				reqHeaders = append(reqHeaders, header)
+			}
+		}
+
+		rule, ok := config.Match(origin, reqMethod, reqHeaders)
+		if !ok {
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrCORSForbidden), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		setCorsAllowOriginHeaders(w, origin, rule)
+		w.Header().Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+		if len(reqHeaders) > 0 {
+			w.Header().Set(xhttp.AccessControlAllowHeaders, strings.Join(reqHeaders, ", "))
+		}
+		if len(rule.ExposeHeaders) > 0 {
+			w.Header().Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			w.Header().Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Add(xhttp.Vary, xhttp.Origin)
+	if rule, ok := config.Match(origin, r.Method, nil); ok {
+		setCorsAllowOriginHeaders(w, origin, rule)
+		if len(rule.ExposeHeaders) > 0 {
+			w.Header().Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+		}
+	}
+	h.ServeHTTP(w, r)
+}
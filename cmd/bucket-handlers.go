@@ -477,8 +477,12 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 				VersionID: object.VersionID,
 			})
 		}
-		if hasLifecycleConfig && gerr == nil {
+		// The data of a transitioned version on its tier is only purged
+		// with the version, not when a delete marker is added on top of it.
+		if hasLifecycleConfig && gerr == nil && isTransitionedVersionDeleted(bucket, object.VersionID, goi.VersionID) {
 			object.PurgeTransitioned = goi.TransitionStatus
+			object.TransitionTier = goi.TransitionTier
+			object.TransitionedObjName = goi.TransitionedObjName
 		}
 		if replicateDeletes {
 			delMarker, replicate, repsync := checkReplicateDelete(ctx, bucket, ObjectToDelete{
@@ -549,6 +553,8 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			VersionPurgeStatus:            dObjects[i].VersionPurgeStatus,
 			DeleteMarkerReplicationStatus: dObjects[i].DeleteMarkerReplicationStatus,
 			PurgeTransitioned:             dObjects[i].PurgeTransitioned,
+			TransitionTier:                dObjects[i].TransitionTier,
+			TransitionedObjName:           dObjects[i].TransitionedObjName,
 		}]
 		if errs[i] == nil || isErrObjectNotFound(errs[i]) || isErrVersionNotFound(errs[i]) {
 			if replicateDeletes {
@@ -592,10 +598,14 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		}
 
 		if hasLifecycleConfig && dobj.PurgeTransitioned == lifecycle.TransitionComplete { // clean up transitioned tier
-			deleteTransitionedObject(ctx, newObjectLayerFn(), bucket, dobj.ObjectName, lifecycle.ObjectOpts{
-				Name:         dobj.ObjectName,
-				VersionID:    dobj.VersionID,
-				DeleteMarker: dobj.DeleteMarker,
+			deleteTransitionedObject(ctx, newObjectLayerFn(), ObjectInfo{
+				Bucket:              bucket,
+				Name:                dobj.ObjectName,
+				VersionID:           dobj.VersionID,
+				DeleteMarker:        dobj.DeleteMarker,
+				TransitionStatus:    dobj.PurgeTransitioned,
+				TransitionTier:      dobj.TransitionTier,
+				TransitionedObjName: dobj.TransitionedObjName,
 			}, false, true)
 		}
 
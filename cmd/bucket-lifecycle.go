@@ -24,9 +24,10 @@ import (
 	"net/http"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
-	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -34,7 +35,6 @@ import (
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/hash"
-	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/s3select"
 )
 
@@ -164,155 +164,100 @@ func initBackgroundTransition(ctx context.Context, objectAPI ObjectLayer) {
 	}
 }
 
+const (
+	// Transition state and the location of the data of a transitioned
+	// version are saved in its metadata, under the reserved prefix.
+	transitionStatus       = "transition-status"
+	transitionedObjectName = "transitioned-object"
+	transitionTier         = "transition-tier"
+)
+
+// validateLifecycleTransition returns error if a transition storage class
+// does not name a remote tier configured on this cluster.
 func validateLifecycleTransition(ctx context.Context, bucket string, lfc *lifecycle.Lifecycle) error {
 	for _, rule := range lfc.Rules {
 		if rule.Transition.StorageClass != "" {
-			sameTarget, destbucket, err := validateTransitionDestination(ctx, bucket, rule.Transition.StorageClass)
-			if err != nil {
-				return err
-			}
-			if sameTarget && destbucket == bucket {
-				return fmt.Errorf("Transition destination cannot be the same as the source bucket")
+			if !globalTierConfigMgr.IsTierValid(rule.Transition.StorageClass) {
+				return fmt.Errorf("Transition storage class %s is not a configured remote tier", rule.Transition.StorageClass)
 			}
 		}
 	}
 	return nil
 }
 
-// validateTransitionDestination returns error if transition destination bucket missing or not configured
-// It also returns true if transition destination is same as this server.
-func validateTransitionDestination(ctx context.Context, bucket string, targetLabel string) (bool, string, error) {
-	tgt := globalBucketTargetSys.GetRemoteTargetWithLabel(ctx, bucket, targetLabel)
-	if tgt == nil {
-		return false, "", BucketRemoteTargetNotFound{Bucket: bucket}
-	}
-	arn, err := madmin.ParseARN(tgt.Arn)
+// tierInUse returns true if an active lifecycle rule of any bucket
+// transitions objects to the remote tier.
+func tierInUse(ctx context.Context, objAPI ObjectLayer, tierName string) (bool, error) {
+	buckets, err := objAPI.ListBuckets(ctx)
 	if err != nil {
-		return false, "", BucketRemoteTargetNotFound{Bucket: bucket}
-	}
-	if arn.Type != madmin.ILMService {
-		return false, "", BucketRemoteArnTypeInvalid{}
-	}
-	clnt := globalBucketTargetSys.GetRemoteTargetClient(ctx, tgt.Arn)
-	if clnt == nil {
-		return false, "", BucketRemoteTargetNotFound{Bucket: bucket}
-	}
-	if found, _ := clnt.BucketExists(ctx, arn.Bucket); !found {
-		return false, "", BucketRemoteDestinationNotFound{Bucket: arn.Bucket}
+		return false, err
 	}
-	sameTarget, _ := isLocalHost(clnt.EndpointURL().Hostname(), clnt.EndpointURL().Port(), globalMinioPort)
-	return sameTarget, arn.Bucket, nil
-}
-
-// transitionSC returns storage class label for this bucket
-func transitionSC(ctx context.Context, bucket string) string {
-	cfg, err := globalBucketMetadataSys.GetLifecycleConfig(bucket)
-	if err != nil {
-		return ""
-	}
-	for _, rule := range cfg.Rules {
-		if rule.Status == Disabled {
+	for _, bucket := range buckets {
+		lc, err := globalLifecycleSys.Get(bucket.Name)
+		if err != nil {
 			continue
 		}
-		if rule.Transition.StorageClass != "" {
-			return rule.Transition.StorageClass
+		for _, rule := range lc.Rules {
+			if rule.Status == Disabled {
+				continue
+			}
+			if strings.EqualFold(rule.Transition.StorageClass, tierName) {
+				return true, nil
+			}
 		}
 	}
-	return ""
+	return false, nil
 }
 
-// return true if ARN representing transition storage class is present in a active rule
-// for the lifecycle configured on this bucket
-func transitionSCInUse(ctx context.Context, lfc *lifecycle.Lifecycle, bucket, arnStr string) bool {
-	tgtLabel := globalBucketTargetSys.GetRemoteLabelWithArn(ctx, bucket, arnStr)
-	if tgtLabel == "" {
-		return false
-	}
-	for _, rule := range lfc.Rules {
-		if rule.Status == Disabled {
-			continue
-		}
-		if rule.Transition.StorageClass != "" && rule.Transition.StorageClass == tgtLabel {
-			return true
-		}
-	}
-	return false
+// genTransitionObjName generates a unique name for the data of a version
+// on its remote tier, names are spread across prefixes of the tier.
+func genTransitionObjName() string {
+	us := mustGetUUID()
+	return pathJoin(us[0:2], us[2:4], us)
 }
 
-// set PutObjectOptions for PUT operation to transition data to target cluster
-func putTransitionOpts(objInfo ObjectInfo) (putOpts miniogo.PutObjectOptions) {
-	meta := make(map[string]string)
-
-	tag, err := tags.ParseObjectTags(objInfo.UserTags)
-	if err != nil {
-		return
+// isTransitionedVersionDeleted returns true if a delete of the object with
+// versionID removes the transitioned version transitionedVersionID itself,
+// rather than adding a delete marker on top of it. The data on the remote
+// tier must only be removed along with the version.
+func isTransitionedVersionDeleted(bucket, versionID, transitionedVersionID string) bool {
+	if versionID != "" {
+		return true
 	}
-	putOpts = miniogo.PutObjectOptions{
-		UserMetadata:    meta,
-		UserTags:        tag.ToMap(),
-		ContentType:     objInfo.ContentType,
-		ContentEncoding: objInfo.ContentEncoding,
-		StorageClass:    objInfo.StorageClass,
-		Internal: miniogo.AdvancedPutOptions{
-			SourceVersionID: objInfo.VersionID,
-			SourceMTime:     objInfo.ModTime,
-			SourceETag:      objInfo.ETag,
-		},
-	}
-	if mode, ok := objInfo.UserDefined[xhttp.AmzObjectLockMode]; ok {
-		rmode := miniogo.RetentionMode(mode)
-		putOpts.Mode = rmode
-	}
-	if retainDateStr, ok := objInfo.UserDefined[xhttp.AmzObjectLockRetainUntilDate]; ok {
-		rdate, err := time.Parse(time.RFC3339, retainDateStr)
-		if err != nil {
-			return
-		}
-		putOpts.RetainUntilDate = rdate
-	}
-	if lhold, ok := objInfo.UserDefined[xhttp.AmzObjectLockLegalHold]; ok {
-		putOpts.LegalHold = miniogo.LegalHoldStatus(lhold)
-	}
-
-	return
+	// Without version ID, only the null version is replaced, when
+	// versioning is not enabled on the bucket.
+	return !globalBucketVersioningSys.Enabled(bucket) &&
+		(transitionedVersionID == "" || transitionedVersionID == nullVersionID)
 }
 
 // handle deletes of transitioned objects or object versions when one of the following is true:
 // 1. temporarily restored copies of objects (restored with the PostRestoreObject API) expired.
 // 2. life cycle expiry date is met on the object.
 // 3. Object is removed through DELETE api call
-func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, bucket, object string, lcOpts lifecycle.ObjectOpts, restoredObject, isDeleteTierOnly bool) error {
-	if lcOpts.TransitionStatus == "" && !isDeleteTierOnly {
+func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo, restoredObject, isDeleteTierOnly bool) error {
+	if oi.TransitionStatus == "" && !isDeleteTierOnly {
 		return nil
 	}
-	lc, err := globalLifecycleSys.Get(bucket)
-	if err != nil {
-		return err
-	}
-	arn := getLifecycleTransitionTargetArn(ctx, lc, bucket, lcOpts)
-	if arn == nil {
-		return fmt.Errorf("remote target not configured")
-	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn.String())
-	if tgt == nil {
-		return fmt.Errorf("remote target not configured")
-	}
 
 	var opts ObjectOptions
-	opts.Versioned = globalBucketVersioningSys.Enabled(bucket)
-	opts.VersionID = lcOpts.VersionID
+	opts.Versioned = globalBucketVersioningSys.Enabled(oi.Bucket)
+	opts.VersionID = oi.VersionID
 	if restoredObject {
 		// delete locally restored copy of object or object version
 		// from the source, while leaving metadata behind. The data on
 		// transitioned tier lies untouched and still accessible
-		opts.TransitionStatus = lcOpts.TransitionStatus
-		_, err = objectAPI.DeleteObject(ctx, bucket, object, opts)
+		opts.TransitionStatus = oi.TransitionStatus
+		_, err := objectAPI.DeleteObject(ctx, oi.Bucket, oi.Name, opts)
 		return err
 	}
 
 	// When an object is past expiry, delete the data from transitioned tier and
 	// metadata from source
-	if err := tgt.RemoveObject(context.Background(), arn.Bucket, object, miniogo.RemoveObjectOptions{VersionID: lcOpts.VersionID}); err != nil {
+	d, remoteObject, err := getTransitionedObjectTier(ctx, oi)
+	if err != nil {
+		return err
+	}
+	if err = d.Remove(ctx, remoteObject); err != nil {
 		logger.LogIf(ctx, err)
 	}
 
@@ -320,18 +265,18 @@ func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, bucket
 		return nil
 	}
 
-	objInfo, err := objectAPI.DeleteObject(ctx, bucket, object, opts)
+	objInfo, err := objectAPI.DeleteObject(ctx, oi.Bucket, oi.Name, opts)
 	if err != nil {
 		return err
 	}
 	eventName := event.ObjectRemovedDelete
-	if lcOpts.DeleteMarker {
+	if oi.DeleteMarker {
 		eventName = event.ObjectRemovedDeleteMarkerCreated
 	}
 	// Notify object deleted event.
 	sendEvent(eventArgs{
 		EventName:  eventName,
-		BucketName: bucket,
+		BucketName: oi.Bucket,
 		Object:     objInfo,
 		Host:       "Internal: [ILM-EXPIRY]",
 	})
@@ -340,26 +285,25 @@ func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, bucket
 	return nil
 }
 
-// transition object to target specified by the transition ARN. When an object is transitioned to another
-// storage specified by the transition ARN, the metadata is left behind on source cluster and original content
-// is moved to the transition tier. Note that in the case of encrypted objects, entire encrypted stream is moved
-// to the transition tier without decrypting or re-encrypting.
+// transition object to the remote tier named by the transition storage class. When an object is
+// transitioned, the metadata is left behind on source cluster and original content is moved to
+// the remote tier under a generated name. Note that in the case of encrypted objects, entire
+// encrypted stream is moved to the remote tier without decrypting or re-encrypting.
 func transitionObject(ctx context.Context, objectAPI ObjectLayer, objInfo ObjectInfo) error {
 	lc, err := globalLifecycleSys.Get(objInfo.Bucket)
 	if err != nil {
 		return err
 	}
-	lcOpts := lifecycle.ObjectOpts{
+	tier := getLifecycleTransitionTier(lc, lifecycle.ObjectOpts{
 		Name:     objInfo.Name,
 		UserTags: objInfo.UserTags,
+	})
+	if tier == "" {
+		return fmt.Errorf("remote tier not configured")
 	}
-	arn := getLifecycleTransitionTargetArn(ctx, lc, objInfo.Bucket, lcOpts)
-	if arn == nil {
-		return fmt.Errorf("remote target not configured")
-	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn.String())
-	if tgt == nil {
-		return fmt.Errorf("remote target not configured")
+	d, err := globalTierConfigMgr.getDriver(tier)
+	if err != nil {
+		return err
 	}
 
 	gr, err := objectAPI.GetObjectNInfo(ctx, objInfo.Bucket, objInfo.Name, nil, http.Header{}, readLock, ObjectOptions{
@@ -375,8 +319,8 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, objInfo Object
 		return nil
 	}
 
-	putOpts := putTransitionOpts(oi)
-	if _, err = tgt.PutObject(ctx, arn.Bucket, oi.Name, gr, oi.Size, putOpts); err != nil {
+	destObj := genTransitionObjName()
+	if err = d.Put(ctx, destObj, gr, oi.Size); err != nil {
 		gr.Close()
 		return err
 	}
@@ -386,11 +330,15 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, objInfo Object
 	opts.Versioned = globalBucketVersioningSys.Enabled(oi.Bucket)
 	opts.VersionID = oi.VersionID
 	opts.TransitionStatus = lifecycle.TransitionComplete
+	opts.TransitionTier = tier
+	opts.TransitionedObjName = destObj
 	eventName := event.ObjectTransitionComplete
 
 	objInfo, err = objectAPI.DeleteObject(ctx, oi.Bucket, oi.Name, opts)
 	if err != nil {
 		eventName = event.ObjectTransitionFailed
+		// The version still holds its data, remove the copy on the tier.
+		logger.LogIf(ctx, d.Remove(ctx, destObj))
 	}
 
 	// Notify object deleted event.
@@ -403,53 +351,83 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, objInfo Object
 	return err
 }
 
-// getLifecycleTransitionTargetArn returns transition ARN for storage class specified in the config.
-func getLifecycleTransitionTargetArn(ctx context.Context, lc *lifecycle.Lifecycle, bucket string, obj lifecycle.ObjectOpts) *madmin.ARN {
+// getLifecycleTransitionTier returns the remote tier named by the storage class specified in the config.
+func getLifecycleTransitionTier(lc *lifecycle.Lifecycle, obj lifecycle.ObjectOpts) string {
 	for _, rule := range lc.FilterActionableRules(obj) {
 		if rule.Transition.StorageClass != "" {
-			return globalBucketTargetSys.GetRemoteArnWithLabel(ctx, bucket, rule.Transition.StorageClass)
+			return rule.Transition.StorageClass
 		}
 	}
-	return nil
+	return ""
 }
 
-// getTransitionedObjectReader returns a reader from the transitioned tier.
-func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, oi ObjectInfo, opts ObjectOptions) (gr *GetObjectReader, err error) {
-	var lc *lifecycle.Lifecycle
-	lc, err = globalLifecycleSys.Get(bucket)
-	if err != nil {
-		return nil, err
+// getTransitionedObjectTier returns the remote storage holding the data of
+// the transitioned version, and the name of the data there. Versions which
+// were transitioned before remote tiers have no tier, their data is on the
+// ILM remote target labeled by the transition storage class of the bucket.
+func getTransitionedObjectTier(ctx context.Context, oi ObjectInfo) (WarmBackend, string, error) {
+	if oi.TransitionTier != "" {
+		d, err := globalTierConfigMgr.getDriver(oi.TransitionTier)
+		return d, oi.TransitionedObjName, err
 	}
 
-	arn := getLifecycleTransitionTargetArn(ctx, lc, bucket, lifecycle.ObjectOpts{
-		Name:         object,
+	lc, err := globalLifecycleSys.Get(oi.Bucket)
+	if err != nil {
+		return nil, "", err
+	}
+	label := getLifecycleTransitionTier(lc, lifecycle.ObjectOpts{
+		Name:         oi.Name,
 		UserTags:     oi.UserTags,
 		ModTime:      oi.ModTime,
 		VersionID:    oi.VersionID,
 		DeleteMarker: oi.DeleteMarker,
 		IsLatest:     oi.IsLatest,
 	})
+	arn := globalBucketTargetSys.GetRemoteArnWithLabel(ctx, oi.Bucket, label)
 	if arn == nil {
-		return nil, fmt.Errorf("remote target not configured")
+		return nil, "", fmt.Errorf("remote target not configured")
 	}
 	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn.String())
 	if tgt == nil {
-		return nil, fmt.Errorf("remote target not configured")
+		return nil, "", fmt.Errorf("remote target not configured")
+	}
+	return &warmBackendTarget{
+		client:    tgt,
+		Bucket:    arn.Bucket,
+		VersionID: oi.VersionID,
+	}, oi.Name, nil
+}
+
+// transitionSCInUse returns true if a rule of the lifecycle configured on the
+// bucket transitions to the label of the ILM remote target arnStr, the versions
+// transitioned to the target before remote tiers are read through the rule.
+// Disabled rules count as well, the versions are read again once enabled.
+func transitionSCInUse(ctx context.Context, lfc *lifecycle.Lifecycle, bucket, arnStr string) bool {
+	tgtLabel := globalBucketTargetSys.GetRemoteLabelWithArn(ctx, bucket, arnStr)
+	if tgtLabel == "" {
+		return false
+	}
+	for _, rule := range lfc.Rules {
+		if rule.Transition.StorageClass != "" && strings.EqualFold(rule.Transition.StorageClass, tgtLabel) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTransitionedObjectReader returns a reader from the transitioned tier.
+func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, oi ObjectInfo, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	d, remoteObject, err := getTransitionedObjectTier(ctx, oi)
+	if err != nil {
+		return nil, err
 	}
 	fn, off, length, err := NewGetObjectReader(rs, oi, opts)
 	if err != nil {
 		return nil, ErrorRespToObjectError(err, bucket, object)
 	}
-	gopts := miniogo.GetObjectOptions{VersionID: opts.VersionID}
+	gopts := WarmBackendGetOpts{startOffset: off, length: length}
 
-	// get correct offsets for encrypted object
-	if off >= 0 && length >= 0 {
-		if err := gopts.SetRange(off, off+length-1); err != nil {
-			return nil, ErrorRespToObjectError(err, bucket, object)
-		}
-	}
-
-	reader, err := tgt.GetObject(ctx, arn.Bucket, object, gopts)
+	reader, err := d.Get(ctx, remoteObject, gopts)
 	if err != nil {
 		return nil, err
 	}
@@ -681,3 +659,124 @@ func restoreTransitionedObject(ctx context.Context, bucket, object string, objAP
 
 	return nil
 }
+
+// Number of incomplete multipart uploads aborted by lifecycle rules.
+var globalILMAbortedUploads uint64
+
+// Set while incomplete multipart uploads are being aborted, a crawler cycle
+// does not start aborting them again before the previous one is done.
+var globalILMAbortingUploads int32
+
+// applyAbortIncompleteMultipartUploads aborts the multipart uploads which
+// were not completed within the days set by the lifecycle rules of their
+// bucket. It runs alongside the crawler, in its own goroutine.
+func applyAbortIncompleteMultipartUploads(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&globalILMAbortingUploads, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&globalILMAbortingUploads, 0)
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	lcs := make(map[string]*lifecycle.Lifecycle)
+	for _, bucket := range buckets {
+		lc, err := globalLifecycleSys.Get(bucket.Name)
+		if err != nil || !lc.HasAbortIncompleteMultipartUpload() {
+			continue
+		}
+		lcs[bucket.Name] = lc
+	}
+	if len(lcs) == 0 {
+		return
+	}
+
+	abort := func(upload MultipartInfo) {
+		lc, ok := lcs[upload.Bucket]
+		if !ok {
+			return
+		}
+		if lc.ComputeMultipartAction(upload.Object, upload.Initiated) != lifecycle.AbortMultipartUploadAction {
+			return
+		}
+		err := objAPI.AbortMultipartUpload(ctx, upload.Bucket, upload.Object, upload.UploadID, ObjectOptions{})
+		if err != nil {
+			if _, ok := err.(InvalidUploadID); !ok {
+				logger.LogIf(ctx, err)
+			}
+			return
+		}
+		atomic.AddUint64(&globalILMAbortedUploads, 1)
+		logger.AuditLogInternal(ctx, "AbortIncompleteMultipartUpload", upload.Bucket, upload.Object)
+	}
+
+	unrecorded := set.NewStringSet()
+	for _, pool := range z.serverPools {
+		for _, es := range pool.sets {
+			shaDirs, err := es.walkMultipartUploads(ctx, abort)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				return
+			}
+			unrecorded = unrecorded.Union(shaDirs)
+		}
+	}
+	if unrecorded.IsEmpty() {
+		return
+	}
+
+	// Uploads initiated by older servers did not record their object,
+	// their directory is named after the hash of the bucket and object.
+	// They are found by the names of the objects of the buckets with an
+	// abort rule, and listed with their initiation time. The walk stops
+	// once all of them are found.
+	for bucket := range lcs {
+		if unrecorded.IsEmpty() {
+			break
+		}
+		walkCtx, cancel := context.WithCancel(ctx)
+		objInfoCh := make(chan ObjectInfo)
+		if err := objAPI.Walk(walkCtx, bucket, "", objInfoCh, ObjectOptions{WalkVersions: true}); err != nil {
+			cancel()
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for objInfo := range objInfoCh {
+			if unrecorded.IsEmpty() {
+				// Drain the walk until it is canceled.
+				cancel()
+				continue
+			}
+			shaDir := getSHA256Hash([]byte(pathJoin(bucket, objInfo.Name)))
+			if !unrecorded.Contains(shaDir) {
+				continue
+			}
+			unrecorded.Remove(shaDir)
+			var uploadIDMarker string
+			for {
+				result, err := objAPI.ListMultipartUploads(ctx, bucket, objInfo.Name, "", uploadIDMarker, "", maxUploadsList)
+				if err != nil {
+					logger.LogIf(ctx, err)
+					break
+				}
+				for _, upload := range result.Uploads {
+					upload.Bucket = bucket
+					abort(upload)
+				}
+				if !result.IsTruncated {
+					break
+				}
+				uploadIDMarker = result.NextUploadIDMarker
+			}
+		}
+		cancel()
+	}
+}
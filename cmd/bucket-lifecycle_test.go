@@ -0,0 +1,137 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+)
+
+func TestApplyAbortIncompleteMultipartUploads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(disks)
+	z := obj.(*erasureServerPools)
+	setObjectLayer(z)
+
+	if err = z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration><Rule><ID>rule1</ID><Filter><Prefix>abort/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata("bucket")
+	meta.lifecycleConfig = lc
+	globalBucketMetadataSys.Set("bucket", meta)
+
+	// rewriteUpload rewrites the metadata of the upload on all drives.
+	rewriteUpload := func(object, uploadID string, fn func(fi *FileInfo)) {
+		t.Helper()
+		es := z.serverPools[0].getHashedSet(object)
+		uploadIDPath := es.getUploadIDDir("bucket", object, uploadID)
+		for _, disk := range es.getDisks() {
+			fi, err := disk.ReadVersion(ctx, minioMetaMultipartBucket, uploadIDPath, "", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fn(&fi)
+			if err = disk.Delete(ctx, minioMetaMultipartBucket, pathJoin(uploadIDPath, xlStorageFormatFile), false); err != nil {
+				t.Fatal(err)
+			}
+			if err = disk.WriteMetadata(ctx, minioMetaMultipartBucket, uploadIDPath, fi); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	initiated := time.Now().Add(-3 * 24 * time.Hour)
+
+	testCases := []struct {
+		object  string
+		expired bool
+		legacy  bool
+		exists  bool
+		aborted bool
+	}{
+		// Expired upload matching the rule.
+		{object: "abort/object1", expired: true, aborted: true},
+		// Upload initiated within the days of the rule.
+		{object: "abort/object2"},
+		// Expired upload not matching the rule.
+		{object: "keep/object3", expired: true},
+		// Expired upload of an older server which did not record its
+		// object, it is found by the name of the object.
+		{object: "abort/object4", expired: true, legacy: true, exists: true, aborted: true},
+		// Expired upload of an older server without an object of the
+		// same name, it is left to the cleanup of stale uploads.
+		{object: "abort/object5", expired: true, legacy: true},
+	}
+
+	uploadIDs := make([]string, len(testCases))
+	for i, tc := range testCases {
+		if tc.exists {
+			if _, err = z.PutObject(ctx, "bucket", tc.object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), ObjectOptions{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if uploadIDs[i], err = z.NewMultipartUpload(ctx, "bucket", tc.object, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		rewriteUpload(tc.object, uploadIDs[i], func(fi *FileInfo) {
+			if tc.expired {
+				fi.ModTime = initiated
+			}
+			if tc.legacy {
+				delete(fi.Metadata, multipartObjectKey)
+			}
+		})
+	}
+
+	aborted := atomic.LoadUint64(&globalILMAbortedUploads)
+	applyAbortIncompleteMultipartUploads(ctx, z)
+	if n := atomic.LoadUint64(&globalILMAbortedUploads) - aborted; n != 2 {
+		t.Fatalf("expected 2 uploads to be aborted, got %d", n)
+	}
+
+	for i, tc := range testCases {
+		result, err := z.ListMultipartUploads(ctx, "bucket", tc.object, "", "", "", maxUploadsList)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, upload := range result.Uploads {
+			if upload.UploadID == uploadIDs[i] {
+				found = true
+			}
+		}
+		if found == tc.aborted {
+			t.Fatalf("Test %d: expected the upload of %s aborted: %v", i+1, tc.object, tc.aborted)
+		}
+	}
+}
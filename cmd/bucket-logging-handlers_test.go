@@ -0,0 +1,217 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Test S3 Bucket logging APIs
+func TestBucketLogging(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLoggingHandlers, []string{"GetBucketLogging", "PutBucketLogging", "GetObject"})
+}
+
+// Simple tests of bucket logging: PUT, GET and the delivery of the access
+// logs of the requests made to the bucket. Tests are related and the order
+// is important.
+func testBucketLoggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+
+	ctx := context.Background()
+	targetBucket := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(ctx, targetBucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: Failed to make the target bucket: <ERROR> %v", instanceType, err)
+	}
+
+	emptyConfig := `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`
+	loggingConfig := `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LoggingEnabled><TargetBucket>` + targetBucket + `</TargetBucket><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+
+	// test cases with sample input and expected output.
+	testCases := []bucketConfigTestCase{
+		// Access logging is disabled by default.
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(emptyConfig),
+			shouldPass:         true,
+		},
+		// Wrong credentials
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          "abcd",
+			secretKey:          "abcd",
+			body:               []byte(loggingConfig),
+			expectedRespStatus: http.StatusForbidden,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "InvalidAccessKeyId",
+				Message:  "The Access Key Id you provided does not exist in our records.",
+			},
+			shouldPass: false,
+		},
+		// Malformed XML
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<BucketLoggingStatus><LoggingEnabled>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "The XML you provided was not well-formed or did not validate against our published schema.",
+			},
+			shouldPass: false,
+		},
+		// Target bucket missing
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<BucketLoggingStatus><LoggingEnabled><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "TargetBucket must be specified when logging is enabled",
+			},
+			shouldPass: false,
+		},
+		// Target bucket does not exist
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>non-existent-bucket</TargetBucket></LoggingEnabled></BucketLoggingStatus>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "InvalidTargetBucketForLogging",
+				Message:  "The target bucket for logging does not exist or is not writable by the requester",
+			},
+			shouldPass: false,
+		},
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(loggingConfig),
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(``),
+			shouldPass:         true,
+		},
+		// The account delivering the logs is not returned.
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(loggingConfig),
+			shouldPass:         true,
+		},
+	}
+	testBucketConfig(instanceType, apiRouter, t, getBucketLoggingURL, testCases)
+
+	prevLoggingSys := globalBucketLoggingSys
+	globalBucketLoggingSys = NewBucketLoggingSys()
+	defer func() { globalBucketLoggingSys = prevLoggingSys }()
+
+	data := []byte("hello")
+	if _, err := obj.PutObject(ctx, bucketName, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+
+	// Requests are logged by the S3 API router.
+	router := mux.NewRouter().SkipClean(true)
+	router.Methods(http.MethodGet).Path("/{bucket}/{object:.+}").HandlerFunc(collectAPIStats("getobject", apiRouter.ServeHTTP))
+	for _, object := range []string{"object", "missing"} {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodGet, getGetObjectURL("", bucketName, object), 0, nil, creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		router.ServeHTTP(rec, req)
+	}
+
+	globalBucketLoggingSys.stage(ctx, obj)
+	globalBucketLoggingSys.deliver(ctx, obj)
+
+	result, err := obj.ListObjects(ctx, targetBucket, "logs/", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("%s: Failed to list log objects: <ERROR> %v", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("%s: Expected one log object, but instead found %d", instanceType, len(result.Objects))
+	}
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, targetBucket, result.Objects[0].Name, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Failed to read the log object: <ERROR> %v", instanceType, err)
+	}
+	records := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(records) != 2 {
+		t.Fatalf("%s: Expected 2 access log records, but instead found `%s`", instanceType, buf.String())
+	}
+	for i, expected := range []string{
+		" REST.GET.OBJECT object \"GET /" + bucketName + "/object HTTP/1.1\" 200 - 5 5 ",
+		" REST.GET.OBJECT missing \"GET /" + bucketName + "/missing HTTP/1.1\" 404 NoSuchKey ",
+	} {
+		if !strings.Contains(records[i], " "+bucketName+" ") || !strings.Contains(records[i], expected) {
+			t.Errorf("%s: Expected the access log record %d to contain `%s`, but instead found `%s`", instanceType, i+1, expected, records[i])
+		}
+	}
+
+	// An empty BucketLoggingStatus disables access logging.
+	testCases = []bucketConfigTestCase{
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<BucketLoggingStatus></BucketLoggingStatus>`),
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(``),
+			shouldPass:         true,
+		},
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(emptyConfig),
+			shouldPass:         true,
+		},
+	}
+	testBucketConfig(instanceType, apiRouter, t, getBucketLoggingURL, testCases)
+}
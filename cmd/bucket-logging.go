@@ -0,0 +1,500 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+const (
+	// Access log records are staged in the meta bucket before they
+	// are delivered, so that records survive server restarts.
+	bucketLoggingStagingPrefix = bucketConfigPrefix + SlashSeparator + ".logging"
+
+	// Interval at which buffered records are staged, records are
+	// also staged on shutdown.
+	bucketLoggingStageInterval = time.Second
+
+	// Interval at which staged records are delivered to target buckets.
+	bucketLoggingDeliverInterval = 5 * time.Minute
+
+	// Buffered records of a bucket are staged before the next interval
+	// once they reach this size, records are dropped above it until they
+	// are staged.
+	bucketLoggingMaxBufferSize = 8 << 20
+)
+
+// BucketLoggingSys - buffers server access log records of buckets
+// with access logging enabled and delivers them as log objects into
+// the configured target buckets.
+type BucketLoggingSys struct {
+	sync.Mutex
+	records map[string]*bytes.Buffer
+
+	// stageCh requests staging the buffered records before the next interval.
+	stageCh chan struct{}
+}
+
+// NewBucketLoggingSys - creates new bucket logging system.
+func NewBucketLoggingSys() *BucketLoggingSys {
+	return &BucketLoggingSys{
+		records: make(map[string]*bytes.Buffer),
+		stageCh: make(chan struct{}, 1),
+	}
+}
+
+// Enabled - returns true if access logging is enabled for bucket.
+func (sys *BucketLoggingSys) Enabled(bucket string) bool {
+	if bucket == "" || isMinioMetaBucketName(bucket) {
+		return false
+	}
+	config, err := globalBucketMetadataSys.GetLoggingConfig(bucket)
+	if err != nil {
+		return false
+	}
+	return config.Enabled()
+}
+
+// Send - queues a log record for bucket.
+func (sys *BucketLoggingSys) Send(bucket string, record string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	buf, ok := sys.records[bucket]
+	if !ok {
+		buf = &bytes.Buffer{}
+		sys.records[bucket] = buf
+	}
+	if buf.Len() >= bucketLoggingMaxBufferSize {
+		logger.LogOnceIf(GlobalContext, fmt.Errorf("Access log records of bucket %s are dropped, they are not staged fast enough", bucket), "bucket-logging-dropped-"+bucket)
+		return
+	}
+	buf.WriteString(record)
+	buf.WriteByte('\n')
+	if buf.Len() >= bucketLoggingMaxBufferSize {
+		select {
+		case sys.stageCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// stage - writes all buffered records to the meta bucket.
+func (sys *BucketLoggingSys) stage(ctx context.Context, objAPI ObjectLayer) {
+	sys.Lock()
+	records := sys.records
+	sys.records = make(map[string]*bytes.Buffer, len(records))
+	sys.Unlock()
+
+	for bucket, buf := range records {
+		if buf.Len() == 0 {
+			continue
+		}
+		stagedFile := path.Join(bucketLoggingStagingPrefix, bucket,
+			UTCNow().Format("2006-01-02-15-04-05")+"-"+mustGetUUID())
+		if err := saveConfig(ctx, objAPI, stagedFile, buf.Bytes()); err != nil {
+			logger.LogIf(ctx, err)
+			// Re-queue the records to retry at the next interval.
+			sys.Lock()
+			if cur, ok := sys.records[bucket]; ok {
+				buf.Write(cur.Bytes())
+			}
+			sys.records[bucket] = buf
+			sys.Unlock()
+		}
+	}
+}
+
+// deliver - moves the staged records of all buckets into log objects
+// in their target buckets.
+func (sys *BucketLoggingSys) deliver(ctx context.Context, objAPI ObjectLayer) {
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, minioMetaBucket, bucketLoggingStagingPrefix+SlashSeparator, marker, SlashSeparator, maxObjectList)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		for _, prefix := range result.Prefixes {
+			bucket := path.Base(strings.TrimSuffix(prefix, SlashSeparator))
+			if err = sys.deliverBucket(ctx, objAPI, bucket); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		}
+		if !result.IsTruncated {
+			return
+		}
+		marker = result.NextMarker
+	}
+}
+
+// deliverBucket - delivers the staged records of a bucket, staged records
+// are removed only once they are written into the target bucket. Each
+// listed page of staged records is delivered as one log object.
+func (sys *BucketLoggingSys) deliverBucket(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	stagingDir := path.Join(bucketLoggingStagingPrefix, bucket) + SlashSeparator
+
+	// Make sure only one server delivers the records of a bucket.
+	lk := objAPI.NewNSLock(minioMetaBucket, path.Join(stagingDir, "deliver.lock"))
+	if err := lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	// Records of buckets which have logging disabled, a missing target
+	// bucket or no permission to write into it anymore since they were
+	// staged are dropped.
+	config, err := globalBucketMetadataSys.GetLoggingConfig(bucket)
+	enabled := err == nil && config.Enabled()
+	if enabled && !isBucketLogDeliveryAllowed(config) {
+		logger.LogIf(ctx, fmt.Errorf("Unable to deliver access logs of bucket %s: access denied to target bucket %s",
+			bucket, config.LoggingEnabled.TargetBucket))
+		enabled = false
+	}
+
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, minioMetaBucket, stagingDir, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		var (
+			data   bytes.Buffer
+			staged []string
+		)
+		for _, obj := range result.Objects {
+			buf, err := readConfig(ctx, objAPI, obj.Name)
+			if err != nil {
+				// Records which cannot be read are left staged, they
+				// are delivered at a later interval.
+				if err != errConfigNotFound {
+					logger.LogIf(ctx, fmt.Errorf("Unable to read staged access logs of bucket %s: %w", bucket, err))
+				}
+				continue
+			}
+			data.Write(buf)
+			staged = append(staged, obj.Name)
+		}
+
+		if enabled && data.Len() > 0 {
+			target := config.LoggingEnabled
+			object := target.TargetPrefix + UTCNow().Format("2006-01-02-15-04-05") + "-" +
+				strings.ToUpper(strings.Replace(mustGetUUID(), "-", "", -1)[:16])
+			if err = putBucketLogObject(ctx, objAPI, target.TargetBucket, object, data.Bytes()); err != nil {
+				if !isErrBucketNotFound(err) {
+					return err
+				}
+				logger.LogIf(ctx, fmt.Errorf("Unable to deliver access logs of bucket %s: %w", bucket, err))
+				enabled = false
+			}
+		}
+
+		for _, name := range staged {
+			if err = deleteConfig(ctx, objAPI, name); err != nil && err != errConfigNotFound {
+				logger.LogIf(ctx, err)
+			}
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// isBucketLogDeliveryAllowed - returns true if the account which enabled
+// access logging is still allowed to write log objects into the target
+// bucket, an empty account is checked against the bucket policy.
+func isBucketLogDeliveryAllowed(config *logging.Config) bool {
+	target := config.LoggingEnabled
+	if config.AccessKey == "" {
+		return globalPolicySys.IsAllowed(policy.Args{
+			Action:          policy.PutObjectAction,
+			BucketName:      target.TargetBucket,
+			ConditionValues: map[string][]string{},
+			ObjectName:      target.TargetPrefix,
+		})
+	}
+
+	if config.AccessKey == globalActiveCred.AccessKey {
+		return true
+	}
+
+	cred, ok := globalIAMSys.GetUser(config.AccessKey)
+	if !ok || !cred.IsValid() {
+		return false
+	}
+
+	var claims map[string]interface{}
+	if cred.SessionToken != "" {
+		var err error
+		r := new(http.Request).WithContext(GlobalContext)
+		if claims, err = getClaimsFromToken(r, cred.SessionToken); err != nil {
+			return false
+		}
+	}
+
+	principalType := "User"
+	if cred.IsTemp() {
+		principalType = "AssumedRole"
+	}
+	return globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName: cred.AccessKey,
+		Action:      iampolicy.PutObjectAction,
+		BucketName:  target.TargetBucket,
+		ConditionValues: map[string][]string{
+			"principaltype": {principalType},
+			"userid":        {cred.AccessKey},
+			"username":      {cred.AccessKey},
+		},
+		ObjectName: target.TargetPrefix,
+		Claims:     claims,
+	})
+}
+
+// putBucketLogObject - writes a log object into the target bucket.
+func putBucketLogObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, data []byte) error {
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)), globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return err
+	}
+	opts := ObjectOptions{
+		UserDefined: map[string]string{
+			xhttp.ContentType: "text/plain",
+		},
+	}
+	if globalBucketVersioningSys.Enabled(bucket) {
+		opts.Versioned = true
+	}
+	_, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hashReader, nil, nil), opts)
+	return err
+}
+
+// initBucketLogging - starts the background staging and delivery
+// of server access logs. Records staged before a restart are
+// delivered at the first delivery interval.
+func initBucketLogging(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		stageTimer := time.NewTimer(bucketLoggingStageInterval)
+		defer stageTimer.Stop()
+		deliverTimer := time.NewTimer(bucketLoggingDeliverInterval)
+		defer deliverTimer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stageTimer.C:
+				globalBucketLoggingSys.stage(ctx, objAPI)
+				stageTimer.Reset(bucketLoggingStageInterval)
+			case <-globalBucketLoggingSys.stageCh:
+				globalBucketLoggingSys.stage(ctx, objAPI)
+			case <-deliverTimer.C:
+				globalBucketLoggingSys.deliver(ctx, objAPI)
+				deliverTimer.Reset(bucketLoggingDeliverInterval)
+			}
+		}
+	}()
+}
+
+// bucketLogOperation - returns the operation of the request in the
+// form REST.<METHOD>.<RESOURCE> e.g. REST.GET.OBJECT
+func bucketLogOperation(r *http.Request, object string) string {
+	query := r.URL.Query()
+	resource := "BUCKET"
+	if object != "" {
+		resource = "OBJECT"
+	}
+	switch {
+	case query.Get("uploadId") != "" && query.Get("partNumber") != "":
+		resource = "PART"
+	case query.Get("uploadId") != "":
+		resource = "UPLOAD"
+	default:
+		for _, subresource := range []string{"acl", "cors", "delete", "encryption", "legal-hold", "lifecycle",
+			"location", "logging", "notification", "object-lock", "policy", "replication", "restore",
+			"retention", "select", "tagging", "uploads", "versioning", "versions", "website"} {
+			if _, ok := query[subresource]; ok {
+				resource = strings.ToUpper(strings.Replace(subresource, "-", "", -1))
+				break
+			}
+		}
+	}
+	return "REST." + r.Method + "." + resource
+}
+
+// bucketLogErrorCode - returns the S3 error code of a failed request.
+func bucketLogErrorCode(w *logger.ResponseWriter) string {
+	if w.StatusCode < http.StatusBadRequest {
+		return "-"
+	}
+	body := w.Body()
+	start := bytes.Index(body, []byte("<Code>"))
+	end := bytes.Index(body, []byte("</Code>"))
+	if start < 0 || end < start {
+		return "-"
+	}
+	return string(body[start+len("<Code>") : end])
+}
+
+// bucketLogObjectSize - returns the total size of the object the request
+// read or wrote, the size is not known for other requests.
+func bucketLogObjectSize(r *http.Request, w *logger.ResponseWriter, object string) string {
+	if w.StatusCode >= http.StatusBadRequest {
+		return "-"
+	}
+	switch bucketLogOperation(r, object) {
+	case "REST.GET.OBJECT", "REST.HEAD.OBJECT":
+		// Content-Range holds the size of the object for range requests.
+		if contentRange := w.Header().Get(xhttp.ContentRange); contentRange != "" {
+			if i := strings.LastIndex(contentRange, "/"); i >= 0 && contentRange[i+1:] != "*" {
+				return contentRange[i+1:]
+			}
+		}
+		return bucketLogValue(w.Header().Get(xhttp.ContentLength))
+	case "REST.PUT.OBJECT", "REST.PUT.PART":
+		if r.Header.Get(xhttp.AmzCopySource) != "" {
+			return "-"
+		}
+		if size := r.Header.Get(xhttp.AmzDecodedContentLength); size != "" {
+			return size
+		}
+		if r.ContentLength >= 0 {
+			return strconv.FormatInt(r.ContentLength, 10)
+		}
+	}
+	return "-"
+}
+
+func bucketLogValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func bucketLogQuoted(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return strconv.Quote(s)
+}
+
+// newBucketLogRecord - returns the server access log record of a request
+// in the AWS S3 log format, as documented at
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/LogFormat.html
+func newBucketLogRecord(r *http.Request, w *logger.ResponseWriter, bucket, object string) string {
+	var sigVersion, authType string
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeStreamingSigned:
+		sigVersion, authType = "SigV4", "AuthHeader"
+	case authTypePresigned:
+		sigVersion, authType = "SigV4", "QueryString"
+	case authTypeSignedV2:
+		sigVersion, authType = "SigV2", "AuthHeader"
+	case authTypePresignedV2:
+		sigVersion, authType = "SigV2", "QueryString"
+	}
+
+	var cipherSuite, tlsVersion string
+	if r.TLS != nil {
+		cipherSuite = tls.CipherSuiteName(r.TLS.CipherSuite)
+		switch r.TLS.Version {
+		case tls.VersionTLS10:
+			tlsVersion = "TLSv1"
+		case tls.VersionTLS11:
+			tlsVersion = "TLSv1.1"
+		case tls.VersionTLS12:
+			tlsVersion = "TLSv1.2"
+		case tls.VersionTLS13:
+			tlsVersion = "TLSv1.3"
+		}
+	}
+
+	key := "-"
+	if object != "" {
+		key = url.PathEscape(object)
+	}
+
+	return strings.Join([]string{
+		"-", // bucket owner
+		bucket,
+		w.StartTime.Format("[02/Jan/2006:15:04:05 -0700]"),
+		bucketLogValue(handlers.GetSourceIP(r)),
+		bucketLogValue(getReqAccessCred(r, globalServerRegion).AccessKey),
+		bucketLogValue(w.Header().Get(xhttp.AmzRequestID)),
+		bucketLogOperation(r, object),
+		key,
+		strconv.Quote(r.Method + " " + r.URL.RequestURI() + " " + r.Proto),
+		strconv.Itoa(w.StatusCode),
+		bucketLogErrorCode(w),
+		bucketLogValue(w.Header().Get(xhttp.ContentLength)),
+		bucketLogObjectSize(r, w, object),
+		strconv.FormatInt(time.Since(w.StartTime).Milliseconds(), 10),
+		strconv.FormatInt(w.TimeToFirstByte.Milliseconds(), 10),
+		bucketLogQuoted(r.Referer()),
+		bucketLogQuoted(r.UserAgent()),
+		bucketLogValue(r.URL.Query().Get(xhttp.VersionID)),
+		"-", // host id
+		bucketLogValue(sigVersion),
+		bucketLogValue(cipherSuite),
+		bucketLogValue(authType),
+		bucketLogValue(r.Host),
+		bucketLogValue(tlsVersion),
+	}, " ")
+}
+
+// logBucketAccess - wraps a S3 API handler to record server access
+// logs for buckets with access logging enabled.
+func logBucketAccess(w *logger.ResponseWriter, r *http.Request, f http.HandlerFunc) {
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	if globalBucketLoggingSys == nil || !globalBucketLoggingSys.Enabled(bucket) {
+		f.ServeHTTP(w, r)
+		return
+	}
+
+	// Record error responses to log the S3 error code.
+	w.LogErrBody = true
+	f.ServeHTTP(w, r)
+
+	object, err := url.PathUnescape(vars["object"])
+	if err != nil {
+		object = vars["object"]
+	}
+	globalBucketLoggingSys.Send(bucket, newBucketLogRecord(r, w, bucket, object))
+}
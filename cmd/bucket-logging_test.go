@@ -0,0 +1,109 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+)
+
+func TestBucketLogObjectSize(t *testing.T) {
+	testCases := []struct {
+		method        string
+		target        string
+		object        string
+		reqHeaders    map[string]string
+		respHeaders   map[string]string
+		statusCode    int
+		contentLength int64
+		expected      string
+	}{
+		// GET of the whole object.
+		{method: http.MethodGet, target: "/bucket/object", object: "object",
+			respHeaders: map[string]string{xhttp.ContentLength: "100"}, statusCode: http.StatusOK, expected: "100"},
+		// Range GET reports the size of the object.
+		{method: http.MethodGet, target: "/bucket/object", object: "object",
+			respHeaders: map[string]string{xhttp.ContentLength: "10", xhttp.ContentRange: "bytes 0-9/100"},
+			statusCode:  http.StatusPartialContent, expected: "100"},
+		// Presigned HEAD.
+		{method: http.MethodHead, target: "/bucket/object?X-Amz-Signature=abc", object: "object",
+			respHeaders: map[string]string{xhttp.ContentLength: "100"}, statusCode: http.StatusOK, expected: "100"},
+		// PUT of an object.
+		{method: http.MethodPut, target: "/bucket/object", object: "object",
+			contentLength: 42, statusCode: http.StatusOK, expected: "42"},
+		// Streaming signature PUT.
+		{method: http.MethodPut, target: "/bucket/object", object: "object",
+			reqHeaders:    map[string]string{xhttp.AmzDecodedContentLength: "40"},
+			contentLength: 100, statusCode: http.StatusOK, expected: "40"},
+		// PUT of a part.
+		{method: http.MethodPut, target: "/bucket/object?partNumber=1&uploadId=abc", object: "object",
+			contentLength: 5, statusCode: http.StatusOK, expected: "5"},
+		// Copy of an object.
+		{method: http.MethodPut, target: "/bucket/object", object: "object",
+			reqHeaders: map[string]string{xhttp.AmzCopySource: "/bucket/source"}, statusCode: http.StatusOK, expected: "-"},
+		// Sub-resources of an object.
+		{method: http.MethodGet, target: "/bucket/object?tagging", object: "object",
+			respHeaders: map[string]string{xhttp.ContentLength: "100"}, statusCode: http.StatusOK, expected: "-"},
+		// Bucket requests.
+		{method: http.MethodGet, target: "/bucket", statusCode: http.StatusOK, expected: "-"},
+		// Failed requests.
+		{method: http.MethodGet, target: "/bucket/object", object: "object",
+			respHeaders: map[string]string{xhttp.ContentLength: "100"}, statusCode: http.StatusNotFound, expected: "-"},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(tc.method, tc.target, nil)
+		r.ContentLength = tc.contentLength
+		for k, v := range tc.reqHeaders {
+			r.Header.Set(k, v)
+		}
+		w := logger.NewResponseWriter(httptest.NewRecorder())
+		for k, v := range tc.respHeaders {
+			w.Header().Set(k, v)
+		}
+		w.StatusCode = tc.statusCode
+		if got := bucketLogObjectSize(r, w, tc.object); got != tc.expected {
+			t.Errorf("Test %d: expected object size %s, got %s", i+1, tc.expected, got)
+		}
+	}
+}
+
+func TestBucketLoggingSendFull(t *testing.T) {
+	sys := NewBucketLoggingSys()
+	record := strings.Repeat("a", 1<<20)
+	for i := 0; i < 8; i++ {
+		sys.Send("bucket", record)
+	}
+
+	// A full buffer is staged before the next interval.
+	select {
+	case <-sys.stageCh:
+	default:
+		t.Fatal("expected staging of the full buffer to be requested")
+	}
+
+	size := sys.records["bucket"].Len()
+	sys.Send("bucket", record)
+	if sys.records["bucket"].Len() != size {
+		t.Fatal("expected records above the buffer size to be dropped")
+	}
+}
@@ -26,12 +26,15 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
 	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/sync/errgroup"
@@ -168,6 +171,16 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 			return NotImplemented{}
 		}
 		meta.ReplicationConfigXML = configData
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+	case bucketWebsiteConfig:
+		meta.WebsiteConfigXML = configData
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
+	case bucketStorageClassConfigFile:
+		meta.StorageClassConfigJSON = configData
+	case bucketCompressionConfigFile:
+		meta.CompressionConfigJSON = configData
 	case bucketTargetsFile:
 		meta.BucketTargetsConfigJSON, meta.BucketTargetsConfigMetaJSON, err = encryptBucketMetadata(meta.Name, configData, crypto.Context{bucket: meta.Name, bucketTargetsFile: bucketTargetsFile})
 		if err != nil {
@@ -310,6 +323,54 @@ func (sys *BucketMetadataSys) GetSSEConfig(bucket string) (*bucketsse.BucketSSEC
 	return meta.sseConfig, nil
 }
 
+// GetCorsConfig returns configured bucket CORS config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(bucket string) (*cors.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketCorsNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.corsConfig == nil {
+		return nil, BucketCorsNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, nil
+}
+
+// GetWebsiteConfig returns configured bucket website config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetWebsiteConfig(bucket string) (*website.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketWebsiteNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.websiteConfig == nil {
+		return nil, BucketWebsiteNotFound{Bucket: bucket}
+	}
+	return meta.websiteConfig, nil
+}
+
+// GetLoggingConfig returns configured bucket access logging config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(bucket string) (*logging.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketLoggingNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.loggingConfig == nil {
+		return nil, BucketLoggingNotFound{Bucket: bucket}
+	}
+	return meta.loggingConfig, nil
+}
+
 // GetPolicyConfig returns configured bucket policy
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetPolicyConfig(bucket string) (*policy.Policy, error) {
@@ -344,6 +405,32 @@ func (sys *BucketMetadataSys) GetQuotaConfig(bucket string) (*madmin.BucketQuota
 	return meta.quotaConfig, nil
 }
 
+// GetStorageClassConfig returns configured bucket storage class
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetStorageClassConfig(bucket string) (*madmin.BucketStorageClass, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if meta.storageClassConfig == nil {
+		return nil, BucketStorageClassConfigNotFound{Bucket: bucket}
+	}
+	return meta.storageClassConfig, nil
+}
+
+// GetCompressionConfig returns configured bucket compression
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCompressionConfig(bucket string) (*madmin.BucketCompression, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if meta.compressionConfig == nil {
+		return nil, BucketCompressionConfigNotFound{Bucket: bucket}
+	}
+	return meta.compressionConfig, nil
+}
+
 // GetReplicationConfig returns configured bucket replication config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
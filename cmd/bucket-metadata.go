@@ -31,12 +31,15 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
 	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/sio"
@@ -78,6 +81,11 @@ type BucketMetadata struct {
 	ReplicationConfigXML        []byte
 	BucketTargetsConfigJSON     []byte
 	BucketTargetsConfigMetaJSON []byte
+	CorsConfigXML               []byte
+	WebsiteConfigXML            []byte
+	LoggingConfigXML            []byte
+	StorageClassConfigJSON      []byte
+	CompressionConfigJSON       []byte
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig           *policy.Policy
@@ -91,6 +99,11 @@ type BucketMetadata struct {
 	replicationConfig      *replication.Config
 	bucketTargetConfig     *madmin.BucketTargets
 	bucketTargetConfigMeta map[string]string
+	corsConfig             *cors.Config
+	websiteConfig          *website.Config
+	loggingConfig          *logging.Config
+	storageClassConfig     *madmin.BucketStorageClass
+	compressionConfig      *madmin.BucketCompression
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -247,6 +260,51 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	} else {
 		b.bucketTargetConfig = &madmin.BucketTargets{}
 	}
+
+	if len(b.CorsConfigXML) != 0 {
+		b.corsConfig, err = cors.ParseConfig(bytes.NewReader(b.CorsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.corsConfig = nil
+	}
+
+	if len(b.WebsiteConfigXML) != 0 {
+		b.websiteConfig, err = website.ParseConfig(bytes.NewReader(b.WebsiteConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.websiteConfig = nil
+	}
+
+	if len(b.LoggingConfigXML) != 0 {
+		b.loggingConfig, err = logging.ParseConfig(bytes.NewReader(b.LoggingConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.loggingConfig = nil
+	}
+
+	if len(b.StorageClassConfigJSON) != 0 {
+		b.storageClassConfig, err = parseBucketStorageClass(b.StorageClassConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.storageClassConfig = nil
+	}
+
+	if len(b.CompressionConfigJSON) != 0 {
+		b.compressionConfig, err = parseBucketCompression(b.CompressionConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.compressionConfig = nil
+	}
 	return nil
 }
 
@@ -26,9 +26,11 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	miniogopolicy "github.com/minio/minio-go/v7/pkg/policy"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 	"github.com/minio/minio/pkg/handlers"
 )
 
@@ -158,9 +160,54 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		}
 	}
 
+	// Values of object tags are never taken from the request headers,
+	// query parameters or claims.
+	for key := range args {
+		if _, ok := condition.Key("s3:" + key).ObjectTagKey(); ok {
+			delete(args, key)
+		}
+	}
+	delete(args, condition.S3RequestObjectTagKeys.Name())
+	delete(args, http.CanonicalHeaderKey(condition.S3RequestObjectTagKeys.Name()))
+
+	if userTags := r.Header.Get(xhttp.AmzObjectTagging); userTags != "" {
+		if t, err := tags.ParseObjectTags(userTags); err == nil {
+			tagKeys := []string{}
+			for k, v := range t.ToMap() {
+				args[condition.S3RequestObjectTag.Name()+"/"+k] = []string{v}
+				tagKeys = append(tagKeys, k)
+			}
+			args[condition.S3RequestObjectTagKeys.Name()] = tagKeys
+		}
+	}
+
 	return args
 }
 
+// hasConditionKey - returns true if the key is in the keys. A key of object
+// tags, such as s3:ExistingObjectTag, is also found by the keys naming one
+// of its tags, such as "s3:ExistingObjectTag/project".
+func hasConditionKey(keys condition.KeySet, key condition.Key) bool {
+	for k := range keys {
+		if tagKey, ok := k.ObjectTagKey(); k == key || (ok && tagKey == key) {
+			return true
+		}
+	}
+	return false
+}
+
+// setExistingObjectTagValues - sets the tags of the existing object as
+// condition values, such as "ExistingObjectTag/project".
+func setExistingObjectTagValues(args map[string][]string, userTags string) {
+	t, err := tags.ParseObjectTags(userTags)
+	if err != nil {
+		return
+	}
+	for k, v := range t.ToMap() {
+		args[condition.S3ExistingObjectTag.Name()+"/"+k] = []string{v}
+	}
+}
+
 // PolicyToBucketAccessPolicy converts a MinIO policy into a minio-go policy data structure.
 func PolicyToBucketAccessPolicy(bucketPolicy *policy.Policy) (*miniogopolicy.BucketAccessPolicy, error) {
 	// Return empty BucketAccessPolicy for empty bucket policy.
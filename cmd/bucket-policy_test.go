@@ -0,0 +1,163 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+func TestGetConditionValuesObjectTags(t *testing.T) {
+	r, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object?ExistingObjectTag/project=alpha", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set(xhttp.AmzObjectTagging, "project=alpha&owner=bob")
+	r.Header.Set("RequestObjectTagKeys", "admin")
+
+	values := getConditionValues(r, "", "", nil)
+	if _, ok := values["ExistingObjectTag/project"]; ok {
+		t.Fatal("expected the tags of the existing object not to be taken from the request")
+	}
+	if _, ok := values["Requestobjecttagkeys"]; ok {
+		t.Fatal("expected the request tag keys not to be taken from the request headers")
+	}
+	if got := values["RequestObjectTag/project"]; !reflect.DeepEqual(got, []string{"alpha"}) {
+		t.Fatalf("expected request tag project=alpha, got %v", got)
+	}
+	tagKeys := values["RequestObjectTagKeys"]
+	sort.Strings(tagKeys)
+	if !reflect.DeepEqual(tagKeys, []string{"owner", "project"}) {
+		t.Fatalf("expected request tag keys [owner project], got %v", tagKeys)
+	}
+
+	setExistingObjectTagValues(values, "project=beta")
+	if got := values["ExistingObjectTag/project"]; !reflect.DeepEqual(got, []string{"beta"}) {
+		t.Fatalf("expected existing object tag project=beta, got %v", got)
+	}
+}
+
+func TestIAMExistingObjectTagCondition(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminTestBed.TearDown()
+
+	globalIAMSys.Init(ctx, adminTestBed.objLayer)
+
+	if globalIAMSys.hasExistingObjectTagCondition(nil) {
+		t.Fatal("expected no policy with existing object tag conditions")
+	}
+
+	function, err := condition.NewStringEqualsFunc(condition.S3ExistingObjectTag+"/project", "alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tagged := iampolicy.Policy{
+		Version: iampolicy.DefaultVersion,
+		Statements: []iampolicy.Statement{
+			iampolicy.NewStatement(policy.Allow,
+				iampolicy.NewActionSet(iampolicy.GetObjectAction),
+				iampolicy.NewResourceSet(iampolicy.NewResource("bucket", "*")),
+				condition.NewFunctions(function)),
+		},
+	}
+	if err = globalIAMSys.SetPolicy("tagged", tagged); err != nil {
+		t.Fatal(err)
+	}
+	if !globalIAMSys.hasExistingObjectTagCondition(nil) {
+		t.Fatal("expected a policy with existing object tag conditions")
+	}
+
+	if err = globalIAMSys.DeletePolicy("tagged"); err != nil {
+		t.Fatal(err)
+	}
+	if globalIAMSys.hasExistingObjectTagCondition(nil) {
+		t.Fatal("expected no policy with existing object tag conditions after deleting it")
+	}
+}
+
+func TestGetExistingObjectTags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"tagged", "deleted"} {
+		if _, err = obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(nil), 0, "", ""), ObjectOptions{
+			Versioned:   true,
+			UserDefined: map[string]string{xhttp.AmzObjectTagging: "project=alpha"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = obj.DeleteObject(ctx, "bucket", "deleted", ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		objAPI   ObjectLayer
+		url      string
+		expected string
+		wantErr  bool
+	}{
+		{objAPI: obj, url: "http://localhost/bucket/tagged", expected: "project=alpha"},
+		// Objects which do not exist have no tags.
+		{objAPI: obj, url: "http://localhost/bucket/missing"},
+		{objAPI: obj, url: "http://localhost/bucket/deleted"},
+		// Errors reading the object are returned.
+		{objAPI: obj, url: "http://localhost/bucket/tagged?versionId=invalid", wantErr: true},
+		{objAPI: obj, url: "http://localhost/missing/tagged", wantErr: true},
+		{objAPI: nil, url: "http://localhost/bucket/tagged", wantErr: true},
+	}
+	for i, tc := range testCases {
+		setObjectLayer(tc.objAPI)
+		r, err := http.NewRequest(http.MethodGet, tc.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bucket, object := path2BucketObject(r.URL.Path)
+		userTags, err := getExistingObjectTags(ctx, r, bucket, object)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.wantErr, err)
+		}
+		if userTags != tc.expected {
+			t.Fatalf("Test %d: expected tags %q, got %q", i+1, tc.expected, userTags)
+		}
+	}
+}
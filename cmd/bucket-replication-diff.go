@@ -0,0 +1,166 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// replicationDiffTarget - a remote target versions are compared against.
+type replicationDiffTarget struct {
+	arn    string
+	bucket string
+	client *TargetClient
+}
+
+// getReplicationDiffTargets returns the remote targets of the bucket to compare
+// against, only the target arn if set. All targets must be online.
+func getReplicationDiffTargets(ctx context.Context, bucket, arn string) (*replication.Config, []replicationDiffTarget, error) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+	arns := cfg.TargetArns()
+	if arn != "" {
+		if !contains(arns, arn) {
+			return nil, nil, BucketRemoteTargetNotFound{Bucket: bucket}
+		}
+		arns = []string{arn}
+	}
+
+	targets := make([]replicationDiffTarget, 0, len(arns))
+	for _, arn := range arns {
+		tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+		if tgt == nil {
+			return nil, nil, BucketRemoteTargetNotFound{Bucket: bucket}
+		}
+		if tgt.isOffline() {
+			return nil, nil, BucketRemoteConnectionErr{Bucket: bucket}
+		}
+		targets = append(targets, replicationDiffTarget{
+			arn:    arn,
+			bucket: cfg.GetTargetDestination(arn).Bucket,
+			client: tgt,
+		})
+	}
+	return cfg, targets, nil
+}
+
+// replicationDiff compares every version of the bucket under prefix against the
+// remote targets and sends the versions which are not in sync to diffCh. With
+// requeue set they are also queued for replication to the target.
+func replicationDiff(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, cfg *replication.Config, targets []replicationDiffTarget, requeue bool, diffCh chan<- madmin.ReplicationDiffEntry) error {
+	var marker, versionIDMarker string
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, bucket, prefix, marker, versionIDMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, oi := range loi.Objects {
+			// Replicas are not replicated back, versions pending
+			// a purge are not expected on the target anymore.
+			if oi.ReplicationStatus == replication.Replica || !oi.VersionPurgeStatus.Empty() {
+				continue
+			}
+			statuses := replication.ParseTargetStatuses(oi.UserDefined[replicationTargetStatusKey])
+			for _, tgt := range targets {
+				if !cfg.Replicate(replication.ObjectOpts{
+					Name:         oi.Name,
+					UserTags:     oi.UserTags,
+					DeleteMarker: oi.DeleteMarker,
+					SSEC:         crypto.SSEC.IsEncrypted(oi.UserDefined),
+					TargetArn:    tgt.arn,
+				}) {
+					continue
+				}
+
+				entry, ok := diffReplicatedVersion(ctx, oi, tgt)
+				if !ok {
+					continue
+				}
+				entry.ReplicationStatus = string(statuses[tgt.arn])
+				if entry.ReplicationStatus == "" {
+					entry.ReplicationStatus = string(oi.ReplicationStatus)
+				}
+				if requeue && entry.Status != madmin.ReplicationDiffUnknown {
+					if err = queueReplicaResyncVersion(ctx, oi, tgt.arn); err != nil {
+						return err
+					}
+					entry.Requeued = true
+				}
+
+				select {
+				case diffCh <- entry:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker, versionIDMarker = loi.NextMarker, loi.NextVersionIDMarker
+	}
+}
+
+// diffReplicatedVersion compares the version against the remote target the same way
+// replication does, returns false when the version is in sync on the target.
+func diffReplicatedVersion(ctx context.Context, oi ObjectInfo, tgt replicationDiffTarget) (entry madmin.ReplicationDiffEntry, ok bool) {
+	entry = madmin.ReplicationDiffEntry{
+		Object:         oi.Name,
+		VersionID:      oi.VersionID,
+		IsDeleteMarker: oi.DeleteMarker,
+		Arn:            tgt.arn,
+	}
+
+	toi, err := tgt.client.StatObject(ctx, tgt.bucket, oi.Name, miniogo.StatObjectOptions{
+		VersionID: oi.VersionID,
+		Internal: miniogo.AdvancedGetOptions{
+			ReplicationProxyRequest: "false",
+		}})
+	if oi.DeleteMarker && toi.IsDeleteMarker {
+		// Stat of a delete marker fails, the marker itself is in sync.
+		return entry, false
+	}
+	if err != nil {
+		if miniogo.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			entry.Status = madmin.ReplicationDiffMissing
+		} else {
+			entry.Status = madmin.ReplicationDiffUnknown
+			entry.Error = err.Error()
+		}
+		return entry, true
+	}
+
+	switch getReplicationAction(oi, toi) {
+	case replicateNone:
+		return entry, false
+	case replicateMetadata:
+		entry.Status = madmin.ReplicationDiffMetadata
+	default:
+		entry.Status = madmin.ReplicationDiffStale
+	}
+	return entry, true
+}
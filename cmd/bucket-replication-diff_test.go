@@ -0,0 +1,269 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// testReplicationRemote - a remote target serving the versions set on it.
+type testReplicationRemote struct {
+	sync.Mutex
+	// versions maps a version id to the response to its HEAD.
+	versions map[string]func(w http.ResponseWriter)
+}
+
+func (rr *testReplicationRemote) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+		return
+	}
+	rr.Lock()
+	respond, ok := rr.versions[r.URL.Query().Get("versionId")]
+	rr.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	respond(w)
+}
+
+// set makes the remote reply to the HEAD of the version with the object info.
+func (rr *testReplicationRemote) set(oi ObjectInfo) {
+	rr.Lock()
+	defer rr.Unlock()
+	rr.versions[oi.VersionID] = func(w http.ResponseWriter) {
+		if oi.DeleteMarker {
+			w.Header().Set(xhttp.AmzDeleteMarker, "true")
+			w.Header().Set(xhttp.AmzVersionID, oi.VersionID)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set(xhttp.ETag, "\""+oi.ETag+"\"")
+		w.Header().Set(xhttp.LastModified, oi.ModTime.UTC().Format(http.TimeFormat))
+		w.Header().Set(xhttp.ContentLength, strconv.FormatInt(oi.Size, 10))
+		w.Header().Set(xhttp.ContentType, oi.ContentType)
+		w.Header().Set(xhttp.AmzVersionID, oi.VersionID)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// deny makes the remote reject the HEAD of the version.
+func (rr *testReplicationRemote) deny(versionID string) {
+	rr.Lock()
+	defer rr.Unlock()
+	rr.versions[versionID] = func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusForbidden)
+	}
+}
+
+// newTestReplicationRemote starts a remote target and returns an online client to it.
+func newTestReplicationRemote(t *testing.T) (*testReplicationRemote, *TargetClient, func()) {
+	t.Helper()
+	rr := &testReplicationRemote{versions: make(map[string]func(w http.ResponseWriter))}
+	ts := httptest.NewServer(rr)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		ts.Close()
+		t.Fatal(err)
+	}
+	clnt, err := miniogo.New(u.Host, &miniogo.Options{
+		Creds: credentials.NewStaticV4("minio", "minio123", ""),
+	})
+	if err != nil {
+		ts.Close()
+		t.Fatal(err)
+	}
+	return rr, &TargetClient{Client: clnt, up: 1, bucket: "dst1"}, ts.Close
+}
+
+func TestReplicationDiff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareReplicationBucket(ctx, t, "bucket")
+	defer removeRoots(disks)
+
+	prevState := globalReplicationState
+	globalReplicationState = newReplicationState()
+	defer func() { globalReplicationState = prevState }()
+
+	remote, clnt, closeRemote := newTestReplicationRemote(t)
+	defer closeRemote()
+
+	put := func(object string, opts ObjectOptions) ObjectInfo {
+		t.Helper()
+		data := []byte(object)
+		opts.Versioned = true
+		oi, err := z.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+
+	// In sync on the remote.
+	remote.set(put("object1", ObjectOptions{}))
+	// Missing on the remote.
+	missing := put("object2", ObjectOptions{})
+	// Different content on the remote.
+	stale := put("object3", ObjectOptions{})
+	staleRemote := stale
+	staleRemote.ETag = "d41d8cd98f00b204e9800998ecf8427e"
+	remote.set(staleRemote)
+	// Different metadata on the remote.
+	metadata := put("object4", ObjectOptions{UserDefined: map[string]string{"content-type": "text/plain"}})
+	metadataRemote := metadata
+	metadataRemote.ContentType = "application/octet-stream"
+	remote.set(metadataRemote)
+	// Not readable on the remote.
+	unknown := put("object5", ObjectOptions{})
+	remote.deny(unknown.VersionID)
+	// Replicas are not compared.
+	put("object6", ObjectOptions{UserDefined: map[string]string{xhttp.AmzBucketReplicationStatus: "REPLICA"}})
+	// Out of the prefix.
+	put("other", ObjectOptions{})
+
+	cfg, err := getReplicationConfig(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets := []replicationDiffTarget{{arn: testReplicationArn1, bucket: "dst1", client: clnt}}
+
+	expected := map[string]madmin.ReplicationDiffEntry{
+		missing.Name:  {Object: missing.Name, VersionID: missing.VersionID, Arn: testReplicationArn1, Status: madmin.ReplicationDiffMissing, Requeued: true},
+		stale.Name:    {Object: stale.Name, VersionID: stale.VersionID, Arn: testReplicationArn1, Status: madmin.ReplicationDiffStale, Requeued: true},
+		metadata.Name: {Object: metadata.Name, VersionID: metadata.VersionID, Arn: testReplicationArn1, Status: madmin.ReplicationDiffMetadata, Requeued: true},
+		unknown.Name:  {Object: unknown.Name, VersionID: unknown.VersionID, Arn: testReplicationArn1, Status: madmin.ReplicationDiffUnknown},
+	}
+
+	for _, requeue := range []bool{false, true} {
+		diffCh := make(chan madmin.ReplicationDiffEntry, 100)
+		if err = replicationDiff(ctx, z, "bucket", "object", cfg, targets, requeue, diffCh); err != nil {
+			t.Fatal(err)
+		}
+		close(diffCh)
+
+		found := make(map[string]bool)
+		for entry := range diffCh {
+			exp, ok := expected[entry.Object]
+			if !ok {
+				t.Fatalf("Requeue %t: unexpected diff of %s: %+v", requeue, entry.Object, entry)
+			}
+			if entry.VersionID != exp.VersionID || entry.Arn != exp.Arn || entry.Status != exp.Status {
+				t.Fatalf("Requeue %t: expected diff %+v, got %+v", requeue, exp, entry)
+			}
+			if entry.Requeued != (requeue && exp.Requeued) {
+				t.Fatalf("Requeue %t: expected %s requeued %t, got %t", requeue, entry.Object, requeue && exp.Requeued, entry.Requeued)
+			}
+			if exp.Status == madmin.ReplicationDiffUnknown && entry.Error == "" {
+				t.Fatalf("Requeue %t: expected the error comparing %s", requeue, entry.Object)
+			}
+			found[entry.Object] = true
+		}
+		if len(found) != len(expected) {
+			t.Fatalf("Requeue %t: expected diffs of %d versions, got %v", requeue, len(expected), found)
+		}
+
+		names := queuedResyncTasks(t, globalReplicationState, testReplicationArn1)
+		if !requeue && len(names) != 0 {
+			t.Fatalf("expected no versions queued without requeue, got %v", names)
+		}
+		if requeue && len(names) != 3 {
+			t.Fatalf("expected the missing, stale and diverged versions queued, got %v", names)
+		}
+	}
+}
+
+func TestDiffReplicatedVersionDeleteMarker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	remote, clnt, closeRemote := newTestReplicationRemote(t)
+	defer closeRemote()
+	tgt := replicationDiffTarget{arn: testReplicationArn1, bucket: "dst1", client: clnt}
+
+	marker := ObjectInfo{Bucket: "bucket", Name: "object", VersionID: mustGetUUID(), DeleteMarker: true}
+	if entry, ok := diffReplicatedVersion(ctx, marker, tgt); !ok || entry.Status != madmin.ReplicationDiffMissing || !entry.IsDeleteMarker {
+		t.Fatalf("expected the delete marker to be missing on the remote, got %t %+v", ok, entry)
+	}
+
+	remote.set(marker)
+	if entry, ok := diffReplicatedVersion(ctx, marker, tgt); ok {
+		t.Fatalf("expected the delete marker to be in sync, got %+v", entry)
+	}
+}
+
+func TestGetReplicationDiffTargets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, disks := prepareReplicationBucket(ctx, t, "bucket")
+	defer removeRoots(disks)
+
+	prevTargetSys := globalBucketTargetSys
+	globalBucketTargetSys = NewBucketTargetSys()
+	defer func() { globalBucketTargetSys = prevTargetSys }()
+
+	_, clnt1, closeRemote1 := newTestReplicationRemote(t)
+	defer closeRemote1()
+	_, clnt2, closeRemote2 := newTestReplicationRemote(t)
+	defer closeRemote2()
+	globalBucketTargetSys.arnRemotesMap[testReplicationArn1] = clnt1
+
+	// The second target has no client.
+	if _, _, err := getReplicationDiffTargets(ctx, "bucket", ""); !errors.As(err, &BucketRemoteTargetNotFound{}) {
+		t.Fatalf("expected BucketRemoteTargetNotFound, got %v", err)
+	}
+	if _, _, err := getReplicationDiffTargets(ctx, "bucket", "arn:minio:replication:us-east-1:id3:dst3"); !errors.As(err, &BucketRemoteTargetNotFound{}) {
+		t.Fatalf("expected BucketRemoteTargetNotFound for a target not in the config, got %v", err)
+	}
+
+	_, targets, err := getReplicationDiffTargets(ctx, "bucket", testReplicationArn1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 1 || targets[0].arn != testReplicationArn1 || targets[0].client != clnt1 {
+		t.Fatalf("expected only the target %s, got %+v", testReplicationArn1, targets)
+	}
+
+	globalBucketTargetSys.arnRemotesMap[testReplicationArn2] = clnt2
+	if _, targets, err = getReplicationDiffTargets(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 2 {
+		t.Fatalf("expected all targets of the bucket, got %+v", targets)
+	}
+
+	clnt2.up = 0
+	if _, _, err = getReplicationDiffTargets(ctx, "bucket", ""); !errors.As(err, &BucketRemoteConnectionErr{}) {
+		t.Fatalf("expected BucketRemoteConnectionErr for an offline target, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+const (
+	// Pending replication tasks are journaled under
+	// .minio.sys/buckets/.replication/<node>/<task-id>
+	replicationJournalPrefix = bucketMetaPrefix + SlashSeparator + ".replication"
+)
+
+// replicationJournalEntry - a pending replication task, Delete is
+// set for delete replication tasks.
+type replicationJournalEntry struct {
+	Bucket    string `json:"bucket"`
+	Object    string `json:"object"`
+	VersionID string `json:"versionId,omitempty"`
+	TargetArn string `json:"targetArn,omitempty"`
+	// Remote targets and size the task is accounted for in the replication metrics.
+	Arns   []string       `json:"arns,omitempty"`
+	Size   int64          `json:"size,omitempty"`
+	Delete *DeletedObject `json:"delete,omitempty"`
+}
+
+// replicationTaskID returns the journal ID of the replication task, the
+// ID is the same for repeated tasks of an object version so that they
+// are journaled only once.
+func replicationTaskID(bucket, object, versionID, targetArn string, isDelete bool) string {
+	kind := "put"
+	if isDelete {
+		kind = "delete"
+	}
+	return getSHA256Hash([]byte(kind + SlashSeparator + pathJoin(bucket, object) + SlashSeparator + versionID + SlashSeparator + targetArn))
+}
+
+// Journal writes of a task are serialized over this many locks, by the
+// journal ID of the task.
+const replicationJournalLocks = 256
+
+// replicationJournal persists pending replication tasks on the drives of
+// the erasure set owning the object in the pool the object is placed in,
+// so that queued tasks survive restarts and crashes. Every node journals
+// into its own directory and only replays its own tasks. A task is written
+// to a write quorum of the drives before it is queued.
+type replicationJournal struct {
+	z      *erasureServerPools
+	prefix string
+	// Adding and removing a task are made under the lock of its journal
+	// ID, so that a removal does not delete a task journaled again.
+	locks [replicationJournalLocks]sync.Mutex
+}
+
+func newReplicationJournal(objAPI ObjectLayer) *replicationJournal {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return nil
+	}
+	node := getSHA256Hash([]byte(GetLocalPeer(globalEndpoints)))
+	return &replicationJournal{
+		z:      z,
+		prefix: pathJoin(replicationJournalPrefix, node[:16]),
+	}
+}
+
+// lock returns the lock of the journal ID.
+func (j *replicationJournal) lock(id string) *sync.Mutex {
+	return &j.locks[crcHashMod(id, len(j.locks))]
+}
+
+// getHashedSet returns the erasure set journaling the task, in the pool
+// the object version is placed in or would be written to.
+func (j *replicationJournal) getHashedSet(ctx context.Context, entry replicationJournalEntry) (*erasureObjects, error) {
+	idx, err := j.z.getPoolIdx(ctx, entry.Bucket, encodeDirObject(entry.Object), ObjectOptions{
+		VersionID: entry.VersionID,
+	}, entry.Size)
+	if err != nil {
+		return nil, err
+	}
+	return j.z.serverPools[idx].getHashedSet(entry.Object), nil
+}
+
+// add journals the task, the task is durable once it is written to
+// a write quorum of the drives of the erasure set. The task is written
+// aside and renamed in place so that it replaces a previous entry of
+// the journal ID at once.
+func (j *replicationJournal) add(ctx context.Context, id string, entry replicationJournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	set, err := j.getHashedSet(ctx, entry)
+	if err != nil {
+		return err
+	}
+	tmpPath := mustGetUUID()
+	disks := set.getDisks()
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			if err := disks[index].WriteAll(ctx, minioMetaTmpBucket, tmpPath, data); err != nil {
+				return err
+			}
+			return disks[index].RenameFile(ctx, minioMetaTmpBucket, tmpPath, minioMetaBucket, pathJoin(j.prefix, id))
+		}, index)
+	}
+	return reduceWriteQuorumErrs(ctx, g.Wait(), objectOpIgnoredErrs, len(disks)/2+1)
+}
+
+// remove deletes the task from the journal. The task is removed from the
+// erasure set of the object in every pool, the pool the object is placed
+// in may have changed since the task was journaled.
+func (j *replicationJournal) remove(ctx context.Context, object, id string) {
+	for _, pool := range j.z.serverPools {
+		disks := pool.getHashedSet(object).getDisks()
+		g := errgroup.WithNErrs(len(disks))
+		for index := range disks {
+			index := index
+			g.Go(func() error {
+				if disks[index] == nil {
+					return errDiskNotFound
+				}
+				return disks[index].Delete(ctx, minioMetaBucket, pathJoin(j.prefix, id), false)
+			}, index)
+		}
+		g.Wait()
+	}
+}
+
+// walk calls fn for every task journaled by this node, stops
+// early when fn returns false.
+func (j *replicationJournal) walk(ctx context.Context, fn func(id string, entry replicationJournalEntry) bool) {
+	for _, pool := range j.z.serverPools {
+		for _, set := range pool.sets {
+			disks := set.getDisks()
+
+			// A task may be missing on some of the drives, list
+			// the journal on all of them.
+			ids := make(map[string]struct{})
+			for _, disk := range disks {
+				if disk == nil {
+					continue
+				}
+				entries, err := disk.ListDir(ctx, minioMetaBucket, j.prefix, -1)
+				if err != nil {
+					continue
+				}
+				for _, id := range entries {
+					ids[id] = struct{}{}
+				}
+			}
+
+			for id := range ids {
+				entry, ok := j.read(ctx, disks, id)
+				if !ok {
+					continue
+				}
+				if !fn(id, entry) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// read returns the journaled task from the first drive it can be read from.
+func (j *replicationJournal) read(ctx context.Context, disks []StorageAPI, id string) (entry replicationJournalEntry, ok bool) {
+	for _, disk := range disks {
+		if disk == nil {
+			continue
+		}
+		data, err := disk.ReadAll(ctx, minioMetaBucket, pathJoin(j.prefix, id))
+		if err != nil {
+			continue
+		}
+		if err = json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		return entry, true
+	}
+	return entry, false
+}
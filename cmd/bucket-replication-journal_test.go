@@ -0,0 +1,78 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestReplicationJournal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	j := newReplicationJournal(obj)
+	if j == nil {
+		t.Fatal("expected a journal for erasure server pools")
+	}
+
+	journaled := func() map[string]replicationJournalEntry {
+		entries := make(map[string]replicationJournalEntry)
+		j.walk(ctx, func(id string, entry replicationJournalEntry) bool {
+			entries[id] = entry
+			return true
+		})
+		return entries
+	}
+
+	entry := replicationJournalEntry{Bucket: "bucket", Object: "object", VersionID: "v1", Size: 10,
+		Arns: []string{"arn:minio:replication::1:bucket", "arn:minio:replication::2:bucket"}}
+	id := replicationTaskID(entry.Bucket, entry.Object, entry.VersionID, "", false)
+
+	if err = j.add(ctx, id, entry); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := journaled()[id]
+	if !ok {
+		t.Fatalf("expected task %s journaled", id)
+	}
+	if !reflect.DeepEqual(got, entry) {
+		t.Fatalf("expected %v, got %v", entry, got)
+	}
+
+	// A shorter entry journaled again replaces the previous one.
+	entry.Arns = nil
+	if err = j.add(ctx, id, entry); err != nil {
+		t.Fatal(err)
+	}
+	if got = journaled()[id]; !reflect.DeepEqual(got, entry) {
+		t.Fatalf("expected %v, got %v", entry, got)
+	}
+
+	j.remove(ctx, entry.Object, id)
+	if _, ok = journaled()[id]; ok {
+		t.Fatalf("expected task %s removed from the journal", id)
+	}
+}
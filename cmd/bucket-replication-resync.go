@@ -0,0 +1,339 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Resync status of every remote target is saved under
+	// .minio.sys/buckets/<bucket>/replication-resync/
+	replicationResyncDir = "replication-resync"
+)
+
+var errReplicationResyncStopped = errors.New("replication resync is no longer running")
+
+// replicationResyncFile returns the path of the resync status of the
+// bucket to the remote target arn.
+func replicationResyncFile(bucket, arn string) string {
+	return pathJoin(bucketMetaPrefix, bucket, replicationResyncDir, getSHA256Hash([]byte(arn))[:16]+".json")
+}
+
+// replicationResyncSys queues the existing objects of a bucket for replication
+// to a remote target. The progress of each resync is saved after every listed
+// page, resyncs interrupted by a restart resume on the node which started them.
+type replicationResyncSys struct {
+	sync.Mutex
+	// resyncs running on this node, by status file.
+	running map[string]*replicationResyncJob
+}
+
+type replicationResyncJob struct {
+	cancel context.CancelFunc
+}
+
+func newReplicationResyncSys() *replicationResyncSys {
+	return &replicationResyncSys{
+		running: make(map[string]*replicationResyncJob),
+	}
+}
+
+var globalReplicationResyncSys *replicationResyncSys
+
+func loadReplicationResync(ctx context.Context, objAPI ObjectLayer, bucket, arn string) (status madmin.ReplicationResyncStatus, err error) {
+	data, err := readConfig(ctx, objAPI, replicationResyncFile(bucket, arn))
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketReplicationResyncNotFound{Bucket: bucket}
+		}
+		return status, err
+	}
+	err = json.Unmarshal(data, &status)
+	return status, err
+}
+
+// updateReplicationResync updates the saved resync status with fn, fn may
+// return an error to leave the status unchanged. The update is serialized
+// across the cluster so that a cancel is never overwritten by the resync.
+func updateReplicationResync(ctx context.Context, objAPI ObjectLayer, bucket, arn string, fn func(status *madmin.ReplicationResyncStatus, found bool) error) (status madmin.ReplicationResyncStatus, err error) {
+	file := replicationResyncFile(bucket, arn)
+	lk := objAPI.NewNSLock(minioMetaBucket, file+".lock")
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return status, err
+	}
+	defer lk.Unlock()
+
+	status, err = loadReplicationResync(ctx, objAPI, bucket, arn)
+	found := err == nil
+	if err != nil && !errors.As(err, &BucketReplicationResyncNotFound{}) {
+		return status, err
+	}
+	if err = fn(&status, found); err != nil {
+		return status, err
+	}
+	status.LastUpdate = UTCNow()
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		return status, err
+	}
+	return status, saveConfig(ctx, objAPI, file, data)
+}
+
+// Start starts the resync of the bucket to the remote target arn.
+func (sys *replicationResyncSys) Start(ctx context.Context, objAPI ObjectLayer, bucket, arn string) (madmin.ReplicationResyncStatus, error) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return madmin.ReplicationResyncStatus{}, err
+	}
+	if !contains(cfg.TargetArns(), arn) {
+		return madmin.ReplicationResyncStatus{}, BucketRemoteTargetNotFound{Bucket: bucket}
+	}
+
+	status, err := updateReplicationResync(ctx, objAPI, bucket, arn, func(status *madmin.ReplicationResyncStatus, found bool) error {
+		if found && status.State == madmin.ResyncRunning {
+			return BucketReplicationResyncInProgress{Bucket: bucket}
+		}
+		now := UTCNow()
+		*status = madmin.ReplicationResyncStatus{
+			Bucket:    bucket,
+			Arn:       arn,
+			State:     madmin.ResyncRunning,
+			Node:      GetLocalPeer(globalEndpoints),
+			StartTime: now,
+		}
+		return nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	sys.run(objAPI, status)
+	return status, nil
+}
+
+// Status returns the progress of the resync of the bucket to the remote target arn.
+func (sys *replicationResyncSys) Status(ctx context.Context, objAPI ObjectLayer, bucket, arn string) (madmin.ReplicationResyncStatus, error) {
+	return loadReplicationResync(ctx, objAPI, bucket, arn)
+}
+
+// Cancel cancels the resync of the bucket to the remote target arn, versions
+// already queued are still replicated.
+func (sys *replicationResyncSys) Cancel(ctx context.Context, objAPI ObjectLayer, bucket, arn string) (madmin.ReplicationResyncStatus, error) {
+	status, err := updateReplicationResync(ctx, objAPI, bucket, arn, func(status *madmin.ReplicationResyncStatus, found bool) error {
+		if !found {
+			return BucketReplicationResyncNotFound{Bucket: bucket}
+		}
+		if status.State == madmin.ResyncRunning {
+			status.State = madmin.ResyncCanceled
+		}
+		return nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	// A resync running on another node stops at its next checkpoint.
+	sys.Lock()
+	if job, ok := sys.running[replicationResyncFile(bucket, arn)]; ok {
+		job.cancel()
+	}
+	sys.Unlock()
+	return status, nil
+}
+
+// resume restarts the resyncs this node was running before a restart.
+func (sys *replicationResyncSys) resume(ctx context.Context, objAPI ObjectLayer) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	localPeer := GetLocalPeer(globalEndpoints)
+	for _, bucket := range buckets {
+		prefix := pathJoin(bucketMetaPrefix, bucket.Name, replicationResyncDir) + SlashSeparator
+		var marker string
+		for {
+			result, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				break
+			}
+			for _, obj := range result.Objects {
+				data, err := readConfig(ctx, objAPI, obj.Name)
+				if err != nil {
+					logger.LogIf(ctx, err)
+					continue
+				}
+				var status madmin.ReplicationResyncStatus
+				if err = json.Unmarshal(data, &status); err != nil {
+					logger.LogIf(ctx, err)
+					continue
+				}
+				if status.State == madmin.ResyncRunning && status.Node == localPeer {
+					sys.run(objAPI, status)
+				}
+			}
+
+			if !result.IsTruncated {
+				break
+			}
+
+			marker = result.NextMarker
+		}
+	}
+}
+
+// run runs the resync in the background until it completes, fails or is canceled.
+func (sys *replicationResyncSys) run(objAPI ObjectLayer, status madmin.ReplicationResyncStatus) {
+	file := replicationResyncFile(status.Bucket, status.Arn)
+	ctx, cancel := context.WithCancel(GlobalContext)
+	job := &replicationResyncJob{cancel: cancel}
+
+	sys.Lock()
+	if prev, ok := sys.running[file]; ok {
+		// A canceled resync may still be winding down.
+		prev.cancel()
+	}
+	sys.running[file] = job
+	sys.Unlock()
+
+	go func() {
+		defer func() {
+			sys.Lock()
+			if sys.running[file] == job {
+				delete(sys.running, file)
+			}
+			sys.Unlock()
+			cancel()
+		}()
+
+		resyncErr := resyncBucket(ctx, objAPI, &status)
+		if ctx.Err() != nil || errors.Is(resyncErr, errReplicationResyncStopped) {
+			// Canceled or shutting down, the status is left as is
+			// for a cancel and resumed from the last checkpoint
+			// after a restart.
+			return
+		}
+		_, err := updateReplicationResync(GlobalContext, objAPI, status.Bucket, status.Arn, func(s *madmin.ReplicationResyncStatus, found bool) error {
+			if !found || s.State != madmin.ResyncRunning {
+				return errReplicationResyncStopped
+			}
+			*s = status
+			if resyncErr != nil {
+				s.State = madmin.ResyncFailed
+				s.Error = resyncErr.Error()
+			} else {
+				s.State = madmin.ResyncCompleted
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errReplicationResyncStopped) {
+			logger.LogIf(GlobalContext, fmt.Errorf("Unable to save replication resync status of %s: %w", status.Bucket, err))
+		}
+	}()
+}
+
+// resyncBucket queues every version of the bucket which replicates to the remote
+// target for replication to it, starting after the last checkpoint in status.
+func resyncBucket(ctx context.Context, objAPI ObjectLayer, status *madmin.ReplicationResyncStatus) error {
+	bucket, arn := status.Bucket, status.Arn
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, bucket, "", status.Object, status.VersionID, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		// Pick up changes to the replication configuration.
+		cfg, err := getReplicationConfig(ctx, bucket)
+		if err != nil {
+			return err
+		}
+
+		for _, oi := range loi.Objects {
+			status.ScannedCount++
+			// Replicas are not replicated back, versions pending
+			// a purge are replicated by the crawler.
+			if oi.ReplicationStatus == replication.Replica || !oi.VersionPurgeStatus.Empty() {
+				continue
+			}
+			if !cfg.Replicate(replication.ObjectOpts{
+				Name:         oi.Name,
+				UserTags:     oi.UserTags,
+				DeleteMarker: oi.DeleteMarker,
+				SSEC:         crypto.SSEC.IsEncrypted(oi.UserDefined),
+				TargetArn:    arn,
+			}) {
+				continue
+			}
+			if err = queueReplicaResyncVersion(ctx, oi, arn); err != nil {
+				return err
+			}
+			if !oi.DeleteMarker {
+				status.QueuedSize += oi.Size
+			}
+			status.QueuedCount++
+		}
+
+		if !loi.IsTruncated {
+			return nil
+		}
+
+		// Checkpoint, the resync stops if it was canceled meanwhile.
+		status.Object, status.VersionID = loi.NextMarker, loi.NextVersionIDMarker
+		if _, err = updateReplicationResync(ctx, objAPI, bucket, arn, func(s *madmin.ReplicationResyncStatus, found bool) error {
+			if !found || s.State != madmin.ResyncRunning {
+				return errReplicationResyncStopped
+			}
+			*s = *status
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// queueReplicaResyncVersion queues the object version or delete marker
+// for replication to the remote target arn.
+func queueReplicaResyncVersion(ctx context.Context, oi ObjectInfo, arn string) error {
+	if oi.DeleteMarker {
+		return globalReplicationState.queueReplicaDeleteResyncTask(ctx, DeletedObjectVersionInfo{
+			DeletedObject: DeletedObject{
+				ObjectName:                    oi.Name,
+				DeleteMarkerVersionID:         oi.VersionID,
+				DeleteMarkerReplicationStatus: string(oi.ReplicationStatus),
+				DeleteMarkerMTime:             DeleteMarkerMTime{oi.ModTime},
+				DeleteMarker:                  true,
+			},
+			Bucket:    oi.Bucket,
+			TargetArn: arn,
+		})
+	}
+	return globalReplicationState.queueReplicaResyncTask(ctx, replicateObjectInfo{
+		ObjectInfo: oi,
+		TargetArn:  arn,
+	})
+}
@@ -0,0 +1,198 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// waitReplicationResync waits until the resync is no longer running.
+func waitReplicationResync(ctx context.Context, t *testing.T, sys *replicationResyncSys, objAPI ObjectLayer, bucket, arn string) madmin.ReplicationResyncStatus {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		status, err := sys.Status(ctx, objAPI, bucket, arn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.State != madmin.ResyncRunning {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the resync to %s to complete", arn)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// queuedResyncTasks returns the object names of the queued tasks, all
+// of them have to be queued for the remote target arn.
+func queuedResyncTasks(t *testing.T, r *replicationState, arn string) []string {
+	t.Helper()
+	var names []string
+	for {
+		select {
+		case ri := <-r.replicaCh:
+			if ri.TargetArn != arn {
+				t.Fatalf("expected %s to be queued for %s, got %s", ri.Name, arn, ri.TargetArn)
+			}
+			names = append(names, ri.Name)
+		default:
+			return names
+		}
+	}
+}
+
+func TestReplicationResyncStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareReplicationBucket(ctx, t, "bucket")
+	defer removeRoots(disks)
+
+	prevState := globalReplicationState
+	globalReplicationState = newReplicationState()
+	defer func() { globalReplicationState = prevState }()
+
+	objects := []string{"object1", "object2", "object3"}
+	for _, object := range objects {
+		data := []byte(object)
+		if _, err := z.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sys := newReplicationResyncSys()
+	if _, err := sys.Status(ctx, z, "bucket", testReplicationArn1); !errors.As(err, &BucketReplicationResyncNotFound{}) {
+		t.Fatalf("expected BucketReplicationResyncNotFound before a resync, got %v", err)
+	}
+	if _, err := sys.Start(ctx, z, "bucket", "arn:minio:replication:us-east-1:id3:dst3"); !errors.As(err, &BucketRemoteTargetNotFound{}) {
+		t.Fatalf("expected BucketRemoteTargetNotFound for an unknown target, got %v", err)
+	}
+	if _, err := sys.Cancel(ctx, z, "bucket", testReplicationArn1); !errors.As(err, &BucketReplicationResyncNotFound{}) {
+		t.Fatalf("expected BucketReplicationResyncNotFound canceling no resync, got %v", err)
+	}
+
+	status, err := sys.Start(ctx, z, "bucket", testReplicationArn1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != madmin.ResyncRunning || status.Arn != testReplicationArn1 {
+		t.Fatalf("expected a running resync to %s, got %+v", testReplicationArn1, status)
+	}
+
+	status = waitReplicationResync(ctx, t, sys, z, "bucket", testReplicationArn1)
+	if status.State != madmin.ResyncCompleted {
+		t.Fatalf("expected the resync to complete, got %+v", status)
+	}
+	if status.ScannedCount != uint64(len(objects)) || status.QueuedCount != uint64(len(objects)) {
+		t.Fatalf("expected %d versions scanned and queued, got %+v", len(objects), status)
+	}
+	if names := queuedResyncTasks(t, globalReplicationState, testReplicationArn1); len(names) != len(objects) {
+		t.Fatalf("expected %d versions queued, got %v", len(objects), names)
+	}
+}
+
+func TestReplicationResyncResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareReplicationBucket(ctx, t, "bucket")
+	defer removeRoots(disks)
+
+	prevState := globalReplicationState
+	globalReplicationState = newReplicationState()
+	defer func() { globalReplicationState = prevState }()
+
+	var versions []ObjectInfo
+	for _, object := range []string{"object1", "object2", "object3"} {
+		data := []byte(object)
+		oi, err := z.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, oi)
+	}
+
+	save := func(status madmin.ReplicationResyncStatus) {
+		t.Helper()
+		data, err := json.Marshal(status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = saveConfig(ctx, z, replicationResyncFile(status.Bucket, status.Arn), data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A resync of this node interrupted after its first checkpoint,
+	// and a resync running on another node.
+	save(madmin.ReplicationResyncStatus{
+		Bucket:       "bucket",
+		Arn:          testReplicationArn1,
+		State:        madmin.ResyncRunning,
+		Node:         GetLocalPeer(globalEndpoints),
+		Object:       versions[0].Name,
+		VersionID:    versions[0].VersionID,
+		ScannedCount: 1,
+		QueuedCount:  1,
+	})
+	save(madmin.ReplicationResyncStatus{
+		Bucket: "bucket",
+		Arn:    testReplicationArn2,
+		State:  madmin.ResyncRunning,
+		Node:   "other-node:9000",
+	})
+
+	sys := newReplicationResyncSys()
+	sys.resume(ctx, z)
+
+	status := waitReplicationResync(ctx, t, sys, z, "bucket", testReplicationArn1)
+	if status.State != madmin.ResyncCompleted {
+		t.Fatalf("expected the resumed resync to complete, got %+v", status)
+	}
+	if status.ScannedCount != 3 || status.QueuedCount != 3 {
+		t.Fatalf("expected the counts to continue from the checkpoint, got %+v", status)
+	}
+	names := queuedResyncTasks(t, globalReplicationState, testReplicationArn1)
+	if len(names) != 2 || names[0] != "object2" || names[1] != "object3" {
+		t.Fatalf("expected the versions after the checkpoint to be queued, got %v", names)
+	}
+
+	status, err := sys.Status(ctx, z, "bucket", testReplicationArn2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != madmin.ResyncRunning || status.ScannedCount != 0 {
+		t.Fatalf("expected the resync of another node not to be resumed, got %+v", status)
+	}
+
+	// The resync of another node is canceled through the saved status.
+	if status, err = sys.Cancel(ctx, z, "bucket", testReplicationArn2); err != nil {
+		t.Fatal(err)
+	}
+	if status.State != madmin.ResyncCanceled {
+		t.Fatalf("expected the resync to be canceled, got %+v", status)
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// replicationStats - live replication metrics of this server by bucket
+// and remote target ARN, unlike the replication usage computed by the
+// crawler they reflect the state of replication right now.
+type replicationStats struct {
+	sync.Mutex
+	buckets map[string]map[string]*madmin.TargetReplicationMetrics
+}
+
+func newReplicationStats() *replicationStats {
+	return &replicationStats{
+		buckets: make(map[string]map[string]*madmin.TargetReplicationMetrics),
+	}
+}
+
+var globalReplicationStats *replicationStats
+
+// target returns the metrics of the remote target, must be called with the lock held.
+func (s *replicationStats) target(bucket, arn string) *madmin.TargetReplicationMetrics {
+	targets, ok := s.buckets[bucket]
+	if !ok {
+		targets = make(map[string]*madmin.TargetReplicationMetrics)
+		s.buckets[bucket] = targets
+	}
+	t, ok := targets[arn]
+	if !ok {
+		t = &madmin.TargetReplicationMetrics{Arn: arn}
+		targets[arn] = t
+	}
+	return t
+}
+
+// decrementStat subtracts n from v without wrapping around.
+func decrementStat(v *uint64, n uint64) {
+	if *v < n {
+		*v = 0
+		return
+	}
+	*v -= n
+}
+
+// queued records a replication task queued for the remote targets.
+func (s *replicationStats) queued(bucket string, arns []string, size int64) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	for _, arn := range arns {
+		t := s.target(bucket, arn)
+		t.QueuedCount++
+		t.QueuedSize += uint64(size)
+	}
+}
+
+// dequeued records a replication task taken out of the queue.
+func (s *replicationStats) dequeued(bucket string, arns []string, size int64) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	for _, arn := range arns {
+		t := s.target(bucket, arn)
+		decrementStat(&t.QueuedCount, 1)
+		decrementStat(&t.QueuedSize, uint64(size))
+	}
+}
+
+// started records a replication to the remote target in progress.
+func (s *replicationStats) started(bucket, arn string, size int64) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	t := s.target(bucket, arn)
+	t.InflightCount++
+	t.InflightSize += uint64(size)
+}
+
+// finished records the outcome of a replication to the remote target.
+func (s *replicationStats) finished(bucket, arn string, size int64, status replication.StatusType) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	t := s.target(bucket, arn)
+	decrementStat(&t.InflightCount, 1)
+	decrementStat(&t.InflightSize, uint64(size))
+	if status == replication.Completed {
+		t.CompletedCount++
+		t.CompletedSize += uint64(size)
+		t.LastReplicationTime = UTCNow()
+	} else {
+		t.FailedCount++
+		t.FailedSize += uint64(size)
+	}
+}
+
+// roundTrip records the round trip time of a request to the remote target.
+func (s *replicationStats) roundTrip(bucket, arn string, d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	t := s.target(bucket, arn)
+	if t.Latency == 0 {
+		t.Latency = d
+		return
+	}
+	// Exponentially weighted moving average.
+	t.Latency = (t.Latency*7 + d) / 8
+}
+
+// getBucket returns the metrics of the bucket on this server.
+func (s *replicationStats) getBucket(bucket string) madmin.BucketReplicationMetrics {
+	m := madmin.BucketReplicationMetrics{
+		Bucket:     bucket,
+		QueueDepth: uint64(globalReplicationState.queued()),
+		Targets:    make(map[string]madmin.TargetReplicationMetrics),
+	}
+	if s == nil {
+		return m
+	}
+	s.Lock()
+	defer s.Unlock()
+	for arn, t := range s.buckets[bucket] {
+		m.Targets[arn] = *t
+	}
+	return m
+}
+
+// getAll returns the metrics of all buckets on this server.
+func (s *replicationStats) getAll() map[string]madmin.BucketReplicationMetrics {
+	all := make(map[string]madmin.BucketReplicationMetrics)
+	if s == nil {
+		return all
+	}
+	s.Lock()
+	buckets := make([]string, 0, len(s.buckets))
+	for bucket := range s.buckets {
+		buckets = append(buckets, bucket)
+	}
+	s.Unlock()
+	for _, bucket := range buckets {
+		all[bucket] = s.getBucket(bucket)
+	}
+	return all
+}
+
+// delete drops the metrics of a deleted bucket.
+func (s *replicationStats) delete(bucket string) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	delete(s.buckets, bucket)
+}
+
+// mergeBucketReplicationMetrics sums up the metrics of a bucket reported by
+// all servers, the latency is averaged across the servers reporting one.
+func mergeBucketReplicationMetrics(bucket string, metrics ...madmin.BucketReplicationMetrics) madmin.BucketReplicationMetrics {
+	merged := madmin.BucketReplicationMetrics{
+		Bucket:  bucket,
+		Targets: make(map[string]madmin.TargetReplicationMetrics),
+	}
+	latencies := make(map[string]int)
+	for _, m := range metrics {
+		merged.QueueDepth += m.QueueDepth
+		for arn, t := range m.Targets {
+			mt := merged.Targets[arn]
+			mt.Arn = arn
+			mt.QueuedCount += t.QueuedCount
+			mt.QueuedSize += t.QueuedSize
+			mt.InflightCount += t.InflightCount
+			mt.InflightSize += t.InflightSize
+			mt.FailedCount += t.FailedCount
+			mt.FailedSize += t.FailedSize
+			mt.CompletedCount += t.CompletedCount
+			mt.CompletedSize += t.CompletedSize
+			if t.LastReplicationTime.After(mt.LastReplicationTime) {
+				mt.LastReplicationTime = t.LastReplicationTime
+			}
+			if t.Latency > 0 {
+				mt.Latency += t.Latency
+				latencies[arn]++
+			}
+			merged.Targets[arn] = mt
+		}
+	}
+	for arn, n := range latencies {
+		mt := merged.Targets[arn]
+		mt.Latency /= time.Duration(n)
+		merged.Targets[arn] = mt
+	}
+	return merged
+}
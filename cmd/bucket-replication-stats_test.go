@@ -0,0 +1,91 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestReplicationStats(t *testing.T) {
+	const arn1, arn2 = "arn:minio:replication::id1:dst1", "arn:minio:replication::id2:dst2"
+
+	s := newReplicationStats()
+	s.queued("bucket", []string{arn1, arn2}, 100)
+	s.dequeued("bucket", []string{arn1, arn2}, 100)
+	s.started("bucket", arn1, 100)
+	s.started("bucket", arn2, 100)
+	s.finished("bucket", arn1, 100, replication.Completed)
+	s.finished("bucket", arn2, 100, replication.Failed)
+	s.queued("bucket", []string{arn2}, 10)
+	// Accounting a task twice out of the queue must not wrap around.
+	s.dequeued("bucket", []string{arn1}, 10)
+
+	m := s.getBucket("bucket")
+	t1, t2 := m.Targets[arn1], m.Targets[arn2]
+	if t1.QueuedCount != 0 || t1.InflightCount != 0 || t1.CompletedCount != 1 || t1.CompletedSize != 100 || t1.LastReplicationTime.IsZero() {
+		t.Fatalf("unexpected metrics %+v", t1)
+	}
+	if t2.QueuedCount != 1 || t2.QueuedSize != 10 || t2.FailedCount != 1 || t2.FailedSize != 100 || !t2.LastReplicationTime.IsZero() {
+		t.Fatalf("unexpected metrics %+v", t2)
+	}
+
+	s.delete("bucket")
+	if m = s.getBucket("bucket"); len(m.Targets) != 0 {
+		t.Fatalf("expected no metrics after delete, got %+v", m)
+	}
+}
+
+func TestMergeBucketReplicationMetrics(t *testing.T) {
+	const arn = "arn:minio:replication::id1:dst1"
+	now := UTCNow()
+
+	merged := mergeBucketReplicationMetrics("bucket",
+		madmin.BucketReplicationMetrics{
+			QueueDepth: 2,
+			Targets: map[string]madmin.TargetReplicationMetrics{
+				arn: {QueuedCount: 1, CompletedCount: 3, Latency: 10 * time.Millisecond, LastReplicationTime: now.Add(-time.Minute)},
+			},
+		},
+		madmin.BucketReplicationMetrics{
+			QueueDepth: 3,
+			Targets: map[string]madmin.TargetReplicationMetrics{
+				arn: {QueuedCount: 2, CompletedCount: 4, Latency: 20 * time.Millisecond, LastReplicationTime: now},
+			},
+		},
+		// Servers which did not talk to the target yet do not skew the latency.
+		madmin.BucketReplicationMetrics{
+			Targets: map[string]madmin.TargetReplicationMetrics{
+				arn: {FailedCount: 1},
+			},
+		},
+	)
+
+	tm := merged.Targets[arn]
+	if merged.QueueDepth != 5 || tm.QueuedCount != 3 || tm.CompletedCount != 7 || tm.FailedCount != 1 {
+		t.Fatalf("unexpected merged metrics %+v", merged)
+	}
+	if tm.Latency != 15*time.Millisecond {
+		t.Fatalf("expected latency %v, got %v", 15*time.Millisecond, tm.Latency)
+	}
+	if !tm.LastReplicationTime.Equal(now) {
+		t.Fatalf("expected last replication %v, got %v", now, tm.LastReplicationTime)
+	}
+}
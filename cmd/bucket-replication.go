@@ -22,6 +22,8 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	minio "github.com/minio/minio-go/v7"
@@ -53,39 +55,59 @@ func getReplicationConfig(ctx context.Context, bucketName string) (rc *replicati
 }
 
 // validateReplicationDestination returns error if replication destination bucket missing or not configured
-// It also returns true if replication destination is same as this server.
+// It also returns true if any of the replication destinations is same as this server.
 func validateReplicationDestination(ctx context.Context, bucket string, rCfg *replication.Config) (bool, error) {
-	arn, err := madmin.ParseARN(rCfg.RoleArn)
-	if err != nil {
-		return false, BucketRemoteArnInvalid{}
-	}
-	if arn.Type != madmin.ReplicationService {
-		return false, BucketRemoteArnTypeInvalid{}
-	}
-	clnt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rCfg.RoleArn)
-	if clnt == nil {
-		return false, BucketRemoteTargetNotFound{Bucket: bucket}
-	}
-	if found, _ := clnt.BucketExists(ctx, rCfg.GetDestination().Bucket); !found {
-		return false, BucketRemoteDestinationNotFound{Bucket: rCfg.GetDestination().Bucket}
-	}
-	if ret, err := globalBucketObjectLockSys.Get(bucket); err == nil {
-		if ret.LockEnabled {
-			lock, _, _, _, err := clnt.GetObjectLockConfig(ctx, rCfg.GetDestination().Bucket)
-			if err != nil || lock != "Enabled" {
-				return false, BucketReplicationDestinationMissingLock{Bucket: rCfg.GetDestination().Bucket}
+	var sameTarget bool
+	for _, arnStr := range rCfg.TargetArns() {
+		arn, err := madmin.ParseARN(arnStr)
+		if err != nil {
+			return false, BucketRemoteArnInvalid{}
+		}
+		if arn.Type != madmin.ReplicationService {
+			return false, BucketRemoteArnTypeInvalid{}
+		}
+		clnt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arnStr)
+		if clnt == nil {
+			return false, BucketRemoteTargetNotFound{Bucket: bucket}
+		}
+		dest := rCfg.GetTargetDestination(arnStr)
+		if found, _ := clnt.BucketExists(ctx, dest.Bucket); !found {
+			return false, BucketRemoteDestinationNotFound{Bucket: dest.Bucket}
+		}
+		if ret, err := globalBucketObjectLockSys.Get(bucket); err == nil {
+			if ret.LockEnabled {
+				lock, _, _, _, err := clnt.GetObjectLockConfig(ctx, dest.Bucket)
+				if err != nil || lock != "Enabled" {
+					return false, BucketReplicationDestinationMissingLock{Bucket: dest.Bucket}
+				}
 			}
 		}
+		// validate replication ARN against target endpoint
+		c, ok := globalBucketTargetSys.arnRemotesMap[arnStr]
+		if !ok || c.EndpointURL().String() != clnt.EndpointURL().String() {
+			return false, BucketRemoteTargetNotFound{Bucket: bucket}
+		}
+		if local, _ := isLocalHost(clnt.EndpointURL().Hostname(), clnt.EndpointURL().Port(), globalMinioPort); local {
+			sameTarget = true
+		}
 	}
-	// validate replication ARN against target endpoint
-	c, ok := globalBucketTargetSys.arnRemotesMap[rCfg.RoleArn]
-	if ok {
-		if c.EndpointURL().String() == clnt.EndpointURL().String() {
-			sameTarget, _ := isLocalHost(clnt.EndpointURL().Hostname(), clnt.EndpointURL().Port(), globalMinioPort)
-			return sameTarget, nil
+	return sameTarget, nil
+}
+
+// replicateSyncTargets returns true if any of the remote targets is configured
+// for synchronous replication, found is false if none of the targets exist.
+func replicateSyncTargets(ctx context.Context, arns []string) (found bool, sync bool) {
+	for _, arn := range arns {
+		tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+		if tgt == nil {
+			continue
+		}
+		found = true
+		if tgt.replicateSync {
+			sync = true
 		}
 	}
-	return false, BucketRemoteTargetNotFound{Bucket: bucket}
+	return found, sync
 }
 
 func mustReplicateWeb(ctx context.Context, r *http.Request, bucket, object string, meta map[string]string, replStatus string, permErr APIErrorCode) (replicate bool, sync bool) {
@@ -128,13 +150,11 @@ func mustReplicater(ctx context.Context, bucket, object string, meta map[string]
 	if ok {
 		opts.UserTags = tagStr
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, cfg.RoleArn)
+	arns := cfg.FilterTargetArns(opts)
 	// the target online status should not be used here while deciding
 	// whether to replicate as the target could be temporarily down
-	if tgt != nil {
-		return cfg.Replicate(opts), tgt.replicateSync
-	}
-	return cfg.Replicate(opts), false
+	_, sync = replicateSyncTargets(ctx, arns)
+	return len(arns) > 0, sync
 }
 
 // Standard headers that needs to be extracted from User metadata.
@@ -192,12 +212,6 @@ func checkReplicateDelete(ctx context.Context, bucket string, dobj ObjectToDelet
 		}
 		return oi.DeleteMarker, false, sync
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rcfg.RoleArn)
-	// the target online status should not be used here while deciding
-	// whether to replicate deletes as the target could be temporarily down
-	if tgt == nil {
-		return oi.DeleteMarker, false, false
-	}
 	opts := replication.ObjectOpts{
 		Name:         dobj.ObjectName,
 		SSEC:         crypto.SSEC.IsEncrypted(oi.UserDefined),
@@ -205,7 +219,10 @@ func checkReplicateDelete(ctx context.Context, bucket string, dobj ObjectToDelet
 		DeleteMarker: oi.DeleteMarker,
 		VersionID:    dobj.VersionID,
 	}
-	return oi.DeleteMarker, rcfg.Replicate(opts), tgt.replicateSync
+	// the target online status should not be used here while deciding
+	// whether to replicate deletes as the target could be temporarily down
+	found, sync := replicateSyncTargets(ctx, rcfg.FilterTargetArns(opts))
+	return oi.DeleteMarker, found, sync
 }
 
 // replicate deletes to the designated replication target if replication configuration
@@ -241,9 +258,21 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 		})
 		return
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rcfg.RoleArn)
-	if tgt == nil {
-		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, rcfg.RoleArn))
+	arns := rcfg.FilterTargetArns(replication.ObjectOpts{
+		Name:         dobj.ObjectName,
+		DeleteMarker: dobj.DeleteMarker,
+		VersionID:    dobj.VersionID,
+	})
+	if dobj.TargetArn != "" {
+		// Resync replicates to the requested target only.
+		if contains(arns, dobj.TargetArn) {
+			arns = []string{dobj.TargetArn}
+		} else {
+			arns = nil
+		}
+	}
+	if len(arns) == 0 {
+		logger.LogIf(ctx, fmt.Errorf("failed to get targets for bucket:%s object:%s", bucket, dobj.ObjectName))
 		sendEvent(eventArgs{
 			BucketName: bucket,
 			Object: ObjectInfo{
@@ -258,25 +287,37 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 		return
 	}
 
-	rmErr := tgt.RemoveObject(ctx, rcfg.GetDestination().Bucket, dobj.ObjectName, miniogo.RemoveObjectOptions{
-		VersionID: versionID,
-		Internal: miniogo.AdvancedRemoveOptions{
-			ReplicationDeleteMarker: dobj.DeleteMarkerVersionID != "",
-			ReplicationMTime:        dobj.DeleteMarkerMTime.Time,
-			ReplicationStatus:       miniogo.ReplicationStatusReplica,
-		},
-	})
+	// Deletes are sent to all targets in parallel, replication
+	// of the delete fails if it failed on any of the targets.
+	var (
+		wg     sync.WaitGroup
+		failed int32
+	)
+	for _, arn := range arns {
+		wg.Add(1)
+		go func(arn string) {
+			defer wg.Done()
+			globalReplicationStats.started(bucket, arn, 0)
+			if err := replicateDeleteToTarget(ctx, dobj, rcfg, arn, versionID); err != nil {
+				logger.LogIf(ctx, err)
+				atomic.StoreInt32(&failed, 1)
+				globalReplicationStats.finished(bucket, arn, 0, replication.Failed)
+				return
+			}
+			globalReplicationStats.finished(bucket, arn, 0, replication.Completed)
+		}(arn)
+	}
+	wg.Wait()
 
 	replicationStatus := dobj.DeleteMarkerReplicationStatus
 	versionPurgeStatus := dobj.VersionPurgeStatus
 
-	if rmErr != nil {
+	if atomic.LoadInt32(&failed) == 1 {
 		if dobj.VersionID == "" {
 			replicationStatus = string(replication.Failed)
 		} else {
 			versionPurgeStatus = Failed
 		}
-		logger.LogIf(ctx, fmt.Errorf("Unable to replicate delete marker to %s/%s(%s): %w", rcfg.GetDestination().Bucket, dobj.ObjectName, versionID, err))
 	} else {
 		if dobj.VersionID == "" {
 			replicationStatus = string(replication.Completed)
@@ -322,6 +363,27 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 	}
 }
 
+// replicateDeleteToTarget replicates the delete marker or versioned delete
+// to the remote target identified by arn.
+func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectVersionInfo, rcfg *replication.Config, arn, versionID string) error {
+	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+	if tgt == nil {
+		return fmt.Errorf("failed to get target for bucket:%s arn:%s", dobj.Bucket, arn)
+	}
+	dest := rcfg.GetTargetDestination(arn)
+	if err := tgt.RemoveObject(ctx, dest.Bucket, dobj.ObjectName, miniogo.RemoveObjectOptions{
+		VersionID: versionID,
+		Internal: miniogo.AdvancedRemoveOptions{
+			ReplicationDeleteMarker: dobj.DeleteMarkerVersionID != "",
+			ReplicationMTime:        dobj.DeleteMarkerMTime.Time,
+			ReplicationStatus:       miniogo.ReplicationStatusReplica,
+		},
+	}); err != nil {
+		return fmt.Errorf("Unable to replicate delete marker to %s/%s(%s): %w", dest.Bucket, dobj.ObjectName, versionID, err)
+	}
+	return nil
+}
+
 func getCopyObjMetadata(oi ObjectInfo, dest replication.Destination) map[string]string {
 	meta := make(map[string]string, len(oi.UserDefined))
 	for k, v := range oi.UserDefined {
@@ -533,16 +595,34 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo) replicationActio
 	return replicateNone
 }
 
-// replicateObject replicates the specified version of the object to destination bucket
-// The source object is then updated to reflect the replication status.
-func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLayer) {
+// replicationTargetStatusKey - internal metadata key holding the replication
+// status of an object version on each of the remote targets.
+const replicationTargetStatusKey = ReservedMetadataPrefix + "replication-status"
+
+// Replication of an object version to a remote target which failed is retried
+// this many times, after a backoff doubling from replicationRetryBackoff, before
+// the version is marked failed on the target and left to the crawler.
+const (
+	replicationMaxRetries   = 3
+	replicationRetryBackoff = 5 * time.Second
+)
+
+// replicateObject replicates the specified version of the object to the destination
+// bucket of the remote target of the task. Every remote target is replicated to by
+// its own task, so that a slow target does not hold up the others. When retrying a
+// failed replication the targets it already completed on are skipped. Returns true
+// if the replication failed and is to be retried, otherwise the replication status
+// of the target is updated on the source object.
+func replicateObject(ctx context.Context, ri replicateObjectInfo, objectAPI ObjectLayer) (retry bool) {
+	objInfo := ri.ObjectInfo
 	z, ok := objectAPI.(*erasureServerPools)
 	if !ok {
-		return
+		return false
 	}
 
 	bucket := objInfo.Bucket
 	object := objInfo.Name
+	arn := ri.TargetArn
 
 	cfg, err := getReplicationConfig(ctx, bucket)
 	if err != nil {
@@ -553,31 +633,174 @@ func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLa
 			Object:     objInfo,
 			Host:       "Internal: [Replication]",
 		})
-		return
+		return false
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, cfg.RoleArn)
-	if tgt == nil {
-		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, cfg.RoleArn))
+
+	set, err := getReplicationObjectSet(ctx, z, bucket, object, objInfo.VersionID)
+	if err == nil {
+		objInfo, err = set.GetObjectInfo(ctx, bucket, object, ObjectOptions{
+			VersionID: objInfo.VersionID,
+		})
+	}
+	if err != nil {
 		sendEvent(eventArgs{
 			EventName:  event.ObjectReplicationNotTracked,
 			BucketName: bucket,
 			Object:     objInfo,
 			Host:       "Internal: [Replication]",
 		})
-		return
+		logger.LogIf(ctx, err)
+		return false
 	}
+
+	arns := cfg.FilterTargetArns(replication.ObjectOpts{
+		Name:     object,
+		UserTags: objInfo.UserTags,
+		SSEC:     crypto.SSEC.IsEncrypted(objInfo.UserDefined),
+	})
+	if !contains(arns, arn) {
+		// The object version no longer replicates to the target,
+		// such as when the configuration changed since queued.
+		return false
+	}
+
+	prevStatuses := replication.ParseTargetStatuses(objInfo.UserDefined[replicationTargetStatusKey])
+	if objInfo.ReplicationStatus == replication.Failed && prevStatuses[arn] == replication.Completed {
+		return false
+	}
+
+	globalReplicationStats.started(bucket, arn, objInfo.Size)
+	status := replicateObjectToTarget(ctx, objInfo, objectAPI, cfg, arn)
+	globalReplicationStats.finished(bucket, arn, objInfo.Size, status)
+	if status == replication.Failed && ri.retries < replicationMaxRetries {
+		return true
+	}
+
+	objInfo, err = updateReplicationTargetStatus(ctx, z, objInfo, arns, arn, status)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w", bucket, object, objInfo.VersionID, err))
+		return false
+	}
+
+	// FIXME: add support for missing replication events
+	// - event.ObjectReplicationMissedThreshold
+	// - event.ObjectReplicationReplicatedAfterThreshold
+	var eventName event.Name
+	switch {
+	case status == replication.Failed:
+		eventName = event.ObjectReplicationFailed
+	case objInfo.ReplicationStatus == replication.Completed:
+		eventName = event.ObjectReplicationComplete
+	default:
+		// Replication to the other targets is pending.
+		return false
+	}
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Replication]",
+	})
+	return false
+}
+
+// getReplicationObjectSet returns the erasure set of the pool with the object
+// version, the versions of an object are spread across pools while a pool is
+// decommissioned or rebalanced.
+func getReplicationObjectSet(ctx context.Context, z *erasureServerPools, bucket, object, versionID string) (*erasureObjects, error) {
+	poolIdx := 0
+	if !z.SinglePool() {
+		_, idx, err := z.getLatestObjectInfo(ctx, bucket, object, ObjectOptions{
+			VersionID: versionID,
+		})
+		if idx < 0 {
+			return nil, err
+		}
+		poolIdx = idx
+	}
+	return z.serverPools[poolIdx].getHashedSet(object), nil
+}
+
+// updateReplicationTargetStatus records the replication status of the object
+// version on the remote target arn and returns the updated object info. The
+// statuses are updated under the lock of the object so that the statuses
+// recorded meanwhile by the tasks of the other targets are kept, only the
+// status of arn is changed. Until a first status is recorded for a version
+// pending replication, its targets in arns are pending.
+func updateReplicationTargetStatus(ctx context.Context, z *erasureServerPools, objInfo ObjectInfo, arns []string, arn string, status replication.StatusType) (ObjectInfo, error) {
+	bucket := objInfo.Bucket
+	object := objInfo.Name
+
+	set, err := getReplicationObjectSet(ctx, z, bucket, object, objInfo.VersionID)
+	if err != nil {
+		return objInfo, err
+	}
+	lk := set.NewNSLock(bucket, object)
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer lk.Unlock()
+
+	objInfo, err = set.GetObjectInfo(ctx, bucket, object, ObjectOptions{
+		VersionID: objInfo.VersionID,
+		NoLock:    true,
+	})
+	if err != nil {
+		return objInfo, err
+	}
+
+	statuses := replication.ParseTargetStatuses(objInfo.UserDefined[replicationTargetStatusKey])
+	if len(statuses) == 0 && objInfo.ReplicationStatus == replication.Pending {
+		// The version was queued to all its targets when written,
+		// the targets not replicated to yet are pending.
+		for _, a := range arns {
+			statuses[a] = replication.Pending
+		}
+	}
+	statuses[arn] = status
+
+	meta := map[string]string{
+		xhttp.AmzBucketReplicationStatus: statuses.Status().String(),
+		replicationTargetStatusKey:       statuses.String(),
+	}
+	// This lower level implementation is necessary as CopyObject
+	// takes the lock of the object held here.
+	if err = set.updateObjectMeta(ctx, bucket, object, meta, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}); err != nil {
+		return objInfo, err
+	}
+	for k, v := range meta {
+		objInfo.UserDefined[k] = v
+	}
+	objInfo.ReplicationStatus = statuses.Status()
+	return objInfo, nil
+}
+
+// replicateObjectToTarget replicates the specified version of the object to the
+// remote target identified by arn and returns the resulting replication status.
+func replicateObjectToTarget(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLayer, cfg *replication.Config, arn string) replication.StatusType {
+	bucket := objInfo.Bucket
+	object := objInfo.Name
+
+	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+	if tgt == nil {
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, arn))
+		return replication.Failed
+	}
+
+	dest := cfg.GetTargetDestination(arn)
+	if dest.Bucket == "" {
+		logger.LogIf(ctx, fmt.Errorf("Unable to replicate object %s(%s), bucket is empty", objInfo.Name, objInfo.VersionID))
+		return replication.Failed
+	}
+
 	gr, err := objectAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{
 		VersionID: objInfo.VersionID,
 	})
 	if err != nil {
-		sendEvent(eventArgs{
-			EventName:  event.ObjectReplicationNotTracked,
-			BucketName: bucket,
-			Object:     objInfo,
-			Host:       "Internal: [Replication]",
-		})
 		logger.LogIf(ctx, err)
-		return
+		return replication.Failed
 	}
 	defer gr.Close() // hold read lock for entire transaction
 
@@ -585,117 +808,66 @@ func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLa
 	size, err := objInfo.GetActualSize()
 	if err != nil {
 		logger.LogIf(ctx, err)
-		sendEvent(eventArgs{
-			EventName:  event.ObjectReplicationNotTracked,
-			BucketName: bucket,
-			Object:     objInfo,
-			Host:       "Internal: [Replication]",
-		})
-		return
-	}
-
-	dest := cfg.GetDestination()
-	if dest.Bucket == "" {
-		logger.LogIf(ctx, fmt.Errorf("Unable to replicate object %s(%s), bucket is empty", objInfo.Name, objInfo.VersionID))
-		sendEvent(eventArgs{
-			EventName:  event.ObjectReplicationNotTracked,
-			BucketName: bucket,
-			Object:     objInfo,
-			Host:       "Internal: [Replication]",
-		})
-		return
+		return replication.Failed
 	}
 
 	rtype := replicateAll
+	start := time.Now()
 	oi, err := tgt.StatObject(ctx, dest.Bucket, object, miniogo.StatObjectOptions{
 		VersionID: objInfo.VersionID,
 		Internal: miniogo.AdvancedGetOptions{
 			ReplicationProxyRequest: "false",
 		}})
+	if err == nil || miniogo.ToErrorResponse(err).StatusCode != 0 {
+		// The target responded, which makes the stat a metadata only round trip.
+		globalReplicationStats.roundTrip(bucket, arn, time.Since(start))
+	}
 	if err == nil {
 		rtype = getReplicationAction(objInfo, oi)
 		if rtype == replicateNone {
 			// object with same VersionID already exists, replication kicked off by
 			// PutObject might have completed.
-			return
+			return replication.Completed
 		}
 	}
-	replicationStatus := replication.Completed
 	if rtype != replicateAll {
 		// replicate metadata for object tagging/copy with metadata replacement
 		dstOpts := miniogo.PutObjectOptions{Internal: miniogo.AdvancedPutOptions{SourceVersionID: objInfo.VersionID}}
 		c := &miniogo.Core{Client: tgt.Client}
 		if _, err = c.CopyObject(ctx, dest.Bucket, object, dest.Bucket, object, getCopyObjMetadata(objInfo, dest), dstOpts); err != nil {
-			replicationStatus = replication.Failed
-			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
-		}
-	} else {
-		target, err := globalBucketMetadataSys.GetBucketTarget(bucket, cfg.RoleArn)
-		if err != nil {
-			logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s cfg:%s err:%s", bucket, cfg.RoleArn, err))
-			sendEvent(eventArgs{
-				EventName:  event.ObjectReplicationNotTracked,
-				BucketName: bucket,
-				Object:     objInfo,
-				Host:       "Internal: [Replication]",
-			})
-			return
-		}
-
-		putOpts := putReplicationOpts(ctx, dest, objInfo)
-		// Setup bandwidth throttling
-		peers, _ := globalEndpoints.peers()
-		totalNodesCount := len(peers)
-		if totalNodesCount == 0 {
-			totalNodesCount = 1 // For standalone erasure coding
-		}
-		b := target.BandwidthLimit / int64(totalNodesCount)
-		var headerSize int
-		for k, v := range putOpts.Header() {
-			headerSize += len(k) + len(v)
-		}
-
-		// r takes over closing gr.
-		r := bandwidth.NewMonitoredReader(ctx, globalBucketMonitor, objInfo.Bucket, objInfo.Name, gr, headerSize, b, target.BandwidthLimit)
-		if _, err = tgt.PutObject(ctx, dest.Bucket, object, r, size, putOpts); err != nil {
-			replicationStatus = replication.Failed
-			logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
+			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s) to %s: %s", bucket, objInfo.Name, objInfo.VersionID, arn, err))
+			return replication.Failed
 		}
-		defer r.Close()
+		return replication.Completed
 	}
 
-	objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = replicationStatus.String()
-	if objInfo.UserTags != "" {
-		objInfo.UserDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
+	target, err := globalBucketMetadataSys.GetBucketTarget(bucket, arn)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s arn:%s err:%s", bucket, arn, err))
+		return replication.Failed
 	}
 
-	// FIXME: add support for missing replication events
-	// - event.ObjectReplicationMissedThreshold
-	// - event.ObjectReplicationReplicatedAfterThreshold
-	var eventName = event.ObjectReplicationComplete
-	if replicationStatus == replication.Failed {
-		eventName = event.ObjectReplicationFailed
+	putOpts := putReplicationOpts(ctx, dest, objInfo)
+	// Setup bandwidth throttling
+	peers, _ := globalEndpoints.peers()
+	totalNodesCount := len(peers)
+	if totalNodesCount == 0 {
+		totalNodesCount = 1 // For standalone erasure coding
+	}
+	b := target.BandwidthLimit / int64(totalNodesCount)
+	var headerSize int
+	for k, v := range putOpts.Header() {
+		headerSize += len(k) + len(v)
 	}
 
-	// This lower level implementation is necessary to avoid write locks from CopyObject.
-	poolIdx, err := z.getPoolIdx(ctx, bucket, object, ObjectOptions{
-		VersionID: objInfo.VersionID,
-	}, objInfo.Size)
-	if err != nil {
-		logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w", bucket, objInfo.Name, objInfo.VersionID, err))
-	} else {
-		if err = z.serverPools[poolIdx].getHashedSet(object).updateObjectMeta(ctx, bucket, object, objInfo.UserDefined, ObjectOptions{
-			VersionID: objInfo.VersionID,
-		}); err != nil {
-			logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w", bucket, objInfo.Name, objInfo.VersionID, err))
-		}
+	// r takes over closing gr.
+	r := bandwidth.NewMonitoredReader(ctx, globalBucketMonitor, objInfo.Bucket, objInfo.Name, gr, headerSize, b, target.BandwidthLimit)
+	defer r.Close()
+	if _, err = tgt.PutObject(ctx, dest.Bucket, object, r, size, putOpts); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s) to %s: %s", bucket, objInfo.Name, objInfo.VersionID, arn, err))
+		return replication.Failed
 	}
-	sendEvent(eventArgs{
-		EventName:  eventName,
-		BucketName: bucket,
-		Object:     objInfo,
-		Host:       "Internal: [Replication]",
-	})
+	return replication.Completed
 }
 
 // filterReplicationStatusMetadata filters replication status metadata for COPY
@@ -718,6 +890,7 @@ func filterReplicationStatusMetadata(metadata map[string]string) map[string]stri
 	}
 
 	delKey(xhttp.AmzBucketReplicationStatus)
+	delKey(replicationTargetStatusKey)
 	return dst
 }
 
@@ -725,31 +898,344 @@ func filterReplicationStatusMetadata(metadata map[string]string) map[string]stri
 type DeletedObjectVersionInfo struct {
 	DeletedObject
 	Bucket string
+	// TargetArn restricts replication to a single remote target, set by resync.
+	TargetArn string
+	// remote targets the task was accounted as queued for.
+	queuedArns []string
+}
+
+// replicateObjectInfo is a queued replication task of an object version
+// to a remote target.
+type replicateObjectInfo struct {
+	ObjectInfo
+	// TargetArn is the remote target the task replicates to.
+	TargetArn string
+	// remote targets the task was accounted as queued for.
+	queuedArns []string
+	// number of times the failed replication was retried.
+	retries int
 }
+
+// Interval at which tasks which did not fit in the queue are replayed from the journal.
+const replicationJournalReplayInterval = time.Minute
+
 type replicationState struct {
-	// add future metrics here
-	replicaCh       chan ObjectInfo
+	// Number of tasks which found the queue full and were left in the journal.
+	spilled uint64
+	// Number of tasks queued by the journal replay.
+	replayed uint64
+
+	replicaCh       chan replicateObjectInfo
 	replicaDeleteCh chan DeletedObjectVersionInfo
+
+	mu sync.Mutex
+	// journal persists queued tasks, nil until background replication is initialized.
+	journal *replicationJournal
+	// number of queued or running tasks by journal ID.
+	inflight map[string]int
 }
 
+// queueReplicaTask queues a replication task of the object version
+// for every remote target it replicates to.
 func (r *replicationState) queueReplicaTask(oi ObjectInfo) {
 	if r == nil {
 		return
 	}
+	for _, arn := range (replicateObjectInfo{ObjectInfo: oi}).targetArns() {
+		r.queueReplicaTargetTask(replicateObjectInfo{ObjectInfo: oi, TargetArn: arn})
+	}
+}
+
+// queueReplicaTargetTask queues the replication task of the object version
+// to its remote target, the task is left in the journal if the queue is full.
+func (r *replicationState) queueReplicaTargetTask(ri replicateObjectInfo) {
+	id := r.journalObjectTask(&ri)
 	select {
-	case r.replicaCh <- oi:
+	case r.replicaCh <- ri:
 	default:
+		r.spillTask(id)
+		globalReplicationStats.dequeued(ri.Bucket, ri.queuedArns, ri.Size)
 	}
 }
 
+// retryTask queues the failed task again after a backoff, the task stays
+// journaled and in flight meanwhile.
+func (r *replicationState) retryTask(ri replicateObjectInfo) {
+	backoff := replicationRetryBackoff << uint(ri.retries)
+	ri.retries++
+	time.AfterFunc(backoff, func() {
+		globalReplicationStats.queued(ri.Bucket, ri.queuedArns, ri.Size)
+		select {
+		case r.replicaCh <- ri:
+		default:
+			r.spillTask(ri.taskID())
+			globalReplicationStats.dequeued(ri.Bucket, ri.queuedArns, ri.Size)
+		}
+	})
+}
+
 func (r *replicationState) queueReplicaDeleteTask(doi DeletedObjectVersionInfo) {
 	if r == nil {
 		return
 	}
+	id := r.journalDeleteTask(&doi)
 	select {
 	case r.replicaDeleteCh <- doi:
 	default:
+		r.spillTask(id)
+		globalReplicationStats.dequeued(doi.Bucket, doi.queuedArns, 0)
+	}
+}
+
+// queueReplicaResyncTask queues replication of an object version to a single
+// remote target, unlike queueReplicaTask it waits while the queue is full.
+func (r *replicationState) queueReplicaResyncTask(ctx context.Context, ri replicateObjectInfo) error {
+	id := r.journalObjectTask(&ri)
+	select {
+	case <-ctx.Done():
+		r.mu.Lock()
+		r.releaseTask(id)
+		r.mu.Unlock()
+		globalReplicationStats.dequeued(ri.Bucket, ri.queuedArns, ri.Size)
+		return ctx.Err()
+	case r.replicaCh <- ri:
+		return nil
+	}
+}
+
+// queueReplicaDeleteResyncTask queues replication of a delete marker to a single
+// remote target, unlike queueReplicaDeleteTask it waits while the queue is full.
+func (r *replicationState) queueReplicaDeleteResyncTask(ctx context.Context, doi DeletedObjectVersionInfo) error {
+	id := r.journalDeleteTask(&doi)
+	select {
+	case <-ctx.Done():
+		r.mu.Lock()
+		r.releaseTask(id)
+		r.mu.Unlock()
+		globalReplicationStats.dequeued(doi.Bucket, doi.queuedArns, 0)
+		return ctx.Err()
+	case r.replicaDeleteCh <- doi:
+		return nil
+	}
+}
+
+// journalObjectTask journals the task and accounts it as queued
+// for the remote targets it replicates to.
+func (r *replicationState) journalObjectTask(ri *replicateObjectInfo) string {
+	ri.queuedArns = ri.targetArns()
+	id := ri.taskID()
+	r.journalTask(id, replicationJournalEntry{
+		Bucket:    ri.Bucket,
+		Object:    ri.Name,
+		VersionID: ri.VersionID,
+		TargetArn: ri.TargetArn,
+		Arns:      ri.queuedArns,
+		Size:      ri.Size,
+	})
+	globalReplicationStats.queued(ri.Bucket, ri.queuedArns, ri.Size)
+	return id
+}
+
+// journalDeleteTask journals the task and accounts it as queued
+// for the remote targets it replicates to.
+func (r *replicationState) journalDeleteTask(doi *DeletedObjectVersionInfo) string {
+	doi.queuedArns = doi.targetArns()
+	id := doi.taskID()
+	dobj := doi.DeletedObject
+	r.journalTask(id, replicationJournalEntry{
+		Bucket:    doi.Bucket,
+		Object:    doi.ObjectName,
+		VersionID: doi.taskVersionID(),
+		TargetArn: doi.TargetArn,
+		Arns:      doi.queuedArns,
+		Delete:    &dobj,
+	})
+	globalReplicationStats.queued(doi.Bucket, doi.queuedArns, 0)
+	return id
+}
+
+// targetArns returns the remote targets the task replicates to.
+func (ri replicateObjectInfo) targetArns() []string {
+	if ri.TargetArn != "" {
+		return []string{ri.TargetArn}
+	}
+	cfg, err := getReplicationConfig(GlobalContext, ri.Bucket)
+	if err != nil {
+		return nil
+	}
+	return cfg.FilterTargetArns(replication.ObjectOpts{
+		Name:     ri.Name,
+		UserTags: ri.UserTags,
+		SSEC:     crypto.SSEC.IsEncrypted(ri.UserDefined),
+	})
+}
+
+// targetArns returns the remote targets the task replicates to.
+func (doi DeletedObjectVersionInfo) targetArns() []string {
+	if doi.TargetArn != "" {
+		return []string{doi.TargetArn}
 	}
+	cfg, err := getReplicationConfig(GlobalContext, doi.Bucket)
+	if err != nil {
+		return nil
+	}
+	return cfg.FilterTargetArns(replication.ObjectOpts{
+		Name:         doi.ObjectName,
+		DeleteMarker: doi.DeleteMarker,
+		VersionID:    doi.VersionID,
+	})
+}
+
+// taskID returns the journal ID of the object replication task.
+func (ri replicateObjectInfo) taskID() string {
+	return replicationTaskID(ri.Bucket, ri.Name, ri.VersionID, ri.TargetArn, false)
+}
+
+// taskID returns the journal ID of the delete replication task.
+func (doi DeletedObjectVersionInfo) taskID() string {
+	return replicationTaskID(doi.Bucket, doi.ObjectName, doi.taskVersionID(), doi.TargetArn, true)
+}
+
+// taskVersionID returns the version the delete replication task is for.
+func (doi DeletedObjectVersionInfo) taskVersionID() string {
+	if doi.DeleteMarkerVersionID != "" {
+		return doi.DeleteMarkerVersionID
+	}
+	return doi.VersionID
+}
+
+// journalTask journals the task before it is queued, the task is written
+// to the journal synchronously so that it is not lost by a crash once it
+// is queued.
+func (r *replicationState) journalTask(id string, entry replicationJournalEntry) {
+	r.mu.Lock()
+	journal := r.journal
+	r.mu.Unlock()
+
+	if journal != nil {
+		mu := journal.lock(id)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	r.mu.Lock()
+	r.inflight[id]++
+	r.mu.Unlock()
+
+	if journal != nil {
+		if err := journal.add(GlobalContext, id, entry); err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("Unable to journal replication of %s/%s: %w", entry.Bucket, entry.Object, err))
+		}
+	}
+}
+
+// spillTask leaves a task which did not fit in the queue to the journal
+// replay, without a journal the task is retried by the crawler.
+func (r *replicationState) spillTask(id string) {
+	r.mu.Lock()
+	r.releaseTask(id)
+	r.mu.Unlock()
+	atomic.AddUint64(&r.spilled, 1)
+}
+
+// taskDone removes the task from the journal once no other task
+// with the same journal ID is queued or running.
+func (r *replicationState) taskDone(ctx context.Context, object, id string) {
+	r.mu.Lock()
+	journal := r.journal
+	r.mu.Unlock()
+
+	if journal != nil {
+		mu := journal.lock(id)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	r.mu.Lock()
+	last := r.releaseTask(id)
+	r.mu.Unlock()
+
+	if last && journal != nil {
+		journal.remove(ctx, object, id)
+	}
+}
+
+// releaseTask must be called with r.mu held, returns true if this
+// was the last queued or running task with the journal ID.
+func (r *replicationState) releaseTask(id string) bool {
+	r.inflight[id]--
+	if r.inflight[id] > 0 {
+		return false
+	}
+	delete(r.inflight, id)
+	return true
+}
+
+// replayJournal queues the journaled tasks which are not queued, those left
+// over from a previous run at startup and those spilled while the queue was
+// full later on. Queueing blocks while the queue is full, so the replay
+// proceeds at the pace of the workers instead of dropping tasks.
+func (r *replicationState) replayJournal(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		r.mu.Lock()
+		journal := r.journal
+		r.mu.Unlock()
+
+		journal.walk(ctx, func(id string, entry replicationJournalEntry) bool {
+			r.mu.Lock()
+			if r.inflight[id] > 0 {
+				r.mu.Unlock()
+				return true
+			}
+			r.inflight[id]++
+			r.mu.Unlock()
+
+			globalReplicationStats.queued(entry.Bucket, entry.Arns, entry.Size)
+			if entry.Delete != nil {
+				select {
+				case <-ctx.Done():
+					globalReplicationStats.dequeued(entry.Bucket, entry.Arns, entry.Size)
+					return false
+				case r.replicaDeleteCh <- DeletedObjectVersionInfo{
+					DeletedObject: *entry.Delete,
+					Bucket:        entry.Bucket,
+					TargetArn:     entry.TargetArn,
+					queuedArns:    entry.Arns,
+				}:
+				}
+			} else {
+				select {
+				case <-ctx.Done():
+					globalReplicationStats.dequeued(entry.Bucket, entry.Arns, entry.Size)
+					return false
+				case r.replicaCh <- replicateObjectInfo{
+					ObjectInfo: ObjectInfo{Bucket: entry.Bucket, Name: entry.Object, VersionID: entry.VersionID, Size: entry.Size},
+					TargetArn:  entry.TargetArn,
+					queuedArns: entry.Arns,
+				}:
+				}
+			}
+			atomic.AddUint64(&r.replayed, 1)
+			return true
+		})
+		timer.Reset(replicationJournalReplayInterval)
+	}
+}
+
+// queued returns the number of tasks waiting in the queue.
+func (r *replicationState) queued() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.replicaCh) + len(r.replicaDeleteCh)
 }
 
 var (
@@ -757,16 +1243,11 @@ var (
 )
 
 func newReplicationState() *replicationState {
-	rs := &replicationState{
-		replicaCh:       make(chan ObjectInfo, 10000),
+	return &replicationState{
+		replicaCh:       make(chan replicateObjectInfo, 10000),
 		replicaDeleteCh: make(chan DeletedObjectVersionInfo, 10000),
+		inflight:        make(map[string]int),
 	}
-	go func() {
-		<-GlobalContext.Done()
-		close(rs.replicaCh)
-		close(rs.replicaDeleteCh)
-	}()
-	return rs
 }
 
 // addWorker creates a new worker to process tasks
@@ -777,16 +1258,17 @@ func (r *replicationState) addWorker(ctx context.Context, objectAPI ObjectLayer)
 			select {
 			case <-ctx.Done():
 				return
-			case oi, ok := <-r.replicaCh:
-				if !ok {
-					return
-				}
-				replicateObject(ctx, oi, objectAPI)
-			case doi, ok := <-r.replicaDeleteCh:
-				if !ok {
-					return
+			case ri := <-r.replicaCh:
+				globalReplicationStats.dequeued(ri.Bucket, ri.queuedArns, ri.Size)
+				if replicateObject(ctx, ri, objectAPI) {
+					r.retryTask(ri)
+					continue
 				}
+				r.taskDone(ctx, ri.Name, ri.taskID())
+			case doi := <-r.replicaDeleteCh:
+				globalReplicationStats.dequeued(doi.Bucket, doi.queuedArns, 0)
 				replicateDelete(ctx, doi, objectAPI)
+				r.taskDone(ctx, doi.ObjectName, doi.taskID())
 			}
 		}
 	}()
@@ -797,10 +1279,23 @@ func initBackgroundReplication(ctx context.Context, objectAPI ObjectLayer) {
 		return
 	}
 
+	// Tasks are journaled from here on, tasks journaled before
+	// a restart are replayed in the background.
+	journal := newReplicationJournal(objectAPI)
+	if journal != nil {
+		globalReplicationState.mu.Lock()
+		globalReplicationState.journal = journal
+		globalReplicationState.mu.Unlock()
+		go globalReplicationState.replayJournal(ctx)
+	}
+
 	// Start replication workers per count set in api config or MINIO_API_REPLICATION_WORKERS.
 	for i := 0; i < globalAPIConfig.getReplicationWorkers(); i++ {
 		globalReplicationState.addWorker(ctx, objectAPI)
 	}
+
+	// Resume the resyncs interrupted by a restart.
+	go globalReplicationResyncSys.resume(ctx, objectAPI)
 }
 
 // get Reader from replication target if active-active replication is in place and
@@ -851,8 +1346,12 @@ func isProxyable(ctx context.Context, bucket string) bool {
 	if err != nil {
 		return false
 	}
-	dest := cfg.GetDestination()
-	return dest.Bucket == bucket
+	for _, arn := range cfg.TargetArns() {
+		if cfg.GetTargetDestination(arn).Bucket == bucket {
+			return true
+		}
+	}
+	return false
 }
 
 func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts ObjectOptions) (tgt *TargetClient, oi ObjectInfo, proxy bool, err error) {
@@ -865,10 +1364,6 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 	if err != nil {
 		return nil, oi, false, err
 	}
-	dest := cfg.GetDestination()
-	if dest.Bucket != bucket { // not active-active
-		return nil, oi, false, err
-	}
 	ssec := false
 	if opts.ServerSideEncryption != nil {
 		ssec = opts.ServerSideEncryption.Type() == encrypt.SSEC
@@ -877,12 +1372,15 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 		Name: object,
 		SSEC: ssec,
 	}
-	if !cfg.Replicate(ropts) { // no matching rule for object prefix
-		return nil, oi, false, nil
+	// only active-active targets replicating this object can be proxied to
+	var arns []string
+	for _, arn := range cfg.FilterTargetArns(ropts) {
+		if cfg.GetTargetDestination(arn).Bucket == bucket {
+			arns = append(arns, arn)
+		}
 	}
-	tgt = globalBucketTargetSys.GetRemoteTargetClient(ctx, cfg.RoleArn)
-	if tgt == nil || tgt.isOffline() {
-		return nil, oi, false, fmt.Errorf("target is offline or not configured")
+	if len(arns) == 0 {
+		return nil, oi, false, nil
 	}
 
 	gopts := miniogo.GetObjectOptions{
@@ -893,7 +1391,17 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 		},
 	}
 
-	objInfo, err := tgt.StatObject(ctx, dest.Bucket, object, gopts)
+	var objInfo miniogo.ObjectInfo
+	err = fmt.Errorf("target is offline or not configured")
+	for _, arn := range arns {
+		tgt = globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+		if tgt == nil || tgt.isOffline() {
+			continue
+		}
+		if objInfo, err = tgt.StatObject(ctx, bucket, object, gopts); err == nil {
+			break
+		}
+	}
 	if err != nil {
 		return nil, oi, false, err
 	}
@@ -934,11 +1442,26 @@ func proxyHeadToReplicationTarget(ctx context.Context, bucket, object string, op
 	return oi, proxy, err
 }
 
+// scheduleReplication replicates the object version to its remote targets, when
+// sync is set the targets configured for synchronous replication are replicated
+// to before returning and the others are queued.
 func scheduleReplication(ctx context.Context, objInfo ObjectInfo, o ObjectLayer, sync bool) {
-	if sync {
-		replicateObject(ctx, objInfo, o)
-	} else {
+	if !sync {
 		globalReplicationState.queueReplicaTask(objInfo)
+		return
+	}
+	for _, arn := range (replicateObjectInfo{ObjectInfo: objInfo}).targetArns() {
+		ri := replicateObjectInfo{ObjectInfo: objInfo, TargetArn: arn}
+		if tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn); tgt != nil && tgt.replicateSync {
+			if !replicateObject(ctx, ri, o) {
+				continue
+			}
+			// Failed replication is retried in the background.
+			ri.retries++
+		}
+		if globalReplicationState != nil {
+			globalReplicationState.queueReplicaTargetTask(ri)
+		}
 	}
 }
 
@@ -0,0 +1,197 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/replication"
+)
+
+const (
+	testReplicationArn1 = "arn:minio:replication:us-east-1:id1:dst1"
+	testReplicationArn2 = "arn:minio:replication:us-east-1:id2:dst2"
+)
+
+// prepareReplicationBucket creates a versioned bucket replicating to
+// two remote targets, the targets are not reachable.
+func prepareReplicationBucket(ctx context.Context, t *testing.T, bucket string) (*erasureServerPools, []string) {
+	z, disks := prepareErasurePools(ctx, t)
+	setObjectLayer(z)
+
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		removeRoots(disks)
+		t.Fatal(err)
+	}
+
+	rule := func(priority, arn string) string {
+		return `<Rule><Status>Enabled</Status><Priority>` + priority + `</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>` + arn + `</Bucket></Destination></Rule>`
+	}
+	cfg, err := replication.ParseConfig(strings.NewReader(`<ReplicationConfiguration>` + rule("1", testReplicationArn1) + rule("2", testReplicationArn2) + `</ReplicationConfiguration>`))
+	if err != nil {
+		removeRoots(disks)
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata(bucket)
+	meta.replicationConfig = cfg
+	globalBucketMetadataSys.Set(bucket, meta)
+	return z, disks
+}
+
+func TestReplicateObjectResyncStatuses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareReplicationBucket(ctx, t, "bucket")
+	defer removeRoots(disks)
+
+	testCases := []struct {
+		object     string
+		replStatus replication.StatusType
+		statuses   string
+		expected   replication.TargetStatuses
+	}{
+		// Status of the target not resynced is carried over.
+		{
+			object:     "object1",
+			replStatus: replication.Pending,
+			statuses:   testReplicationArn1 + "=" + replication.Completed.String() + ";",
+			expected: replication.TargetStatuses{
+				testReplicationArn1: replication.Completed,
+				testReplicationArn2: replication.Failed,
+			},
+		},
+		// Version queued to its targets when written, the target
+		// without a status is pending.
+		{
+			object:     "object2",
+			replStatus: replication.Pending,
+			expected: replication.TargetStatuses{
+				testReplicationArn1: replication.Pending,
+				testReplicationArn2: replication.Failed,
+			},
+		},
+		// Version replicated before the other target was added, only
+		// the status of the resynced target is changed.
+		{
+			object:     "object3",
+			replStatus: replication.Completed,
+			statuses:   testReplicationArn2 + "=" + replication.Completed.String() + ";",
+			expected: replication.TargetStatuses{
+				testReplicationArn2: replication.Failed,
+			},
+		},
+		// Version replicated before the statuses of the targets were
+		// recorded, the other target is not made pending.
+		{
+			object:     "object4",
+			replStatus: replication.Completed,
+			expected: replication.TargetStatuses{
+				testReplicationArn2: replication.Failed,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		data := []byte(tc.object)
+		opts := ObjectOptions{Versioned: true, UserDefined: map[string]string{
+			xhttp.AmzBucketReplicationStatus: tc.replStatus.String(),
+		}}
+		if tc.statuses != "" {
+			opts.UserDefined[replicationTargetStatusKey] = tc.statuses
+		}
+		oi, err := z.PutObject(ctx, "bucket", tc.object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The resync target is not reachable, its replication fails.
+		if replicateObject(ctx, replicateObjectInfo{ObjectInfo: oi, TargetArn: testReplicationArn2, retries: replicationMaxRetries}, z) {
+			t.Fatalf("Test %d: expected no retry once retries are exhausted", i+1)
+		}
+
+		oi, err = z.GetObjectInfo(ctx, "bucket", tc.object, ObjectOptions{VersionID: oi.VersionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses := replication.ParseTargetStatuses(oi.UserDefined[replicationTargetStatusKey])
+		if len(statuses) != len(tc.expected) {
+			t.Fatalf("Test %d: expected statuses %s, got %s", i+1, tc.expected, statuses)
+		}
+		for arn, status := range tc.expected {
+			if statuses[arn] != status {
+				t.Fatalf("Test %d: expected statuses %s, got %s", i+1, tc.expected, statuses)
+			}
+		}
+		if oi.ReplicationStatus != replication.Failed {
+			t.Fatalf("Test %d: expected replication status %s, got %s", i+1, replication.Failed, oi.ReplicationStatus)
+		}
+	}
+}
+
+func TestReplicateObjectRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareReplicationBucket(ctx, t, "bucket")
+	defer removeRoots(disks)
+
+	data := []byte("object")
+	oi, err := z.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+		Versioned: true,
+		UserDefined: map[string]string{
+			xhttp.AmzBucketReplicationStatus: replication.Pending.String(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The target is not reachable, the failed replication is retried
+	// without recording a status.
+	if !replicateObject(ctx, replicateObjectInfo{ObjectInfo: oi, TargetArn: testReplicationArn1}, z) {
+		t.Fatal("expected the failed replication to be retried")
+	}
+	oi, err = z.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{VersionID: oi.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := oi.UserDefined[replicationTargetStatusKey]; s != "" {
+		t.Fatalf("expected no target statuses, got %s", s)
+	}
+
+	// Once the retries are exhausted only the status of the target
+	// is recorded, the other target is pending.
+	if replicateObject(ctx, replicateObjectInfo{ObjectInfo: oi, TargetArn: testReplicationArn1, retries: replicationMaxRetries}, z) {
+		t.Fatal("expected no retry once retries are exhausted")
+	}
+	oi, err = z.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{VersionID: oi.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := replication.TargetStatuses{
+		testReplicationArn1: replication.Failed,
+		testReplicationArn2: replication.Pending,
+	}
+	if s := oi.UserDefined[replicationTargetStatusKey]; s != expected.String() {
+		t.Fatalf("expected statuses %s, got %s", expected, s)
+	}
+}
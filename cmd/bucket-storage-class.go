@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const bucketStorageClassConfigFile = "storage-class.json"
+
+// parseBucketStorageClass parses BucketStorageClass from json
+func parseBucketStorageClass(data []byte) (*madmin.BucketStorageClass, error) {
+	scCfg := &madmin.BucketStorageClass{}
+	if err := json.Unmarshal(data, scCfg); err != nil {
+		return nil, err
+	}
+	if scCfg.StorageClass != "" && !storageclass.IsValid(scCfg.StorageClass) {
+		return nil, fmt.Errorf("Invalid storage class %s", scCfg.StorageClass)
+	}
+	for _, rule := range scCfg.Rules {
+		if !storageclass.IsValid(rule.StorageClass) {
+			return nil, fmt.Errorf("Invalid storage class %s for prefix %s", rule.StorageClass, rule.Prefix)
+		}
+	}
+	return scCfg, nil
+}
+
+// bucketStorageClass returns the storage class configured for the
+// object, the rule with the longest matching prefix takes precedence
+// over the default storage class of the bucket.
+func bucketStorageClass(scCfg *madmin.BucketStorageClass, object string) string {
+	sc := scCfg.StorageClass
+	matched := -1
+	for _, rule := range scCfg.Rules {
+		if strings.HasPrefix(object, rule.Prefix) && len(rule.Prefix) > matched {
+			sc = rule.StorageClass
+			matched = len(rule.Prefix)
+		}
+	}
+	return sc
+}
+
+// setBucketStorageClass sets the storage class configured on the bucket
+// for objects uploaded without one.
+func setBucketStorageClass(bucket, object string, metadata map[string]string) {
+	if metadata[xhttp.AmzStorageClass] != "" || bucket == minioMetaBucket {
+		return
+	}
+	scCfg, err := globalBucketMetadataSys.GetStorageClassConfig(bucket)
+	if err != nil {
+		return
+	}
+	if sc := bucketStorageClass(scCfg, object); sc != "" {
+		metadata[xhttp.AmzStorageClass] = sc
+	}
+}
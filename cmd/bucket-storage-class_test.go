@@ -0,0 +1,146 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+func TestParseBucketStorageClass(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{}`, false},
+		{`{"storageClass":"REDUCED_REDUNDANCY"}`, false},
+		{`{"storageClass":"STANDARD","rules":[{"prefix":"tmp/","storageClass":"REDUCED_REDUNDANCY"}]}`, false},
+		{`{"storageClass":"GLACIER"}`, true},
+		{`{"rules":[{"prefix":"tmp/","storageClass":""}]}`, true},
+		{`{"storageClass":`, true},
+	}
+	for i, testCase := range testCases {
+		_, err := parseBucketStorageClass([]byte(testCase.data))
+		if (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestBucketStorageClass(t *testing.T) {
+	scCfg, err := parseBucketStorageClass([]byte(`{"storageClass":"STANDARD","rules":[
+		{"prefix":"scratch/","storageClass":"REDUCED_REDUNDANCY"},
+		{"prefix":"scratch/keep/","storageClass":"STANDARD"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		object       string
+		storageClass string
+	}{
+		{"object", "STANDARD"},
+		{"scratch/object", "REDUCED_REDUNDANCY"},
+		{"scratch/keep/object", "STANDARD"},
+		{"scratchobject", "STANDARD"},
+	}
+	for i, testCase := range testCases {
+		if sc := bucketStorageClass(scCfg, testCase.object); sc != testCase.storageClass {
+			t.Errorf("Test %d: expected storage class %s, got %s", i+1, testCase.storageClass, sc)
+		}
+	}
+}
+
+func TestBucketStorageClassPutCopy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(disks)
+	setObjectLayer(obj)
+
+	for _, bucket := range []string{"src", "dst"} {
+		if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scCfg, err := parseBucketStorageClass([]byte(`{"rules":[{"prefix":"scratch/","storageClass":"REDUCED_REDUNDANCY"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata("dst")
+	meta.storageClassConfig = scCfg
+	globalBucketMetadataSys.Set("dst", meta)
+
+	data := []byte("data")
+	srcInfo, err := obj.PutObject(ctx, "src", "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+		UserDefined: map[string]string{xhttp.AmzStorageClass: storageclass.RRS},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object       string
+		copied       bool
+		storageClass string
+		expected     string
+	}{
+		// Uploads get the storage class of the rule.
+		{object: "scratch/put", expected: storageclass.RRS},
+		// The requested storage class takes precedence.
+		{object: "scratch/put-standard", storageClass: storageclass.STANDARD, expected: storageclass.STANDARD},
+		// Uploads not matching a rule get the default storage class.
+		{object: "put", expected: storageclass.STANDARD},
+		// Copies get the storage class of the rule.
+		{object: "scratch/copy", copied: true, expected: storageclass.RRS},
+		{object: "scratch/copy-standard", copied: true, storageClass: storageclass.STANDARD, expected: storageclass.STANDARD},
+		{object: "copy", copied: true, expected: storageclass.STANDARD},
+	}
+	for i, tc := range testCases {
+		userDefined := make(map[string]string)
+		if tc.storageClass != "" {
+			userDefined[xhttp.AmzStorageClass] = tc.storageClass
+		}
+		reader := mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", "")
+		if tc.copied {
+			cpInfo := srcInfo
+			cpInfo.UserDefined = userDefined
+			cpInfo.PutObjReader = reader
+			_, err = obj.CopyObject(ctx, "src", "object", "dst", tc.object, cpInfo, ObjectOptions{}, ObjectOptions{})
+		} else {
+			_, err = obj.PutObject(ctx, "dst", tc.object, reader, ObjectOptions{UserDefined: userDefined})
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		oi, err := obj.GetObjectInfo(ctx, "dst", tc.object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if sc := oi.StorageClass; sc != tc.expected {
+			t.Fatalf("Test %d: expected storage class %q, got %q", i+1, tc.expected, sc)
+		}
+	}
+}
@@ -88,7 +88,8 @@ func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *m
 	if globalIsGateway {
 		return nil
 	}
-	if !tgt.Type.IsValid() && !update {
+	// Lifecycle transition uses remote tiers instead of bucket targets.
+	if (!tgt.Type.IsValid() || tgt.Type == madmin.ILMService) && !update {
 		return BucketRemoteArnTypeInvalid{Bucket: bucket}
 	}
 	clnt, err := sys.getRemoteTargetClient(tgt)
@@ -117,20 +118,6 @@ func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *m
 			return BucketRemoteTargetNotVersioned{Bucket: tgt.TargetBucket}
 		}
 	}
-	if tgt.Type == madmin.ILMService {
-		if globalBucketVersioningSys.Enabled(bucket) {
-			vcfg, err := clnt.GetBucketVersioning(ctx, tgt.TargetBucket)
-			if err != nil {
-				if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
-					return BucketRemoteTargetNotFound{Bucket: tgt.TargetBucket}
-				}
-				return BucketRemoteConnectionErr{Bucket: tgt.TargetBucket}
-			}
-			if vcfg.Status != string(versioning.Enabled) {
-				return BucketRemoteTargetNotVersioned{Bucket: tgt.TargetBucket}
-			}
-		}
-	}
 	sys.Lock()
 	defer sys.Unlock()
 
@@ -140,20 +127,19 @@ func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *m
 	found := false
 	for idx, t := range tgts {
 		labels[t.Label] = struct{}{}
-		if t.Type == tgt.Type {
-			if t.Arn == tgt.Arn && !update {
+		// a bucket may have several targets of the same type,
+		// only the target with the same ARN is replaced.
+		if t.Arn == tgt.Arn {
+			if !update {
 				return BucketRemoteAlreadyExists{Bucket: t.TargetBucket}
 			}
-			if t.Label == tgt.Label && !update {
-				return BucketRemoteLabelInUse{Bucket: t.TargetBucket}
-			}
 			newtgts[idx] = *tgt
 			found = true
 			continue
 		}
 		newtgts[idx] = t
 	}
-	if _, ok := labels[tgt.Label]; ok && !update {
+	if _, ok := labels[tgt.Label]; ok && tgt.Label != "" && !update {
 		return BucketRemoteLabelInUse{Bucket: tgt.TargetBucket}
 	}
 	if !found && !update {
@@ -183,19 +169,23 @@ func (sys *BucketTargetSys) RemoveTarget(ctx context.Context, bucket, arnStr str
 		}
 		// reject removal of remote target if replication configuration is present
 		rcfg, err := getReplicationConfig(ctx, bucket)
-		if err == nil && rcfg.RoleArn == arnStr {
-			if _, ok := sys.arnRemotesMap[arnStr]; ok {
-				return BucketRemoteRemoveDisallowed{Bucket: bucket}
+		if err == nil {
+			for _, arn := range rcfg.TargetArns() {
+				if arn != arnStr {
+					continue
+				}
+				if _, ok := sys.arnRemotesMap[arnStr]; ok {
+					return BucketRemoteRemoveDisallowed{Bucket: bucket}
+				}
 			}
 		}
 	}
 	if arn.Type == madmin.ILMService {
-		// reject removal of remote target if lifecycle transition uses this arn
+		// reject removal of remote target if lifecycle transition uses this arn,
+		// the versions transitioned before remote tiers are read from it.
 		config, err := globalBucketMetadataSys.GetLifecycleConfig(bucket)
 		if err == nil && transitionSCInUse(ctx, config, bucket, arnStr) {
-			if _, ok := sys.arnRemotesMap[arnStr]; ok {
-				return BucketRemoteRemoveDisallowed{Bucket: bucket}
-			}
+			return BucketRemoteRemoveDisallowed{Bucket: bucket}
 		}
 	}
 
@@ -230,32 +220,27 @@ func (sys *BucketTargetSys) GetRemoteTargetClient(ctx context.Context, arn strin
 	return sys.arnRemotesMap[arn]
 }
 
-// GetRemoteTargetWithLabel returns bucket target given a target label
-func (sys *BucketTargetSys) GetRemoteTargetWithLabel(ctx context.Context, bucket, targetLabel string) *madmin.BucketTarget {
+// GetRemoteArnWithLabel returns the ARN of the bucket target with the label,
+// labels are matched regardless of case.
+func (sys *BucketTargetSys) GetRemoteArnWithLabel(ctx context.Context, bucket, tgtLabel string) *madmin.ARN {
+	if tgtLabel == "" {
+		return nil
+	}
 	sys.RLock()
 	defer sys.RUnlock()
 	for _, t := range sys.targetsMap[bucket] {
-		if strings.ToUpper(t.Label) == strings.ToUpper(targetLabel) {
-			tgt := t.Clone()
-			return &tgt
+		if !strings.EqualFold(t.Label, tgtLabel) {
+			continue
+		}
+		arn, err := madmin.ParseARN(t.Arn)
+		if err != nil {
+			return nil
 		}
+		return arn
 	}
 	return nil
 }
 
-// GetRemoteArnWithLabel returns bucket target's ARN given its target label
-func (sys *BucketTargetSys) GetRemoteArnWithLabel(ctx context.Context, bucket, tgtLabel string) *madmin.ARN {
-	tgt := sys.GetRemoteTargetWithLabel(ctx, bucket, tgtLabel)
-	if tgt == nil {
-		return nil
-	}
-	arn, err := madmin.ParseARN(tgt.Arn)
-	if err != nil {
-		return nil
-	}
-	return arn
-}
-
 // GetRemoteLabelWithArn returns a bucket target's label given its ARN
 func (sys *BucketTargetSys) GetRemoteLabelWithArn(ctx context.Context, bucket, arnStr string) string {
 	sys.RLock()
@@ -404,6 +389,9 @@ func generateARN(t *madmin.BucketTarget) string {
 	hash.Write([]byte(t.Type))
 	hash.Write([]byte(t.Region))
 	hash.Write([]byte(t.TargetBucket))
+	// targets with the same bucket name on different
+	// endpoints must not share the ARN.
+	hash.Write([]byte(t.Endpoint))
 	hashSum := hex.EncodeToString(hash.Sum(nil))
 	arn := madmin.ARN{
 		Type:   t.Type,
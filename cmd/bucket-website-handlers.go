@@ -0,0 +1,373 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/website"
+	"github.com/minio/minio/pkg/handlers"
+)
+
+const (
+	// Website configuration file.
+	bucketWebsiteConfig = "website.xml"
+
+	// Website endpoints are served at <bucket>.s3-website.<domain>
+	websiteDomainPrefix = "s3-website."
+
+	// Maximum size of bucket website configuration payload sent to the PutBucketWebsiteHandler.
+	maxBucketWebsiteConfigSize = 1 * humanize.MiByte
+)
+
+// PutBucketWebsiteHandler - This HTTP handler stores given bucket website configuration as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketWebsite.html
+func (api objectAPIHandlers) PutBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Error out if Content-Length is missing.
+	// PutBucketWebsite always needs Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Error out if Content-Length is beyond allowed size.
+	if r.ContentLength > maxBucketWebsiteConfigSize {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := website.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Validate the received bucket website document
+	if err = config.Validate(); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(bucket, bucketWebsiteConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketWebsiteHandler - This HTTP handler returns bucket website configuration.
+func (api objectAPIHandlers) GetBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketWebsiteHandler - This HTTP handler removes bucket website configuration.
+func (api objectAPIHandlers) DeleteBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err := globalBucketMetadataSys.Update(bucket, bucketWebsiteConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Success.
+	writeSuccessNoContent(w)
+}
+
+// websiteRedirect sends a redirect response to location.
+func websiteRedirect(w http.ResponseWriter, location string, statusCode int) {
+	w.Header().Set(xhttp.Location, location)
+	w.WriteHeader(statusCode)
+}
+
+// serveWebsiteObject writes the object to the client with the given
+// status code, nothing is written when an error code is returned. Range
+// and conditional headers are only evaluated for the requested object,
+// not for error documents.
+func (api objectAPIHandlers) serveWebsiteObject(ctx context.Context, w http.ResponseWriter, r *http.Request, bucket, object string, statusCode int) APIErrorCode {
+	objectAPI := api.ObjectAPI()
+
+	// Website endpoints serve objects readable by the requester,
+	// which usually means anonymous access via bucket policy.
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		return s3Error
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		return toAPIErrorCode(ctx, err)
+	}
+
+	getObjectNInfo := objectAPI.GetObjectNInfo
+	if api.CacheAPI() != nil {
+		getObjectNInfo = api.CacheAPI().GetObjectNInfo
+	}
+
+	var rs *HTTPRangeSpec
+	if statusCode == http.StatusOK {
+		// Get request range.
+		if rangeHeader := r.Header.Get(xhttp.Range); rangeHeader != "" {
+			var rangeErr error
+			rs, rangeErr = parseRequestRangeSpec(rangeHeader)
+			// Handle only errInvalidRange. Ignore other
+			// parse error and treat it as regular Get
+			// request like Amazon S3.
+			if rangeErr == errInvalidRange {
+				return ErrInvalidRange
+			}
+			if rangeErr != nil {
+				logger.LogIf(ctx, rangeErr, logger.Application)
+			}
+		}
+
+		// Validate pre-conditions if any.
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			if objectAPI.IsEncryptionSupported() {
+				if _, err := DecryptObjectInfo(&oi, r); err != nil {
+					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+					return true
+				}
+			}
+
+			return checkPreconditions(ctx, w, r, oi, opts)
+		}
+	}
+
+	gr, err := getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, opts)
+	if err != nil {
+		if isErrPreconditionFailed(err) {
+			// The response was written by the pre-conditions check.
+			return ErrNone
+		}
+		return toAPIErrorCode(ctx, err)
+	}
+	defer gr.Close()
+
+	objInfo := gr.ObjInfo
+
+	// Set encryption response headers
+	if objectAPI.IsEncryptionSupported() {
+		switch kind, _ := crypto.IsEncrypted(objInfo.UserDefined); kind {
+		case crypto.S3:
+			w.Header().Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
+		case crypto.SSEC:
+			w.Header().Set(xhttp.AmzServerSideEncryptionCustomerAlgorithm, r.Header.Get(xhttp.AmzServerSideEncryptionCustomerAlgorithm))
+			w.Header().Set(xhttp.AmzServerSideEncryptionCustomerKeyMD5, r.Header.Get(xhttp.AmzServerSideEncryptionCustomerKeyMD5))
+		}
+	}
+
+	if err = setObjectHeaders(w, objInfo, rs, opts); err != nil {
+		return toAPIErrorCode(ctx, err)
+	}
+
+	if rs != nil {
+		statusCode = http.StatusPartialContent
+	}
+	w.WriteHeader(statusCode)
+	if r.Method == http.MethodHead {
+		return ErrNone
+	}
+
+	// Write object content to response body
+	if _, err = io.Copy(w, gr); err != nil {
+		logger.LogIf(ctx, err)
+	}
+	return ErrNone
+}
+
+// WebsiteHandler - serves GET and HEAD requests made to the website
+// endpoint of a bucket. Directory-like keys are served with the index
+// document, failed requests are answered with the error document and
+// routing rules are evaluated before and after fetching the object.
+func (api objectAPIHandlers) WebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Website")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := url.PathUnescape(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	proto := handlers.GetSourceScheme(r)
+	if proto == "" {
+		proto = getURLScheme(globalIsTLS)
+	}
+
+	if config.RedirectAllRequestsTo != nil {
+		websiteRedirect(w, config.RedirectAllRequestsTo.Location(proto, object), http.StatusMovedPermanently)
+		return
+	}
+
+	if rule, ok := config.Match(object, 0); ok {
+		websiteRedirect(w, rule.Location(proto, r.Host, object), rule.StatusCode())
+		return
+	}
+
+	key := config.IndexKey(object)
+	s3Error := api.serveWebsiteObject(ctx, w, r, bucket, key, http.StatusOK)
+	if s3Error == ErrNone {
+		return
+	}
+
+	// Keys without a trailing slash which name a directory
+	// holding an index document are redirected to the directory.
+	// The existence of the index document is only revealed to
+	// requesters allowed to read it.
+	if s3Error == ErrNoSuchKey && key == object && object != "" {
+		indexKey := config.IndexKey(object + SlashSeparator)
+		if checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, indexKey) == ErrNone {
+			if _, err = objAPI.GetObjectInfo(ctx, bucket, indexKey, ObjectOptions{}); err == nil {
+				websiteRedirect(w, SlashSeparator+object+SlashSeparator, http.StatusFound)
+				return
+			}
+		}
+	}
+
+	apiErr := errorCodes.ToAPIErr(s3Error)
+	if rule, ok := config.Match(object, apiErr.HTTPStatusCode); ok {
+		websiteRedirect(w, rule.Location(proto, r.Host, object), rule.StatusCode())
+		return
+	}
+
+	if config.ErrorDocument != nil && apiErr.HTTPStatusCode >= 400 && apiErr.HTTPStatusCode < 500 {
+		if s3Error = api.serveWebsiteObject(ctx, w, r, bucket, config.ErrorDocument.Key, apiErr.HTTPStatusCode); s3Error == ErrNone {
+			return
+		}
+	}
+
+	if r.Method == http.MethodHead {
+		writeErrorResponseHeadersOnly(w, apiErr)
+		return
+	}
+	writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+}
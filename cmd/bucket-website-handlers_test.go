@@ -0,0 +1,291 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Test S3 Bucket website APIs
+func TestBucketWebsite(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketWebsiteHandlers, []string{"GetBucketWebsite", "PutBucketWebsite", "DeleteBucketWebsite", "Website"})
+}
+
+// Simple tests of bucket website: PUT, GET, DELETE and the requests served
+// by the website endpoint. Tests are related and the order is important.
+func testBucketWebsiteHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+
+	websiteConfig := `<WebsiteConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><HttpRedirectCode>302</HttpRedirectCode><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule><RoutingRule><Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals><KeyPrefixEquals>old/</KeyPrefixEquals></Condition><Redirect><HostName>archive.example.com</HostName></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`
+
+	// test cases with sample input and expected output.
+	testCases := []bucketConfigTestCase{
+		// No website configuration yet.
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusNotFound,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "NoSuchWebsiteConfiguration",
+				Message:  "The specified bucket does not have a website configuration",
+			},
+			shouldPass: false,
+		},
+		// Wrong credentials
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          "abcd",
+			secretKey:          "abcd",
+			body:               []byte(websiteConfig),
+			expectedRespStatus: http.StatusForbidden,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "InvalidAccessKeyId",
+				Message:  "The Access Key Id you provided does not exist in our records.",
+			},
+			shouldPass: false,
+		},
+		// Malformed XML
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<WebsiteConfiguration><IndexDocument>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "The XML you provided was not well-formed or did not validate against our published schema.",
+			},
+			shouldPass: false,
+		},
+		// Missing Content-Length
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusLengthRequired,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MissingContentLength",
+				Message:  "You must provide the Content-Length HTTP header.",
+			},
+			shouldPass: false,
+		},
+		// Configuration beyond the allowed size
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               bytes.Repeat([]byte(" "), maxBucketWebsiteConfigSize+1),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "EntityTooLarge",
+				Message:  "Your proposed upload exceeds the maximum allowed object size.",
+			},
+			shouldPass: false,
+		},
+		// Index document missing
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<WebsiteConfiguration><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "A value for IndexDocument Suffix must be provided if RedirectAllRequestsTo is empty",
+			},
+			shouldPass: false,
+		},
+		// Redirect of all requests with other rules
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "RedirectAllRequestsTo cannot be provided in conjunction with other Routing/Redirect configurations",
+			},
+			shouldPass: false,
+		},
+		{
+			method:             http.MethodPut,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			body:               []byte(websiteConfig),
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(``),
+			shouldPass:         true,
+		},
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusOK,
+			configResponse:     []byte(websiteConfig),
+			shouldPass:         true,
+		},
+	}
+	testBucketConfig(instanceType, apiRouter, t, getBucketWebsiteURL, testCases)
+
+	ctx := context.Background()
+	objects := map[string]string{
+		"index.html":     "root index",
+		"dir/index.html": "dir index",
+		"error.html":     "error page",
+		"file.txt":       "0123456789",
+	}
+	var fileETag string
+	for object, data := range objects {
+		oi, err := obj.PutObject(ctx, bucketName, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: Failed to put object %s: <ERROR> %v", instanceType, object, err)
+		}
+		if object == "file.txt" {
+			fileETag = oi.ETag
+		}
+	}
+
+	websiteTestCases := []struct {
+		method    string
+		object    string
+		anonymous bool
+		headers   map[string]string
+		// Expected response
+		expectedRespStatus int
+		expectedBody       string
+		expectedLocation   string
+	}{
+		// Index document of the bucket.
+		{method: http.MethodGet, object: "", expectedRespStatus: http.StatusOK, expectedBody: "root index"},
+		// Index document of a directory.
+		{method: http.MethodGet, object: "dir/", expectedRespStatus: http.StatusOK, expectedBody: "dir index"},
+		// Directory without the trailing slash is redirected.
+		{method: http.MethodGet, object: "dir", expectedRespStatus: http.StatusFound, expectedLocation: "/dir/"},
+		// Range request.
+		{method: http.MethodGet, object: "file.txt", headers: map[string]string{xhttp.Range: "bytes=2-4"},
+			expectedRespStatus: http.StatusPartialContent, expectedBody: "234"},
+		// Conditional request.
+		{method: http.MethodGet, object: "file.txt", headers: map[string]string{xhttp.IfNoneMatch: "\"" + fileETag + "\""},
+			expectedRespStatus: http.StatusNotModified},
+		// Error document of a missing key.
+		{method: http.MethodGet, object: "missing.html", expectedRespStatus: http.StatusNotFound, expectedBody: "error page"},
+		// HEAD of a missing key has no body.
+		{method: http.MethodHead, object: "missing.html", expectedRespStatus: http.StatusNotFound},
+		// Routing rule on the key prefix.
+		{method: http.MethodGet, object: "docs/guide.html", expectedRespStatus: http.StatusFound,
+			expectedLocation: "http://127.0.0.1:9000/documents/guide.html"},
+		// Routing rule on the error code of a missing key.
+		{method: http.MethodGet, object: "old/page.html", expectedRespStatus: http.StatusMovedPermanently,
+			expectedLocation: "http://archive.example.com/old/page.html"},
+		// Objects are only served to requesters allowed to read them.
+		{method: http.MethodGet, object: "file.txt", anonymous: true, expectedRespStatus: http.StatusForbidden},
+		// Nor are directories revealed to them.
+		{method: http.MethodGet, object: "dir", anonymous: true, expectedRespStatus: http.StatusForbidden},
+	}
+
+	// Redirects of routing rules are made to the requested host.
+	endPoint := "http://127.0.0.1:9000"
+	for i, testCase := range websiteTestCases {
+		rec := httptest.NewRecorder()
+		var req *http.Request
+		var err error
+		if testCase.anonymous {
+			req, err = newTestRequest(testCase.method, getGetObjectURL(endPoint, bucketName, testCase.object), 0, nil)
+		} else {
+			req, err = newTestSignedRequestV4(testCase.method, getGetObjectURL(endPoint, bucketName, testCase.object),
+				0, nil, creds.AccessKey, creds.SecretKey, testCase.headers)
+		}
+		if err != nil {
+			t.Fatalf("Website Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Website Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.expectedBody != "" && rec.Body.String() != testCase.expectedBody {
+			t.Errorf("Website Test %d: %s: Expected the response to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedBody, rec.Body.String())
+		}
+		if testCase.method == http.MethodHead && rec.Body.Len() != 0 {
+			t.Errorf("Website Test %d: %s: Expected no response body, but instead found `%s`", i+1, instanceType, rec.Body.String())
+		}
+		if location := rec.Header().Get(xhttp.Location); location != testCase.expectedLocation {
+			t.Errorf("Website Test %d: %s: Expected the location to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedLocation, location)
+		}
+	}
+
+	testCases = []bucketConfigTestCase{
+		{
+			method:             http.MethodDelete,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusNoContent,
+			configResponse:     []byte(``),
+			shouldPass:         true,
+		},
+		{
+			method:             http.MethodGet,
+			bucketName:         bucketName,
+			accessKey:          creds.AccessKey,
+			secretKey:          creds.SecretKey,
+			expectedRespStatus: http.StatusNotFound,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "NoSuchWebsiteConfiguration",
+				Message:  "The specified bucket does not have a website configuration",
+			},
+			shouldPass: false,
+		},
+	}
+	testBucketConfig(instanceType, apiRouter, t, getBucketWebsiteURL, testCases)
+
+	// The website is not served anymore.
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodGet, getGetObjectURL("", bucketName, ""), 0, nil, creds.AccessKey, creds.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
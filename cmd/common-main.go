@@ -42,6 +42,7 @@ import (
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/certs"
 	"github.com/minio/minio/pkg/console"
+	"github.com/minio/minio/pkg/dsync"
 	"github.com/minio/minio/pkg/env"
 	"github.com/minio/minio/pkg/handlers"
 )
@@ -70,6 +71,8 @@ func init() {
 	})
 
 	globalReplicationState = newReplicationState()
+	globalReplicationResyncSys = newReplicationResyncSys()
+	globalReplicationStats = newReplicationStats()
 	globalTransitionState = newTransitionState()
 
 	console.SetColor("Debug", color.New())
@@ -246,6 +249,15 @@ func handleCommonEnvVars() {
 		logger.Fatal(config.ErrInvalidFSOSyncValue(err), "Invalid MINIO_FS_OSYNC value in environment variable")
 	}
 
+	if name := env.Get(config.EnvLockBackend, ""); name != "" {
+		backend, ok := dsync.Backend(name)
+		if !ok {
+			logger.Fatal(config.ErrInvalidLockBackendValue(nil).Msg("Unknown lock backend `%s`, available backends are %v", name, dsync.Backends()),
+				"Invalid MINIO_LOCK_BACKEND value in environment variable")
+		}
+		globalLockBackend = backend
+	}
+
 	domains := env.Get(config.EnvDomain, "")
 	if len(domains) != 0 {
 		for _, domainName := range strings.Split(domains, config.ValueSeparator) {
@@ -0,0 +1,241 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+)
+
+const (
+	// s2StreamIdentifier is the chunk every s2 stream starts with,
+	// it is prepended when reading a stream from an indexed block.
+	s2StreamIdentifier = "\xff\x06\x00\x00S2sTwO"
+
+	s2ChunkHeaderSize     = 4
+	s2ChunkChecksumSize   = 4
+	s2ChunkCompressed     = 0x00
+	s2ChunkUncompressed   = 0x01
+	compressIndexVersion1 = 1
+
+	// compressIndexInterval is the minimum distance in the
+	// decompressed stream between two entries of the index.
+	compressIndexInterval = 1 << 20
+
+	// compressIndexMaxEntries limits the size of the index, the
+	// distance between entries is doubled whenever it is reached.
+	compressIndexMaxEntries = 1024
+)
+
+var errInvalidCompressIndex = errors.New("invalid compression index")
+
+// compressIndexEntry locates the start of an s2 block.
+type compressIndexEntry struct {
+	compressedOffset   int64
+	uncompressedOffset int64
+}
+
+// compressIndex maps offsets of the decompressed stream to the
+// s2 blocks holding them, the start of the stream is not stored.
+type compressIndex []compressIndexEntry
+
+// find returns the offsets of the last indexed block starting
+// at or before the decompressed offset.
+func (idx compressIndex) find(offset int64) (compressedOffset, uncompressedOffset int64) {
+	i := sort.Search(len(idx), func(i int) bool {
+		return idx[i].uncompressedOffset > offset
+	})
+	if i == 0 {
+		return 0, 0
+	}
+	return idx[i-1].compressedOffset, idx[i-1].uncompressedOffset
+}
+
+// marshal encodes the index as a version followed by the number of
+// entries and the deltas of their offsets, nil is returned for an
+// empty index.
+func (idx compressIndex) marshal() []byte {
+	if len(idx) == 0 {
+		return nil
+	}
+	b := make([]byte, 1+binary.MaxVarintLen64*(1+2*len(idx)))
+	b[0] = compressIndexVersion1
+	n := 1
+	n += binary.PutUvarint(b[n:], uint64(len(idx)))
+	var prev compressIndexEntry
+	for _, e := range idx {
+		n += binary.PutUvarint(b[n:], uint64(e.compressedOffset-prev.compressedOffset))
+		n += binary.PutUvarint(b[n:], uint64(e.uncompressedOffset-prev.uncompressedOffset))
+		prev = e
+	}
+	return b[:n]
+}
+
+// loadCompressIndex decodes an index encoded by marshal.
+func loadCompressIndex(b []byte) (compressIndex, error) {
+	if len(b) == 0 || b[0] != compressIndexVersion1 {
+		return nil, errInvalidCompressIndex
+	}
+	b = b[1:]
+	readUvarint := func() (int64, error) {
+		v, n := binary.Uvarint(b)
+		if n <= 0 || int64(v) < 0 {
+			return 0, errInvalidCompressIndex
+		}
+		b = b[n:]
+		return int64(v), nil
+	}
+	count, err := readUvarint()
+	if err != nil || count > int64(len(b)) {
+		return nil, errInvalidCompressIndex
+	}
+	idx := make(compressIndex, count)
+	var prev compressIndexEntry
+	for i := range idx {
+		if idx[i].compressedOffset, err = readUvarint(); err != nil {
+			return nil, err
+		}
+		if idx[i].uncompressedOffset, err = readUvarint(); err != nil {
+			return nil, err
+		}
+		idx[i].compressedOffset += prev.compressedOffset
+		idx[i].uncompressedOffset += prev.uncompressedOffset
+		prev = idx[i]
+	}
+	return idx, nil
+}
+
+// compressIndexWriter passes an s2 stream through to the underlying
+// writer and builds the index of its blocks on the way.
+type compressIndexWriter struct {
+	w        io.Writer
+	interval int64
+	index    compressIndex
+
+	// Header of the current chunk, collected until the
+	// decompressed length of the chunk is known.
+	hdr  []byte
+	skip int64 // Remaining bytes of the current chunk.
+
+	compressedOffset   int64
+	uncompressedOffset int64
+}
+
+func newCompressIndexWriter(w io.Writer) *compressIndexWriter {
+	return &compressIndexWriter{
+		w:        w,
+		interval: compressIndexInterval,
+		hdr:      make([]byte, 0, s2ChunkHeaderSize+s2ChunkChecksumSize+binary.MaxVarintLen64),
+	}
+}
+
+func (w *compressIndexWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.parse(p[:n])
+	return n, err
+}
+
+// headerLen returns the number of bytes of the current chunk
+// needed to know its decompressed length.
+func (w *compressIndexWriter) headerLen() int {
+	if len(w.hdr) < s2ChunkHeaderSize {
+		return s2ChunkHeaderSize
+	}
+	chunkLen := int(w.hdr[1]) | int(w.hdr[2])<<8 | int(w.hdr[3])<<16
+	if w.hdr[0] != s2ChunkCompressed {
+		return s2ChunkHeaderSize
+	}
+	// The block of compressed chunks starts with its decompressed length.
+	if chunkLen > s2ChunkChecksumSize+binary.MaxVarintLen64 {
+		chunkLen = s2ChunkChecksumSize + binary.MaxVarintLen64
+	}
+	return s2ChunkHeaderSize + chunkLen
+}
+
+func (w *compressIndexWriter) parse(p []byte) {
+	for len(p) > 0 {
+		if w.skip > 0 {
+			n := int64(len(p))
+			if n > w.skip {
+				n = w.skip
+			}
+			w.skip -= n
+			p = p[n:]
+			continue
+		}
+		for len(p) > 0 && len(w.hdr) < w.headerLen() {
+			w.hdr = append(w.hdr, p[0])
+			p = p[1:]
+		}
+		if len(w.hdr) < w.headerLen() {
+			return
+		}
+		w.addChunk()
+	}
+}
+
+// addChunk indexes the chunk whose header has been collected.
+func (w *compressIndexWriter) addChunk() {
+	chunkLen := int64(w.hdr[1]) | int64(w.hdr[2])<<8 | int64(w.hdr[3])<<16
+	var decodedLen int64
+	switch w.hdr[0] {
+	case s2ChunkCompressed:
+		if v, n := binary.Uvarint(w.hdr[s2ChunkHeaderSize+s2ChunkChecksumSize:]); n > 0 {
+			decodedLen = int64(v)
+		}
+	case s2ChunkUncompressed:
+		decodedLen = chunkLen - s2ChunkChecksumSize
+	}
+	if decodedLen > 0 {
+		var last int64
+		if len(w.index) > 0 {
+			last = w.index[len(w.index)-1].uncompressedOffset
+		}
+		if w.uncompressedOffset-last >= w.interval {
+			w.add(compressIndexEntry{
+				compressedOffset:   w.compressedOffset,
+				uncompressedOffset: w.uncompressedOffset,
+			})
+		}
+	}
+	w.compressedOffset += s2ChunkHeaderSize + chunkLen
+	w.uncompressedOffset += decodedLen
+	w.skip = s2ChunkHeaderSize + chunkLen - int64(len(w.hdr))
+	w.hdr = w.hdr[:0]
+}
+
+func (w *compressIndexWriter) add(e compressIndexEntry) {
+	w.index = append(w.index, e)
+	if len(w.index) < compressIndexMaxEntries {
+		return
+	}
+	// Keep every other entry.
+	w.interval *= 2
+	n := 0
+	for i := 1; i < len(w.index); i += 2 {
+		w.index[n] = w.index[i]
+		n++
+	}
+	w.index = w.index[:n]
+}
+
+// Index returns the encoded index of the stream written so far.
+func (w *compressIndexWriter) Index() []byte {
+	return w.index.marshal()
+}
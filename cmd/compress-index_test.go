@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/s2"
+)
+
+// compressibleData returns data compressing to about half its size.
+func compressibleData(size int) []byte {
+	rng := rand.New(rand.NewSource(int64(size)))
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = "abcdefghijklmnop"[rng.Intn(16)]
+	}
+	return data
+}
+
+func TestCompressIndex(t *testing.T) {
+	data := compressibleData(10 << 20)
+	r, idxFn := newS2CompressReader(bytes.NewReader(data), int64(len(data)))
+	defer r.Close()
+	compressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := loadCompressIndex(idxFn())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idx) < 8 {
+		t.Fatalf("expected at least 8 index entries, got %d", len(idx))
+	}
+	for i, e := range idx {
+		s2r := s2.NewReader(io.MultiReader(strings.NewReader(s2StreamIdentifier), bytes.NewReader(compressed[e.compressedOffset:])))
+		got := make([]byte, 1024)
+		if _, err = io.ReadFull(s2r, got); err != nil {
+			t.Fatalf("Entry %d: %v", i, err)
+		}
+		if !bytes.Equal(got, data[e.uncompressedOffset:e.uncompressedOffset+1024]) {
+			t.Fatalf("Entry %d: decompressed data mismatch", i)
+		}
+	}
+
+	if c, u := idx.find(compressIndexInterval - 1); c != 0 || u != 0 {
+		t.Errorf("expected the start of the stream, got %d, %d", c, u)
+	}
+	last := idx[len(idx)-1]
+	if c, u := idx.find(int64(len(data)) - 1); c != last.compressedOffset || u != last.uncompressedOffset {
+		t.Errorf("expected the last entry %v, got %d, %d", last, c, u)
+	}
+
+	if _, err = loadCompressIndex(nil); err != errInvalidCompressIndex {
+		t.Errorf("expected %v, got %v", errInvalidCompressIndex, err)
+	}
+	if _, err = loadCompressIndex(idxFn()[:3]); err != errInvalidCompressIndex {
+		t.Errorf("expected %v, got %v", errInvalidCompressIndex, err)
+	}
+}
+
+func TestCompressIndexMaxEntries(t *testing.T) {
+	iw := newCompressIndexWriter(ioutil.Discard)
+	iw.interval = 1
+	w := s2.NewWriter(iw, s2.WriterBlockSize(4<<10))
+	if _, err := w.Write(compressibleData(16 << 20)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := loadCompressIndex(iw.Index())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idx) == 0 || len(idx) >= compressIndexMaxEntries {
+		t.Fatalf("expected less than %d index entries, got %d", compressIndexMaxEntries, len(idx))
+	}
+	for i := 1; i < len(idx); i++ {
+		if idx[i].uncompressedOffset-idx[i-1].uncompressedOffset < iw.interval/2 {
+			t.Fatalf("Entry %d: expected entries at least %d apart", i, iw.interval/2)
+		}
+	}
+}
+
+func TestGetObjectReaderCompressedRange(t *testing.T) {
+	data := compressibleData(10 << 20)
+	r, idxFn := newS2CompressReader(bytes.NewReader(data), int64(len(data)))
+	defer r.Close()
+	compressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi := ObjectInfo{
+		Size: int64(len(compressed)),
+		UserDefined: map[string]string{
+			ReservedMetadataPrefix + "compression": compressionAlgorithmV2,
+			ReservedMetadataPrefix + "actual-size": strconv.Itoa(len(data)),
+		},
+		Parts: []ObjectPartInfo{{
+			Number:     1,
+			Size:       int64(len(compressed)),
+			ActualSize: int64(len(data)),
+			Index:      idxFn(),
+		}},
+	}
+
+	testCases := []struct {
+		start, end int64
+		seek       bool
+	}{
+		{0, 99, false},
+		{100, 1<<20 - 1, false},
+		{3<<20 + 17, 3<<20 + 1000, true},
+		{9 << 20, int64(len(data)) - 1, true},
+	}
+	for i, testCase := range testCases {
+		rs := &HTTPRangeSpec{Start: testCase.start, End: testCase.end}
+		fn, off, length, err := NewGetObjectReader(rs, oi, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if (off > 0) != testCase.seek {
+			t.Errorf("Test %d: expected seek %t, got offset %d", i+1, testCase.seek, off)
+		}
+		gr, err := fn(bytes.NewReader(compressed[off:off+length]), http.Header{}, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !bytes.Equal(got, data[testCase.start:testCase.end+1]) {
+			t.Errorf("Test %d: range data mismatch", i+1)
+		}
+	}
+}
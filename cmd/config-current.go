@@ -32,8 +32,10 @@ import (
 	"github.com/minio/minio/cmd/config/heal"
 	xldap "github.com/minio/minio/cmd/config/identity/ldap"
 	"github.com/minio/minio/cmd/config/identity/openid"
+	xtls "github.com/minio/minio/cmd/config/identity/tls"
 	"github.com/minio/minio/cmd/config/notify"
 	"github.com/minio/minio/cmd/config/policy/opa"
+	"github.com/minio/minio/cmd/config/scrub"
 	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
@@ -50,6 +52,7 @@ func initHelp() {
 		config.CompressionSubSys:    compress.DefaultKVS,
 		config.IdentityLDAPSubSys:   xldap.DefaultKVS,
 		config.IdentityOpenIDSubSys: openid.DefaultKVS,
+		config.IdentityTLSSubSys:    xtls.DefaultKVS,
 		config.PolicyOPASubSys:      opa.DefaultKVS,
 		config.RegionSubSys:         config.DefaultRegionKVS,
 		config.APISubSys:            api.DefaultKVS,
@@ -60,6 +63,7 @@ func initHelp() {
 		config.AuditWebhookSubSys:   logger.DefaultAuditKVS,
 		config.HealSubSys:           heal.DefaultKVS,
 		config.CrawlerSubSys:        crawler.DefaultKVS,
+		config.ScrubSubSys:          scrub.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -88,13 +92,18 @@ func initHelp() {
 			Description: "federate multiple clusters for IAM and Bucket DNS",
 		},
 		config.HelpKV{
-			Key:         config.IdentityOpenIDSubSys,
-			Description: "enable OpenID SSO support",
+			Key:             config.IdentityOpenIDSubSys,
+			Description:     "enable OpenID SSO support",
+			MultipleTargets: true,
 		},
 		config.HelpKV{
 			Key:         config.IdentityLDAPSubSys,
 			Description: "enable LDAP SSO support",
 		},
+		config.HelpKV{
+			Key:         config.IdentityTLSSubSys,
+			Description: "enable STS with TLS client certificates",
+		},
 		config.HelpKV{
 			Key:         config.PolicyOPASubSys,
 			Description: "[DEPRECATED] enable external OPA for policy enforcement",
@@ -119,6 +128,10 @@ func initHelp() {
 			Key:         config.CrawlerSubSys,
 			Description: "manage crawling for usage calculation, lifecycle, healing and more",
 		},
+		config.HelpKV{
+			Key:         config.ScrubSubSys,
+			Description: "manage periodic bitrot verification of all objects on all drives",
+		},
 		config.HelpKV{
 			Key:             config.LoggerWebhookSubSys,
 			Description:     "send server logs to webhook endpoints",
@@ -200,8 +213,10 @@ func initHelp() {
 		config.CompressionSubSys:    compress.Help,
 		config.HealSubSys:           heal.Help,
 		config.CrawlerSubSys:        crawler.Help,
+		config.ScrubSubSys:          scrub.Help,
 		config.IdentityOpenIDSubSys: openid.Help,
 		config.IdentityLDAPSubSys:   xldap.Help,
+		config.IdentityTLSSubSys:    xtls.Help,
 		config.PolicyOPASubSys:      opa.Help,
 		config.KmsVaultSubSys:       crypto.HelpVault,
 		config.KmsKesSubSys:         crypto.HelpKes,
@@ -281,6 +296,10 @@ func validateConfig(s config.Config, setDriveCounts []int) error {
 		return err
 	}
 
+	if _, err := scrub.LookupConfig(s[config.ScrubSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -312,7 +331,7 @@ func validateConfig(s config.Config, setDriveCounts []int) error {
 		env.SetEnvOff()
 	}
 
-	if _, err := openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	if _, err := openid.LookupConfigs(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody); err != nil {
 		return err
 	}
@@ -332,6 +351,10 @@ func validateConfig(s config.Config, setDriveCounts []int) error {
 		}
 	}
 
+	if _, err := xtls.Lookup(s[config.IdentityTLSSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	if _, err := opa.LookupConfig(s[config.PolicyOPASubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody); err != nil {
 		return err
@@ -489,11 +512,12 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 		logger.LogIf(ctx, fmt.Errorf(deprecationWarning))
 	}
 
-	globalOpenIDConfig, err = openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	openIDConfigs, err := openid.LookupConfigs(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OpenID: %w", err))
 	}
+	globalOpenIDConfig = openIDConfigs[config.Default]
 
 	opaCfg, err := opa.LookupConfig(s[config.PolicyOPASubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
@@ -501,7 +525,7 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OPA: %w", err))
 	}
 
-	globalOpenIDValidators = getOpenIDValidators(globalOpenIDConfig)
+	globalOpenIDValidators = getOpenIDValidators(openIDConfigs)
 	globalPolicyOPA = opa.New(opaCfg)
 
 	globalLDAPConfig, err = xldap.Lookup(s[config.IdentityLDAPSubSys][config.Default],
@@ -510,6 +534,11 @@ If you need help to migrate smoothly visit: https://min.io/pricing`
 		logger.LogIf(ctx, fmt.Errorf("Unable to parse LDAP configuration: %w", err))
 	}
 
+	globalSTSTLSConfig, err = xtls.Lookup(s[config.IdentityTLSSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize X.509/TLS STS API: %w", err))
+	}
+
 	// Load logger targets based on user's configuration
 	loggerUserAgent := getUserAgent(getMinioMode())
 
@@ -605,6 +634,12 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 		return fmt.Errorf("Unable to apply crawler config: %w", err)
 	}
 
+	// Bitrot scrub
+	scrubCfg, err := scrub.LookupConfig(s[config.ScrubSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply scrub config: %w", err)
+	}
+
 	// Apply configurations.
 	// We should not fail after this.
 	globalAPIConfig.init(apiConfig, objAPI.SetDriveCounts())
@@ -619,6 +654,10 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 
 	logger.LogIf(ctx, crawlerSleeper.Update(crawlerCfg.Delay, crawlerCfg.MaxWait))
 
+	globalScrubConfigMu.Lock()
+	globalScrubConfig = scrubCfg
+	globalScrubConfigMu.Unlock()
+
 	// Update all dynamic config values in memory.
 	globalServerConfigMu.Lock()
 	defer globalServerConfigMu.Unlock()
@@ -752,11 +791,13 @@ func loadConfig(objAPI ObjectLayer) error {
 // enabled providers in server config.
 // A new authentication provider is added like below
 // * Add a new provider in pkg/iam/openid package.
-func getOpenIDValidators(cfg openid.Config) *openid.Validators {
+func getOpenIDValidators(cfgs map[string]openid.Config) *openid.Validators {
 	validators := openid.NewValidators()
 
-	if cfg.JWKS.URL != nil {
-		validators.Add(openid.NewJWT(cfg))
+	for _, cfg := range cfgs {
+		if cfg.JWKS.URL != nil {
+			validators.Add(openid.NewJWT(cfg))
+		}
 	}
 
 	return validators
@@ -66,6 +66,7 @@ const (
 	PolicyOPASubSys      = "policy_opa"
 	IdentityOpenIDSubSys = "identity_openid"
 	IdentityLDAPSubSys   = "identity_ldap"
+	IdentityTLSSubSys    = "identity_tls"
 	CacheSubSys          = "cache"
 	RegionSubSys         = "region"
 	EtcdSubSys           = "etcd"
@@ -78,6 +79,7 @@ const (
 	AuditWebhookSubSys   = "audit_webhook"
 	HealSubSys           = "heal"
 	CrawlerSubSys        = "crawler"
+	ScrubSubSys          = "scrub"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -114,8 +116,10 @@ var SubSystems = set.CreateStringSet(
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
+	IdentityTLSSubSys,
 	CrawlerSubSys,
 	HealSubSys,
+	ScrubSubSys,
 	NotifyAMQPSubSys,
 	NotifyESSubSys,
 	NotifyKafkaSubSys,
@@ -134,6 +138,7 @@ var SubSystemsDynamic = set.CreateStringSet(
 	CompressionSubSys,
 	CrawlerSubSys,
 	HealSubSys,
+	ScrubSubSys,
 )
 
 // SubSystemsSingleTargets - subsystems which only support single target.
@@ -149,9 +154,10 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	KmsKesSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
-	IdentityOpenIDSubSys,
+	IdentityTLSSubSys,
 	HealSubSys,
 	CrawlerSubSys,
+	ScrubSubSys,
 }...)
 
 // Constant separators
@@ -38,6 +38,7 @@ const (
 	EnvFSOSync         = "MINIO_FS_OSYNC"
 	EnvArgs            = "MINIO_ARGS"
 	EnvDNSWebhook      = "MINIO_DNS_WEBHOOK_ENDPOINT"
+	EnvLockBackend     = "MINIO_LOCK_BACKEND"
 
 	EnvUpdate = "MINIO_UPDATE"
 
@@ -36,6 +36,12 @@ var (
 		"MINIO_DOMAIN only accepts non-overlapping domain values",
 	)
 
+	ErrInvalidLockBackendValue = newErrFn(
+		"Invalid lock backend value",
+		"Please check the passed value",
+		"Lock backend must be the name of a lock backend registered with the server",
+	)
+
 	ErrInvalidDomainValue = newErrFn(
 		"Invalid domain value",
 		"Please check the passed value",
@@ -50,6 +50,12 @@ var (
 			Optional:    true,
 			Type:        "csv",
 		},
+		config.HelpKV{
+			Key:         RolePolicy,
+			Description: `Comma separated list of policies applied to tokens without a policy claim e.g. "readonly"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -39,12 +40,15 @@ import (
 // Config - OpenID Config
 // RSA authentication target arguments
 type Config struct {
+	// Name of the provider, config.Default for the default provider.
+	Name string `json:"-"`
 	JWKS struct {
 		URL *xnet.URL `json:"url"`
 	} `json:"jwks"`
 	URL          *xnet.URL `json:"url,omitempty"`
 	ClaimPrefix  string    `json:"claimPrefix,omitempty"`
 	ClaimName    string    `json:"claimName,omitempty"`
+	RolePolicy   string    `json:"rolePolicy,omitempty"`
 	DiscoveryDoc DiscoveryDoc
 	ClientID     string
 	publicKeys   map[string]crypto.PublicKey
@@ -214,7 +218,51 @@ func (p *JWT) Validate(token, dsecs string) (map[string]interface{}, error) {
 
 // ID returns the provider name and authentication type.
 func (p *JWT) ID() ID {
-	return "jwt"
+	if p.Name == "" || p.Name == config.Default {
+		return "jwt"
+	}
+	return ID("jwt" + config.SubSystemSeparator + p.Name)
+}
+
+// PolicyClaimName returns the name of the claim carrying the policies
+// in the tokens of the provider.
+func (p *JWT) PolicyClaimName() string {
+	return p.ClaimPrefix + p.ClaimName
+}
+
+// matches returns the number of the provider settings, issuer and client
+// ID, which match the token claims, -1 if any of them does not match.
+func (p *JWT) matches(claims jwtgo.MapClaims) int {
+	n := 0
+	if issuer := p.DiscoveryDoc.Issuer; issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != issuer {
+			return -1
+		}
+		n++
+	}
+	if p.ClientID != "" {
+		if azp, _ := claims["azp"].(string); azp != p.ClientID && !audienceContains(claims["aud"], p.ClientID) {
+			return -1
+		}
+		n++
+	}
+	return n
+}
+
+// audienceContains returns true if the audience claim, a string or a list
+// of strings, contains the client ID.
+func audienceContains(aud interface{}, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []interface{}:
+		for _, a := range v {
+			if s, _ := a.(string); s == clientID {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // OpenID keys and envs.
@@ -225,6 +273,7 @@ const (
 	ClaimPrefix = "claim_prefix"
 	ClientID    = "client_id"
 	Scopes      = "scopes"
+	RolePolicy  = "role_policy"
 
 	EnvIdentityOpenIDClientID    = "MINIO_IDENTITY_OPENID_CLIENT_ID"
 	EnvIdentityOpenIDJWKSURL     = "MINIO_IDENTITY_OPENID_JWKS_URL"
@@ -232,6 +281,7 @@ const (
 	EnvIdentityOpenIDClaimName   = "MINIO_IDENTITY_OPENID_CLAIM_NAME"
 	EnvIdentityOpenIDClaimPrefix = "MINIO_IDENTITY_OPENID_CLAIM_PREFIX"
 	EnvIdentityOpenIDScopes      = "MINIO_IDENTITY_OPENID_SCOPES"
+	EnvIdentityOpenIDRolePolicy  = "MINIO_IDENTITY_OPENID_ROLE_POLICY"
 )
 
 // DiscoveryDoc - parses the output from openid-configuration
@@ -304,6 +354,10 @@ var (
 			Key:   JwksURL,
 			Value: "",
 		},
+		config.KV{
+			Key:   RolePolicy,
+			Value: "",
+		},
 	}
 )
 
@@ -312,28 +366,85 @@ func Enabled(kvs config.KVS) bool {
 	return kvs.Get(JwksURL) != ""
 }
 
-// LookupConfig lookup jwks from config, override with any ENVs.
+// LookupConfig lookup jwks of the default provider from config, override
+// with any ENVs.
 func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (c Config, err error) {
+	return lookupConfig(config.Default, kvs, transport, closeRespFn)
+}
+
+// LookupConfigs lookup jwks of all the providers from config, indexed by
+// provider name, override with any ENVs. Providers only configured with
+// ENVs, such as MINIO_IDENTITY_OPENID_CONFIG_URL_<name>, are also returned.
+// Like LookupConfig, the configs are returned along with the error of the
+// first provider which failed.
+func LookupConfigs(cfgKVS map[string]config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (cfgs map[string]Config, err error) {
+	kvsMap := make(map[string]config.KVS)
+	for _, envName := range []string{EnvIdentityOpenIDURL, EnvIdentityOpenIDJWKSURL} {
+		for _, e := range env.List(envName + config.Default) {
+			kvsMap[strings.TrimPrefix(e, envName+config.Default)] = DefaultKVS
+		}
+	}
+	for name, kvs := range cfgKVS {
+		kvsMap[name] = kvs
+	}
+	if _, ok := kvsMap[config.Default]; !ok {
+		kvsMap[config.Default] = DefaultKVS
+	}
+
+	names := make([]string, 0, len(kvsMap))
+	for name := range kvsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cfgs = make(map[string]Config, len(kvsMap))
+	for _, name := range names {
+		c, lerr := lookupConfig(name, kvsMap[name], transport, closeRespFn)
+		if lerr != nil && err == nil {
+			err = lerr
+			if name != config.Default {
+				err = fmt.Errorf("%s: %w", name, lerr)
+			}
+		}
+		cfgs[name] = c
+	}
+	return cfgs, err
+}
+
+func lookupConfig(name string, kvs config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (c Config, err error) {
 	if err = config.CheckValidKeys(config.IdentityOpenIDSubSys, kvs, DefaultKVS); err != nil {
 		return c, err
 	}
 
-	jwksURL := env.Get(EnvIamJwksURL, "") // Legacy
+	// The ENVs of a named provider are suffixed with its name.
+	envName := func(e string) string {
+		if name == config.Default {
+			return e
+		}
+		return e + config.Default + name
+	}
+
+	var jwksURL string
+	if name == config.Default {
+		jwksURL = env.Get(EnvIamJwksURL, "") // Legacy
+	}
 	if jwksURL == "" {
-		jwksURL = env.Get(EnvIdentityOpenIDJWKSURL, kvs.Get(JwksURL))
+		jwksURL = env.Get(envName(EnvIdentityOpenIDJWKSURL), kvs.Get(JwksURL))
 	}
 
 	c = Config{
-		ClaimName:   env.Get(EnvIdentityOpenIDClaimName, kvs.Get(ClaimName)),
-		ClaimPrefix: env.Get(EnvIdentityOpenIDClaimPrefix, kvs.Get(ClaimPrefix)),
+		Name:        name,
+		ClaimName:   env.Get(envName(EnvIdentityOpenIDClaimName), kvs.Get(ClaimName)),
+		ClaimPrefix: env.Get(envName(EnvIdentityOpenIDClaimPrefix), kvs.Get(ClaimPrefix)),
+		RolePolicy:  env.Get(envName(EnvIdentityOpenIDRolePolicy), kvs.Get(RolePolicy)),
 		publicKeys:  make(map[string]crypto.PublicKey),
-		ClientID:    env.Get(EnvIdentityOpenIDClientID, kvs.Get(ClientID)),
+		ClientID:    env.Get(envName(EnvIdentityOpenIDClientID), kvs.Get(ClientID)),
 		transport:   transport,
 		closeRespFn: closeRespFn,
 		mutex:       &sync.Mutex{}, // allocate for copying
 	}
 
-	configURL := env.Get(EnvIdentityOpenIDURL, kvs.Get(ConfigURL))
+	configURL := env.Get(envName(EnvIdentityOpenIDURL), kvs.Get(ConfigURL))
 	if configURL != "" {
 		c.URL, err = xnet.ParseHTTPURL(configURL)
 		if err != nil {
@@ -345,7 +456,7 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 		}
 	}
 
-	if scopeList := env.Get(EnvIdentityOpenIDScopes, kvs.Get(Scopes)); scopeList != "" {
+	if scopeList := env.Get(envName(EnvIdentityOpenIDScopes), kvs.Get(Scopes)); scopeList != "" {
 		var scopes []string
 		for _, scope := range strings.Split(scopeList, ",") {
 			scope = strings.TrimSpace(scope)
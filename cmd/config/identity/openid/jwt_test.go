@@ -20,10 +20,12 @@ import (
 	"crypto"
 	"encoding/json"
 	"net/url"
+	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/minio/minio/cmd/config"
 	xnet "github.com/minio/minio/pkg/net"
 )
 
@@ -199,3 +201,35 @@ func TestDefaultExpiryDuration(t *testing.T) {
 		}
 	}
 }
+
+func TestLookupConfigs(t *testing.T) {
+	os.Setenv(EnvIdentityOpenIDRolePolicy+"_corp", "readonly")
+	defer os.Unsetenv(EnvIdentityOpenIDRolePolicy + "_corp")
+
+	corpKVS := config.KVS{
+		config.KV{Key: ClaimName, Value: "groups"},
+		config.KV{Key: ClaimPrefix, Value: "corp/"},
+		config.KV{Key: RolePolicy, Value: "writeonly"},
+	}
+	cfgs, err := LookupConfigs(map[string]config.KVS{"corp": corpKVS}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfgs) != 2 {
+		t.Fatalf("Expected the default and the corp providers, got %v", cfgs)
+	}
+
+	if c := cfgs[config.Default]; c.ClaimName != "policy" || c.RolePolicy != "" {
+		t.Fatalf("Unexpected default provider %#v", c)
+	}
+	jwt := NewJWT(cfgs["corp"])
+	if jwt.ID() != "jwt:corp" {
+		t.Fatalf("Unexpected id %s for the validator", jwt.ID())
+	}
+	if jwt.PolicyClaimName() != "corp/groups" {
+		t.Fatalf("Unexpected policy claim name %s", jwt.PolicyClaimName())
+	}
+	if jwt.RolePolicy != "readonly" {
+		t.Fatalf("Expected the role policy from the environment, got %s", jwt.RolePolicy)
+	}
+}
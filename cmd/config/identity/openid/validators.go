@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
 )
 
 // ID - holds identification name authentication validator target.
@@ -85,6 +87,53 @@ func (list *Validators) Get(id ID) (p Validator, err error) {
 	return p, nil
 }
 
+// GetByToken - returns the JWT provider which issued the token. With
+// several providers, the provider is selected by the issuer and the
+// audience of the token, the one matching most of its settings wins.
+// The token is not validated.
+func (list *Validators) GetByToken(token string) (*JWT, error) {
+	list.RLock()
+	defer list.RUnlock()
+
+	var providers []*JWT
+	for _, v := range list.providers {
+		if p, ok := v.(*JWT); ok {
+			providers = append(providers, p)
+		}
+	}
+	switch len(providers) {
+	case 0:
+		return nil, errors.New("no OpenID provider configured")
+	case 1:
+		return providers[0], nil
+	}
+
+	var claims jwtgo.MapClaims
+	if _, _, err := new(jwtgo.Parser).ParseUnverified(token, &claims); err != nil {
+		return nil, err
+	}
+
+	var found *JWT
+	best, ambiguous := -1, false
+	for _, p := range providers {
+		n := p.matches(claims)
+		switch {
+		case n < 0 || n < best:
+		case n == best:
+			ambiguous = true
+		default:
+			found, best, ambiguous = p, n, false
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no OpenID provider configured for issuer %v", claims["iss"])
+	}
+	if ambiguous {
+		return nil, fmt.Errorf("several OpenID providers configured for issuer %v", claims["iss"])
+	}
+	return found, nil
+}
+
 // NewValidators - creates Validators.
 func NewValidators() *Validators {
 	return &Validators{providers: make(map[ID]Validator)}
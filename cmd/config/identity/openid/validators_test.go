@@ -21,6 +21,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	jwtgo "github.com/dgrijalva/jwt-go"
 	xnet "github.com/minio/minio/pkg/net"
 )
 
@@ -102,3 +103,73 @@ func TestValidators(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestValidatorsGetByToken(t *testing.T) {
+	newProvider := func(name, issuer, clientID string) *JWT {
+		cfg := Config{Name: name, ClientID: clientID}
+		cfg.DiscoveryDoc.Issuer = issuer
+		return NewJWT(cfg)
+	}
+	newToken := func(claims jwtgo.MapClaims) string {
+		token, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	vrs := NewValidators()
+	if _, err := vrs.GetByToken(newToken(jwtgo.MapClaims{})); err == nil {
+		t.Fatal("Unexpected should return error without providers")
+	}
+
+	// A single provider is used for all tokens.
+	if err := vrs.Add(newProvider("_", "https://accounts.example.com", "minio")); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := vrs.GetByToken(newToken(jwtgo.MapClaims{"iss": "https://other.example.com"})); err != nil || p.ID() != "jwt" {
+		t.Fatalf("Expected the default provider, got %v, %v", p, err)
+	}
+
+	if err := vrs.Add(newProvider("corp", "https://corp.example.com", "")); err != nil {
+		t.Fatal(err)
+	}
+	if err := vrs.Add(newProvider("corp-minio", "https://corp.example.com", "minio")); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		claims jwtgo.MapClaims
+		id     ID
+	}{
+		{jwtgo.MapClaims{"iss": "https://accounts.example.com", "aud": "minio"}, "jwt"},
+		{jwtgo.MapClaims{"iss": "https://accounts.example.com", "aud": "other"}, ""},
+		{jwtgo.MapClaims{"iss": "https://corp.example.com", "aud": "other"}, "jwt:corp"},
+		{jwtgo.MapClaims{"iss": "https://corp.example.com", "aud": []string{"other", "minio"}}, "jwt:corp-minio"},
+		{jwtgo.MapClaims{"iss": "https://corp.example.com", "azp": "minio"}, "jwt:corp-minio"},
+		{jwtgo.MapClaims{"iss": "https://other.example.com"}, ""},
+	}
+	for i, testCase := range testCases {
+		p, err := vrs.GetByToken(newToken(testCase.claims))
+		if testCase.id == "" {
+			if err == nil {
+				t.Fatalf("Test %d: expected no provider, got %s", i+1, p.ID())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if p.ID() != testCase.id {
+			t.Fatalf("Test %d: expected provider %s, got %s", i+1, testCase.id, p.ID())
+		}
+	}
+
+	// Providers matching the same settings are ambiguous.
+	if err := vrs.Add(newProvider("corp-dup", "https://corp.example.com", "")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vrs.GetByToken(newToken(jwtgo.MapClaims{"iss": "https://corp.example.com"})); err == nil {
+		t.Fatal("Unexpected should return error for ambiguous providers")
+	}
+}
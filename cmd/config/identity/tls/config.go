@@ -0,0 +1,179 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tls
+
+import (
+	"crypto/x509"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/env"
+)
+
+const (
+	defaultExpiry    = time.Hour * 1
+	defaultMaxExpiry = time.Hour * 12
+)
+
+// Config contains the settings of STS AssumeRoleWithCertificate, which
+// authenticates clients by their TLS client certificate.
+type Config struct {
+	// Enabled is set with MINIO_IDENTITY_TLS_ENABLE, the server only asks
+	// clients for their certificates when it is set.
+	Enabled bool `json:"enabled"`
+
+	// Maximum validity of the temporary credentials.
+	MaxExpiry time.Duration `json:"maxExpiry"`
+
+	// Client certificates must be issued by one of these CAs,
+	// configured explicitly with the client CA.
+	rootCAs *x509.CertPool
+}
+
+// TLS identity keys and envs.
+const (
+	ClientCA  = "client_ca"
+	MaxExpiry = "max_expiry"
+
+	EnvIdentityTLSEnable    = "MINIO_IDENTITY_TLS_ENABLE"
+	EnvIdentityTLSClientCA  = "MINIO_IDENTITY_TLS_CLIENT_CA"
+	EnvIdentityTLSMaxExpiry = "MINIO_IDENTITY_TLS_MAX_EXPIRY"
+)
+
+// DefaultKVS - default config for TLS identity config
+var (
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   ClientCA,
+			Value: "",
+		},
+		config.KV{
+			Key:   MaxExpiry,
+			Value: "12h",
+		},
+	}
+)
+
+// Errors returned when verifying client certificates.
+var (
+	ErrNoCertificate = errors.New("no TLS client certificate provided")
+	ErrNoCommonName  = errors.New("TLS client certificate has no subject common name")
+	ErrNoClientCA    = errors.New("no CA configured for TLS client certificates")
+)
+
+// IsEnabled returns true if the server asks clients for their TLS
+// certificates, which is only configured with the environment.
+func IsEnabled() bool {
+	enabled, err := config.ParseBool(env.Get(EnvIdentityTLSEnable, config.EnableOff))
+	return err == nil && enabled
+}
+
+// Lookup - initializes TLS identity config, overrides config, if any ENV
+// values are set. Client certificates are only verified against the
+// configured client CA, which is required when the TLS identity is enabled.
+func Lookup(kvs config.KVS) (c Config, err error) {
+	if err = config.CheckValidKeys(config.IdentityTLSSubSys, kvs, DefaultKVS); err != nil {
+		return c, err
+	}
+
+	enabled, err := config.ParseBool(env.Get(EnvIdentityTLSEnable, config.EnableOff))
+	if err != nil {
+		return c, err
+	}
+
+	c.MaxExpiry = defaultMaxExpiry
+	if v := env.Get(EnvIdentityTLSMaxExpiry, kvs.Get(MaxExpiry)); v != "" {
+		c.MaxExpiry, err = time.ParseDuration(v)
+		if err != nil {
+			return c, config.Errorf("Invalid TLS identity max expiry %s: %s", v, err)
+		}
+		if c.MaxExpiry < 15*time.Minute {
+			return c, config.Errorf("TLS identity max expiry %s must be at least 15m", v)
+		}
+	}
+
+	caFile := env.Get(EnvIdentityTLSClientCA, kvs.Get(ClientCA))
+	if caFile == "" {
+		if enabled {
+			return c, config.Errorf("TLS identity requires a client CA, set %s or %s", ClientCA, EnvIdentityTLSClientCA)
+		}
+		return c, nil
+	}
+	certs, err := config.ParsePublicCertFile(caFile)
+	if err != nil {
+		return c, err
+	}
+	c.rootCAs = x509.NewCertPool()
+	for _, cert := range certs {
+		c.rootCAs.AddCert(cert)
+	}
+	c.Enabled = enabled
+	return c, nil
+}
+
+// Verify verifies the certificate chain sent by a client, the client
+// certificate first, and returns the client certificate.
+func (c Config) Verify(chain []*x509.Certificate) (*x509.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, ErrNoCertificate
+	}
+	if c.rootCAs == nil {
+		return nil, ErrNoClientCA
+	}
+	opts := x509.VerifyOptions{
+		Roots:         c.rootCAs,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range chain[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := chain[0].Verify(opts); err != nil {
+		return nil, err
+	}
+	if chain[0].Subject.CommonName == "" {
+		return nil, ErrNoCommonName
+	}
+	return chain[0], nil
+}
+
+// GetExpiryDuration returns the validity of temporary credentials for the
+// requested duration in seconds, one hour if none was requested. It is at
+// most the configured max expiry and the validity of the certificate.
+func (c Config) GetExpiryDuration(dsecs string, cert *x509.Certificate) (time.Duration, error) {
+	expiry := defaultExpiry
+	if dsecs != "" {
+		secs, err := strconv.ParseInt(dsecs, 10, 64)
+		if err != nil {
+			return 0, auth.ErrInvalidDuration
+		}
+		expiry = time.Duration(secs) * time.Second
+		if expiry < 15*time.Minute || expiry > c.MaxExpiry {
+			return 0, auth.ErrInvalidDuration
+		}
+	}
+	if expiry > c.MaxExpiry {
+		expiry = c.MaxExpiry
+	}
+	if left := time.Until(cert.NotAfter); left < expiry {
+		expiry = left
+	}
+	return expiry, nil
+}
@@ -0,0 +1,193 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/auth"
+)
+
+func newTestCertificate(t *testing.T, cn string, isCA bool, usage x509.ExtKeyUsage, notAfter time.Time, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{usage},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestLookup(t *testing.T) {
+	c, err := Lookup(DefaultKVS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Enabled {
+		t.Fatalf("Unexpected config %#v", c)
+	}
+
+	os.Setenv(EnvIdentityTLSEnable, config.EnableOn)
+	defer os.Unsetenv(EnvIdentityTLSEnable)
+
+	if c, err = Lookup(DefaultKVS); err == nil || c.Enabled {
+		t.Fatal("Expected the TLS identity without client CA to be rejected")
+	}
+
+	ca, _ := newTestCertificate(t, "ca", true, x509.ExtKeyUsageAny, time.Now().Add(time.Hour), nil, nil)
+	dir, err := ioutil.TempDir("", "identity-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "client-ca.crt")
+	if err = ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Lookup(config.KVS{config.KV{Key: ClientCA, Value: caFile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Enabled || c.MaxExpiry != defaultMaxExpiry {
+		t.Fatalf("Unexpected config %#v", c)
+	}
+
+	kvs := config.KVS{config.KV{Key: ClientCA, Value: caFile}, config.KV{Key: MaxExpiry, Value: "1m"}}
+	if _, err = Lookup(kvs); err == nil {
+		t.Fatal("Expected a max expiry below 15m to be rejected")
+	}
+
+	kvs = config.KVS{config.KV{Key: ClientCA, Value: filepath.Join(dir, "missing-ca.crt")}}
+	if _, err = Lookup(kvs); err == nil {
+		t.Fatal("Expected a missing client CA file to be rejected")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour)
+	ca, caKey := newTestCertificate(t, "ca", true, x509.ExtKeyUsageAny, notAfter, nil, nil)
+	otherCA, otherKey := newTestCertificate(t, "other-ca", true, x509.ExtKeyUsageAny, notAfter, nil, nil)
+	client, _ := newTestCertificate(t, "readwrite", false, x509.ExtKeyUsageClientAuth, notAfter, ca, caKey)
+	server, _ := newTestCertificate(t, "readwrite", false, x509.ExtKeyUsageServerAuth, notAfter, ca, caKey)
+	noCN, _ := newTestCertificate(t, "", false, x509.ExtKeyUsageClientAuth, notAfter, ca, caKey)
+	untrusted, _ := newTestCertificate(t, "readwrite", false, x509.ExtKeyUsageClientAuth, notAfter, otherCA, otherKey)
+
+	dir, err := ioutil.TempDir("", "identity-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "client-ca.crt")
+	if err = ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without client CA no certificate is trusted.
+	if _, err = (Config{}).Verify([]*x509.Certificate{client}); err != ErrNoClientCA {
+		t.Fatalf("Expected %v, got %v", ErrNoClientCA, err)
+	}
+
+	c, err := Lookup(config.KVS{config.KV{Key: ClientCA, Value: caFile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		chain []*x509.Certificate
+		err   bool
+	}{
+		{[]*x509.Certificate{client}, false},
+		{nil, true},
+		{[]*x509.Certificate{server}, true},
+		{[]*x509.Certificate{noCN}, true},
+		{[]*x509.Certificate{untrusted}, true},
+	}
+	for i, testCase := range testCases {
+		cert, err := c.Verify(testCase.chain)
+		if testCase.err != (err != nil) {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err == nil && cert != client {
+			t.Fatalf("Test %d: expected the client certificate", i+1)
+		}
+	}
+}
+
+func TestGetExpiryDuration(t *testing.T) {
+	c := Config{MaxExpiry: 2 * time.Hour}
+	cert := &x509.Certificate{NotAfter: time.Now().Add(24 * time.Hour)}
+	testCases := []struct {
+		dsecs  string
+		expiry time.Duration
+		err    error
+	}{
+		{"", time.Hour, nil},
+		{"3600", time.Hour, nil},
+		{"7200", 2 * time.Hour, nil},
+		{"7201", 0, auth.ErrInvalidDuration},
+		{"600", 0, auth.ErrInvalidDuration},
+		{"1h", 0, auth.ErrInvalidDuration},
+	}
+	for i, testCase := range testCases {
+		expiry, err := c.GetExpiryDuration(testCase.dsecs, cert)
+		if err != testCase.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if expiry != testCase.expiry {
+			t.Fatalf("Test %d: expected expiry %s, got %s", i+1, testCase.expiry, expiry)
+		}
+	}
+
+	// The credentials do not outlive the certificate.
+	cert.NotAfter = time.Now().Add(30 * time.Minute)
+	if expiry, err := c.GetExpiryDuration("", cert); err != nil || expiry > 30*time.Minute {
+		t.Fatalf("Expected an expiry within the certificate validity, got %s, %v", expiry, err)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scrub
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Bitrot scrub environment variables
+const (
+	Period       = "period"
+	MaxBandwidth = "max_bandwidth"
+	MaxIOPS      = "max_iops"
+
+	EnvEnable       = "MINIO_SCRUB_ENABLE"
+	EnvPeriod       = "MINIO_SCRUB_PERIOD"
+	EnvMaxBandwidth = "MINIO_SCRUB_MAX_BANDWIDTH"
+	EnvMaxIOPS      = "MINIO_SCRUB_MAX_IOPS"
+)
+
+// Config represents the bitrot scrub settings.
+type Config struct {
+	// Enabled verifies all objects of the local drives every period.
+	Enabled bool `json:"enabled"`
+	// Period is the time between the start of two scrubs of a drive.
+	Period time.Duration `json:"period"`
+	// MaxBandwidth is the maximum bytes verified per second on a drive.
+	MaxBandwidth uint64 `json:"maxBandwidth"`
+	// MaxIOPS is the maximum files verified per second on a drive.
+	MaxIOPS int `json:"maxIOPS"`
+}
+
+var (
+	// DefaultKVS - default KV config for bitrot scrub settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Period,
+			Value: "720h",
+		},
+		config.KV{
+			Key:   MaxBandwidth,
+			Value: "10MiB",
+		},
+		config.KV{
+			Key:   MaxIOPS,
+			Value: "100",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         config.Enable,
+			Description: `verify the bitrot checksums of all objects on all drives periodically`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         Period,
+			Description: `time between the start of two scrubs of a drive, defaults to '720h'`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         MaxBandwidth,
+			Description: `maximum bytes verified per second on each drive, defaults to '10MiB'`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         MaxIOPS,
+			Description: `maximum files verified per second on each drive, defaults to '100'`,
+			Optional:    true,
+			Type:        "number",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.ScrubSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scrub:enable' value invalid: %w", err)
+	}
+	cfg.Period, err = time.ParseDuration(env.Get(EnvPeriod, kvs.Get(Period)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scrub:period' value invalid: %w", err)
+	}
+	if cfg.Period <= 0 {
+		return cfg, errors.New("'scrub:period' value invalid: must be positive")
+	}
+	cfg.MaxBandwidth, err = humanize.ParseBytes(env.Get(EnvMaxBandwidth, kvs.Get(MaxBandwidth)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scrub:max_bandwidth' value invalid: %w", err)
+	}
+	cfg.MaxIOPS, err = strconv.Atoi(env.Get(EnvMaxIOPS, kvs.Get(MaxIOPS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scrub:max_iops' value invalid: %w", err)
+	}
+	if cfg.MaxIOPS < 0 {
+		return cfg, errors.New("'scrub:max_iops' value invalid: must not be negative")
+	}
+	return cfg, nil
+}
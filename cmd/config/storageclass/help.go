@@ -39,6 +39,12 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         ClassInline,
+			Description: `store objects with erasure shards up to this size inline with their metadata, defaults to "16KiB", "0" disables it`,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
 )
@@ -46,6 +47,7 @@ const (
 	ClassStandard = "standard"
 	ClassRRS      = "rrs"
 	ClassDMA      = "dma"
+	ClassInline   = "inline_block"
 
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
@@ -53,6 +55,8 @@ const (
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
 	// DMA storage class environment variable
 	DMAEnv = "MINIO_STORAGE_CLASS_DMA"
+	// Inline block size environment variable
+	InlineEnv = "MINIO_STORAGE_CLASS_INLINE_BLOCK"
 
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
@@ -65,6 +69,9 @@ const (
 
 	// Default DMA value
 	defaultDMA = DMAReadWrite
+
+	// Default inline block size
+	defaultInlineBlock = "16KiB"
 )
 
 // DefaultKVS - default storage class config
@@ -82,6 +89,10 @@ var (
 			Key:   ClassDMA,
 			Value: defaultDMA,
 		},
+		config.KV{
+			Key:   ClassInline,
+			Value: defaultInlineBlock,
+		},
 	}
 )
 
@@ -95,6 +106,12 @@ type Config struct {
 	Standard StorageClass `json:"standard"`
 	RRS      StorageClass `json:"rrs"`
 	DMA      string       `json:"dma"`
+
+	// Erasure shards of objects up to InlineBlock bytes per
+	// drive are stored inline in xl.meta, 0 disables inlining.
+	InlineBlock int64 `json:"inline_block"`
+
+	initialized bool
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -249,6 +266,21 @@ func (sCfg Config) GetDMA() string {
 	return sCfg.DMA
 }
 
+// ShouldInline - returns true if an erasure shard of shardSize
+// bytes should be stored inline in xl.meta.
+func (sCfg Config) ShouldInline(shardSize int64) bool {
+	if shardSize < 0 {
+		return false
+	}
+	inlineBlock := sCfg.InlineBlock
+	if !sCfg.initialized {
+		// Defaults apply until the configuration is loaded.
+		b, _ := humanize.ParseBytes(defaultInlineBlock)
+		inlineBlock = int64(b)
+	}
+	return inlineBlock > 0 && shardSize <= inlineBlock
+}
+
 // Enabled returns if etcd is enabled.
 func Enabled(kvs config.KVS) bool {
 	ssc := kvs.Get(ClassStandard)
@@ -293,6 +325,17 @@ func LookupConfig(kvs config.KVS, setDriveCount int) (cfg Config, err error) {
 	}
 	cfg.DMA = dma
 
+	inline := env.Get(InlineEnv, kvs.Get(ClassInline))
+	if inline == "" {
+		inline = defaultInlineBlock
+	}
+	inlineBlock, err := humanize.ParseBytes(inline)
+	if err != nil {
+		return Config{}, config.ErrStorageClassValue(err).Msg("Invalid inline block size " + inline)
+	}
+	cfg.InlineBlock = int64(inlineBlock)
+	cfg.initialized = true
+
 	// Validation is done after parsing both the storage classes. This is needed because we need one
 	// storage class value to deduce the correct value of the other storage class.
 	if err = validateParity(cfg.Standard.Parity, cfg.RRS.Parity, setDriveCount); err != nil {
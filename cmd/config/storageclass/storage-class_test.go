@@ -20,6 +20,8 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/minio/minio/cmd/config"
 )
 
 func TestParseStorageClass(t *testing.T) {
@@ -161,3 +163,40 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+// Test ShouldInline with the default and configured inline block sizes.
+func TestShouldInline(t *testing.T) {
+	tests := []struct {
+		inline    string
+		shardSize int64
+		want      bool
+	}{
+		{"", -1, false},
+		{"", 0, true},
+		{"", 16 << 10, true},
+		{"", 16<<10 + 1, false},
+		{"128KiB", 128 << 10, true},
+		{"16KiB", 16 << 10, true},
+		{"16KiB", 16<<10 + 1, false},
+		{"0", 0, false},
+		{"0", 1, false},
+	}
+	for i, tt := range tests {
+		kvs := config.KVS{}
+		if tt.inline != "" {
+			kvs = append(kvs, config.KV{Key: ClassInline, Value: tt.inline})
+		}
+		cfg, err := LookupConfig(kvs, 16)
+		if err != nil {
+			t.Fatalf("Test %d, Unexpected error %v", i+1, err)
+		}
+		if got := cfg.ShouldInline(tt.shardSize); got != tt.want {
+			t.Errorf("Test %d, Expected inline to be %t, got %t", i+1, tt.want, got)
+		}
+	}
+
+	// Defaults apply until the configuration is loaded.
+	if !(Config{}).ShouldInline(1024) {
+		t.Error("Expected default configuration to inline small shards")
+	}
+}
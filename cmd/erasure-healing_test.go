@@ -144,6 +144,102 @@ func TestHealing(t *testing.T) {
 	}
 }
 
+// Tests healing of objects with their data inlined in xl.meta.
+func TestHealingInlineData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	er := z.serverPools[0].sets[0]
+
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 1*humanize.KiByte)
+	if _, err = rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	disk := er.getDisks()[0]
+	entries, err := disk.ListDir(ctx, bucket, object, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0] != xlStorageFormatFile {
+		t.Fatalf("expected only %s, got %v", xlStorageFormatFile, entries)
+	}
+
+	fileInfoPreHeal, err := disk.ReadVersion(ctx, bucket, object, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fileInfoPreHeal.InlineData() || len(fileInfoPreHeal.Data) == 0 {
+		t.Fatal("expected data to be inlined in xl.meta")
+	}
+
+	checkHealed := func() {
+		t.Helper()
+		fileInfoPostHeal, err := disk.ReadVersion(ctx, bucket, object, "", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(fileInfoPreHeal, fileInfoPostHeal) {
+			t.Fatal("HealObject failed")
+		}
+		var buf bytes.Buffer
+		if err = obj.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatal("unexpected object data after heal")
+		}
+	}
+
+	// Remove the object - to simulate the case where the disk was down when the object
+	// was created.
+	if err = removeAll(pathJoin(disk.String(), bucket, object)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = er.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealNormalScan}); err != nil {
+		t.Fatal(err)
+	}
+	checkHealed()
+
+	// Corrupt the inlined data, which is only detected by a deep scan.
+	fileInfoCorrupted := fileInfoPreHeal
+	fileInfoCorrupted.Data = append([]byte{}, fileInfoPreHeal.Data...)
+	fileInfoCorrupted.Data[len(fileInfoCorrupted.Data)-1] ^= 0xff
+	if err = disk.WriteMetadata(ctx, bucket, object, fileInfoCorrupted); err != nil {
+		t.Fatal(err)
+	}
+	if err = disk.CheckParts(ctx, bucket, object, fileInfoPreHeal); err != nil {
+		t.Fatal(err)
+	}
+	if err = disk.VerifyFile(ctx, bucket, object, fileInfoPreHeal); err != errFileCorrupt {
+		t.Fatalf("expected %v, got %v", errFileCorrupt, err)
+	}
+	if _, err = er.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealDeepScan}); err != nil {
+		t.Fatal(err)
+	}
+	checkHealed()
+	if err = disk.VerifyFile(ctx, bucket, object, fileInfoPreHeal); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestHealObjectCorrupted(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
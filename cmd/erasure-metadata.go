@@ -98,6 +98,23 @@ func (fi FileInfo) IsValid() bool {
 		correctIndexes)
 }
 
+// Metadata key marking versions with their erasure shard inlined in xl.meta.
+const inlineDataKey = ReservedMetadataPrefixLower + "inline-data"
+
+// InlineData - returns true if the erasure shard is inlined in xl.meta.
+func (fi FileInfo) InlineData() bool {
+	_, ok := fi.Metadata[inlineDataKey]
+	return ok
+}
+
+// SetInlineData - marks the erasure shard as inlined in xl.meta.
+func (fi *FileInfo) SetInlineData() {
+	if fi.Metadata == nil {
+		fi.Metadata = make(map[string]string)
+	}
+	fi.Metadata[inlineDataKey] = "true"
+}
+
 // ToObjectInfo - Converts metadata to object info.
 func (fi FileInfo) ToObjectInfo(bucket, object string) ObjectInfo {
 	object = decodeDirObject(object)
@@ -147,6 +164,8 @@ func (fi FileInfo) ToObjectInfo(bucket, object string) ObjectInfo {
 	}
 
 	objInfo.TransitionStatus = fi.TransitionStatus
+	objInfo.TransitionedObjName = fi.TransitionedObjName
+	objInfo.TransitionTier = fi.TransitionTier
 
 	// etag/md5Sum has already been extracted. We need to
 	// remove to avoid it from appearing as part of
@@ -184,12 +203,14 @@ func objectPartIndex(parts []ObjectPartInfo, partNumber int) int {
 }
 
 // AddObjectPart - add a new object part in order.
-func (fi *FileInfo) AddObjectPart(partNumber int, partETag string, partSize int64, actualSize int64) {
+func (fi *FileInfo) AddObjectPart(partNumber int, partETag string, partSize int64, actualSize int64, index []byte, checksum string) {
 	partInfo := ObjectPartInfo{
 		Number:     partNumber,
 		ETag:       partETag,
 		Size:       partSize,
 		ActualSize: actualSize,
+		Index:      index,
+		Checksum:   checksum,
 	}
 
 	// Update part info if it already exists.
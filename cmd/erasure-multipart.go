@@ -88,6 +88,118 @@ func (er erasureObjects) removeObjectPart(bucket, object, uploadID, dataDir stri
 	g.Wait()
 }
 
+// multipartObjectKey records the bucket and object of an upload, the
+// upload ID directory is named after their hash.
+const multipartObjectKey = ReservedMetadataPrefix + "multipart-object"
+
+// listMultipartDirs lists the directory of the multipart bucket on all the
+// disks, so that no entry is missed when some disks are offline.
+func (er erasureObjects) listMultipartDirs(ctx context.Context, dirPath string) ([]string, error) {
+	entries := set.NewStringSet()
+	var listed bool
+	for _, disk := range er.getDisks() {
+		if disk == nil {
+			continue
+		}
+		dirs, err := disk.ListDir(ctx, minioMetaMultipartBucket, dirPath, -1)
+		if err != nil {
+			if err == errFileNotFound {
+				listed = true
+			}
+			continue
+		}
+		listed = true
+		for _, dir := range dirs {
+			entries.Add(dir)
+		}
+	}
+	if !listed {
+		return nil, errDiskNotFound
+	}
+	return entries.ToSlice(), nil
+}
+
+// walkMultipartUploads calls fn with every multipart upload of this set
+// which recorded its bucket and object, their metadata is read with read
+// quorum. Uploads initiated by older servers did not record their object,
+// the SHA directories of their bucket and object are returned instead.
+func (er erasureObjects) walkMultipartUploads(ctx context.Context, fn func(upload MultipartInfo)) (set.StringSet, error) {
+	unrecorded := set.NewStringSet()
+	shaDirs, err := er.listMultipartDirs(ctx, "")
+	if err != nil {
+		return unrecorded, err
+	}
+
+	storageDisks := er.getDisks()
+	for _, shaDir := range shaDirs {
+		uploadIDDirs, err := er.listMultipartDirs(ctx, shaDir)
+		if err != nil {
+			continue
+		}
+		for _, uploadIDDir := range uploadIDDirs {
+			select {
+			case <-ctx.Done():
+				return unrecorded, ctx.Err()
+			default:
+			}
+			uploadIDPath := pathJoin(shaDir, uploadIDDir)
+			partsMetadata, errs := readAllFileInfo(ctx, storageDisks, minioMetaMultipartBucket, uploadIDPath, "", false)
+			readQuorum, _, err := objectQuorumFromMeta(ctx, partsMetadata, errs, er.defaultParityCount)
+			if err != nil {
+				continue
+			}
+			if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+				continue
+			}
+			_, modTime := listOnlineDisks(storageDisks, partsMetadata, errs)
+			fi, err := pickValidFileInfo(ctx, partsMetadata, modTime, readQuorum)
+			if err != nil {
+				continue
+			}
+			bucketObject := strings.SplitN(fi.Metadata[multipartObjectKey], SlashSeparator, 2)
+			if len(bucketObject) != 2 {
+				unrecorded.Add(strings.TrimSuffix(shaDir, SlashSeparator))
+				continue
+			}
+			fn(MultipartInfo{
+				Bucket:    bucketObject[0],
+				Object:    bucketObject[1],
+				UploadID:  strings.TrimSuffix(uploadIDDir, SlashSeparator),
+				Initiated: fi.ModTime,
+			})
+		}
+	}
+	return unrecorded, nil
+}
+
+// abortMultipartUploads removes every multipart upload of this set, also
+// the ones of older servers which did not record their object. The uploads
+// of a decommissioned pool are aborted as they are not moved.
+func (er erasureObjects) abortMultipartUploads(ctx context.Context) error {
+	shaDirs, err := er.listMultipartDirs(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	writeQuorum := getWriteQuorum(len(er.getDisks()))
+	for _, shaDir := range shaDirs {
+		uploadIDDirs, err := er.listMultipartDirs(ctx, shaDir)
+		if err != nil {
+			return err
+		}
+		for _, uploadIDDir := range uploadIDDirs {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			uploadIDPath := pathJoin(shaDir, uploadIDDir)
+			if err = er.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, writeQuorum); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Clean-up the old multipart uploads. Should be run in a Go routine.
 func (er erasureObjects) cleanupStaleUploads(ctx context.Context, expiry time.Duration) {
 	// run multiple cleanup's local to this server.
@@ -272,6 +384,7 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 	fi.DataDir = mustGetUUID()
 	fi.ModTime = UTCNow()
 	fi.Metadata = cloneMSS(opts.UserDefined)
+	fi.Metadata[multipartObjectKey] = pathJoin(bucket, object)
 
 	uploadID := mustGetUUID()
 	uploadIDPath := er.getUploadIDDir(bucket, object, uploadID)
@@ -494,8 +607,18 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 
 	md5hex := r.MD5CurrentHexString()
 
+	var index []byte
+	if opts.IndexCB != nil {
+		index = opts.IndexCB()
+	}
+
+	var checksum string
+	if opts.ChecksumCB != nil {
+		checksum = opts.ChecksumCB().String()
+	}
+
 	// Add the current part.
-	fi.AddObjectPart(partID, md5hex, n, data.ActualSize())
+	fi.AddObjectPart(partID, md5hex, n, data.ActualSize(), index, checksum)
 
 	for i, disk := range onlineDisks {
 		if disk == OfflineDisk {
@@ -764,6 +887,15 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 			return oi, invp
 		}
 
+		// The checksum of the part, if sent, has to match the uploaded part.
+		if !verifyPartChecksum(part, currentFI.Parts[partIdx]) {
+			return oi, InvalidPart{
+				PartNumber: part.PartNumber,
+				ExpETag:    currentFI.Parts[partIdx].ETag,
+				GotETag:    part.ETag,
+			}
+		}
+
 		// All parts except the last part has to be atleast 5MB.
 		if (i < len(parts)-1) && !isMinAllowedPartSize(currentFI.Parts[partIdx].ActualSize) {
 			return oi, PartTooSmall{
@@ -784,6 +916,8 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 			Number:     part.PartNumber,
 			Size:       currentFI.Parts[partIdx].Size,
 			ActualSize: currentFI.Parts[partIdx].ActualSize,
+			Index:      currentFI.Parts[partIdx].Index,
+			Checksum:   currentFI.Parts[partIdx].Checksum,
 		}
 	}
 
@@ -803,6 +937,12 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// The object is only recorded for incomplete uploads.
+	delete(fi.Metadata, multipartObjectKey)
+
+	// Save the checksum of the object computed from its parts.
+	setCompleteMultipartChecksum(fi.Metadata, fi.Parts)
+
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
@@ -832,12 +972,14 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		}
 	}
 
-	// Hold namespace to complete the transaction
-	lk := er.NewNSLock(bucket, object)
-	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
-		return oi, err
+	if !opts.NoLock {
+		// Hold namespace to complete the transaction
+		lk := er.NewNSLock(bucket, object)
+		if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+			return oi, err
+		}
+		defer lk.Unlock()
 	}
-	defer lk.Unlock()
 
 	// Rename the multipart object to final location.
 	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath,
@@ -17,12 +17,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -101,6 +103,10 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 		modTime = dstOpts.MTime
 		fi.ModTime = dstOpts.MTime
 	}
+	if fi.InlineData() {
+		// Keep the inlined data of the version.
+		srcInfo.UserDefined[inlineDataKey] = "true"
+	}
 	fi.Metadata = srcInfo.UserDefined
 	srcInfo.UserDefined["etag"] = srcInfo.ETag
 
@@ -209,12 +215,14 @@ func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object stri
 // startOffset indicates the starting read location of the object.
 // length indicates the total length of the object.
 func (er erasureObjects) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) error {
-	// Lock the object before reading.
-	lk := er.NewNSLock(bucket, object)
-	if err := lk.GetRLock(ctx, globalOperationTimeout); err != nil {
-		return err
+	if !opts.NoLock {
+		// Lock the object before reading.
+		lk := er.NewNSLock(bucket, object)
+		if err := lk.GetRLock(ctx, globalOperationTimeout); err != nil {
+			return err
+		}
+		defer lk.RUnlock()
 	}
-	defer lk.RUnlock()
 
 	// Start offset cannot be negative.
 	if startOffset < 0 {
@@ -370,12 +378,14 @@ func (er erasureObjects) getObject(ctx context.Context, bucket, object string, s
 
 // GetObjectInfo - reads object metadata and replies back ObjectInfo.
 func (er erasureObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (info ObjectInfo, err error) {
-	// Lock the object before reading.
-	lk := er.NewNSLock(bucket, object)
-	if err := lk.GetRLock(ctx, globalOperationTimeout); err != nil {
-		return ObjectInfo{}, err
+	if !opts.NoLock {
+		// Lock the object before reading.
+		lk := er.NewNSLock(bucket, object)
+		if err := lk.GetRLock(ctx, globalOperationTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer lk.RUnlock()
 	}
-	defer lk.RUnlock()
 
 	return er.getObjectInfo(ctx, bucket, object, opts)
 }
@@ -451,9 +461,9 @@ func (er erasureObjects) getObjectInfo(ctx context.Context, bucket, object strin
 	}
 	objInfo = fi.ToObjectInfo(bucket, object)
 	if objInfo.TransitionStatus == lifecycle.TransitionComplete {
-		// overlay storage class for transitioned objects with transition tier SC Label
-		if sc := transitionSC(ctx, bucket); sc != "" {
-			objInfo.StorageClass = sc
+		// overlay storage class for transitioned objects with the name of their remote tier
+		if objInfo.TransitionTier != "" {
+			objInfo.StorageClass = objInfo.TransitionTier
 		}
 	}
 	if !fi.VersionPurgeStatus.Empty() {
@@ -498,7 +508,9 @@ func undoRename(disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry str
 
 // Similar to rename but renames data from srcEntry to dstEntry at dataDir
 func renameData(ctx context.Context, disks []StorageAPI, srcBucket, srcEntry, dataDir, dstBucket, dstEntry string, writeQuorum int, ignoredErr []error) ([]StorageAPI, error) {
-	dataDir = retainSlash(dataDir)
+	if dataDir != "" {
+		dataDir = retainSlash(dataDir)
+	}
 	defer ObjectPathUpdated(pathJoin(srcBucket, srcEntry))
 	defer ObjectPathUpdated(pathJoin(dstBucket, dstEntry))
 
@@ -691,12 +703,22 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	partName := "part.1"
 	tempErasureObj := pathJoin(uniqueID, fi.DataDir, partName)
 
+	// Erasure shards of small objects are inlined in xl.meta.
+	shardFileSize := erasure.ShardFileSize(data.Size())
+	inlineBuffers := make([]*bytes.Buffer, len(onlineDisks))
+	inline := globalStorageClass.ShouldInline(shardFileSize)
+
 	writers := make([]io.Writer, len(onlineDisks))
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
 		}
-		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
+		if inline {
+			inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, bitrotShardFileSize(shardFileSize, erasure.ShardSize(), DefaultBitrotAlgorithm)))
+			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+			continue
+		}
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, shardFileSize, DefaultBitrotAlgorithm, erasure.ShardSize())
 	}
 
 	n, erasureErr := erasure.Encode(ctx, data, writers, buffer, writeQuorum)
@@ -719,21 +741,40 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock()
 	}
 
+	var index []byte
+	if opts.IndexCB != nil {
+		index = opts.IndexCB()
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
 			continue
 		}
-		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize())
+		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize(), index, "")
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: 1,
 			Algorithm:  DefaultBitrotAlgorithm,
 			Hash:       bitrotWriterSum(w),
 		})
+		if inline {
+			partsMetadata[i].Data = inlineBuffers[i].Bytes()
+		}
 	}
 	if opts.UserDefined["etag"] == "" {
 		opts.UserDefined["etag"] = r.MD5CurrentHexString()
 	}
+	if opts.ChecksumCB != nil {
+		if c := opts.ChecksumCB(); c != nil {
+			opts.UserDefined[objectChecksumKey] = c.String()
+		}
+	}
+
+	// Metadata copied from another version never decides if the data is inlined.
+	delete(opts.UserDefined, inlineDataKey)
+	if inline {
+		opts.UserDefined[inlineDataKey] = "true"
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
@@ -758,8 +799,13 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 
-	// Rename the successfully written temporary object to final location.
-	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaTmpBucket, tempObj, fi.DataDir, bucket, object, writeQuorum, nil); err != nil {
+	// Rename the successfully written temporary object to final location,
+	// inlined data has no data directory to rename.
+	dataDir := fi.DataDir
+	if inline {
+		dataDir = ""
+	}
+	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaTmpBucket, tempObj, dataDir, bucket, object, writeQuorum, nil); err != nil {
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 
@@ -970,6 +1016,8 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 				ObjectName:                    versions[objIndex].Name,
 				VersionPurgeStatus:            versions[objIndex].VersionPurgeStatus,
 				PurgeTransitioned:             objects[objIndex].PurgeTransitioned,
+				TransitionTier:                objects[objIndex].TransitionTier,
+				TransitionedObjName:           objects[objIndex].TransitionedObjName,
 			}
 		} else {
 			dobjects[objIndex] = DeletedObject{
@@ -978,6 +1026,8 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 				VersionPurgeStatus:            versions[objIndex].VersionPurgeStatus,
 				DeleteMarkerReplicationStatus: versions[objIndex].DeleteMarkerReplicationStatus,
 				PurgeTransitioned:             objects[objIndex].PurgeTransitioned,
+				TransitionTier:                objects[objIndex].TransitionTier,
+				TransitionedObjName:           objects[objIndex].TransitionedObjName,
 			}
 		}
 	}
@@ -1020,12 +1070,14 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 			return objInfo, gerr
 		}
 	}
-	// Acquire a write lock before deleting the object.
-	lk := er.NewNSLock(bucket, object)
-	if err = lk.GetLock(ctx, globalDeleteOperationTimeout); err != nil {
-		return ObjectInfo{}, err
+	if !opts.NoLock {
+		// Acquire a write lock before deleting the object.
+		lk := er.NewNSLock(bucket, object)
+		if err = lk.GetLock(ctx, globalDeleteOperationTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer lk.Unlock()
 	}
-	defer lk.Unlock()
 
 	storageDisks := er.getDisks()
 	writeQuorum := len(storageDisks)/2 + 1
@@ -1078,6 +1130,8 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 				}
 			}
 			fi.TransitionStatus = opts.TransitionStatus
+			fi.TransitionedObjName = opts.TransitionedObjName
+			fi.TransitionTier = opts.TransitionTier
 
 			// versioning suspended means we add `null`
 			// version as delete marker
@@ -1100,6 +1154,8 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 		DeleteMarkerReplicationStatus: opts.DeleteMarkerReplicationStatus,
 		VersionPurgeStatus:            opts.VersionPurgeStatus,
 		TransitionStatus:              opts.TransitionStatus,
+		TransitionedObjName:           opts.TransitionedObjName,
+		TransitionTier:                opts.TransitionTier,
 	}, opts.DeleteMarker); err != nil {
 		return objInfo, toObjectErr(err, bucket, object)
 	}
@@ -1121,6 +1177,40 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 	}, nil
 }
 
+// addDeleteMarker adds a delete marker as the latest version of the object,
+// also when this set has no version of it. The versions of an object on a
+// suspended pool are hidden by a delete marker added to another pool.
+func (er erasureObjects) addDeleteMarker(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		if err := lk.GetLock(ctx, globalDeleteOperationTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer lk.Unlock()
+	}
+
+	modTime := opts.MTime
+	if opts.MTime.IsZero() {
+		modTime = UTCNow()
+	}
+	fi := FileInfo{
+		Name:                          object,
+		Deleted:                       true,
+		MarkDeleted:                   true,
+		ModTime:                       modTime,
+		DeleteMarkerReplicationStatus: opts.DeleteMarkerReplicationStatus,
+		VersionPurgeStatus:            opts.VersionPurgeStatus,
+	}
+	if opts.Versioned {
+		fi.VersionID = mustGetUUID()
+	}
+	writeQuorum := len(er.getDisks())/2 + 1
+	if err := er.deleteObjectVersion(ctx, bucket, object, writeQuorum, fi, true); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	return fi.ToObjectInfo(bucket, object), nil
+}
+
 // Send the successful but partial upload/delete, however ignore
 // if the channel is blocked by other items.
 func (er erasureObjects) addPartial(bucket, object, versionID string) {
@@ -1273,3 +1363,88 @@ func (er erasureObjects) GetObjectTags(ctx context.Context, bucket, object strin
 
 	return tags.ParseObjectTags(oi.UserTags)
 }
+
+// getObjectVersions returns all the versions of the object, `xl.meta` is
+// read from all disks and the versions agreed on by read quorum are returned.
+func (er erasureObjects) getObjectVersions(ctx context.Context, bucket, object string) (FileInfoVersions, error) {
+	disks := er.getDisks()
+	fivs := make([]FileInfoVersions, len(disks))
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			buf, err := disks[index].ReadAll(ctx, bucket, pathJoin(object, xlStorageFormatFile))
+			if err != nil {
+				return err
+			}
+			fivs[index], err = getFileInfoVersions(buf, bucket, object)
+			return err
+		}, index)
+	}
+	errs := g.Wait()
+
+	readQuorum := len(disks) / 2
+	if err := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); err != nil {
+		return FileInfoVersions{}, toObjectErr(err, bucket, object)
+	}
+
+	// Disks agree on the versions when they have the same
+	// versions with the same modification times.
+	agreed := make(map[string]int, len(disks))
+	for index, fiv := range fivs {
+		if errs[index] != nil {
+			continue
+		}
+		var key strings.Builder
+		for _, version := range fiv.Versions {
+			key.WriteString(version.VersionID)
+			key.WriteString(strconv.FormatInt(version.ModTime.UnixNano(), 10))
+		}
+		agreed[key.String()]++
+		if agreed[key.String()] >= readQuorum {
+			return fiv, nil
+		}
+	}
+	return FileInfoVersions{}, toObjectErr(errErasureReadQuorum, bucket, object)
+}
+
+// putTransitionedVersion adds the version of a transitioned object, only its
+// metadata is written as its data is on the remote tier.
+func (er erasureObjects) putTransitionedVersion(ctx context.Context, bucket, object string, fi FileInfo) error {
+	disks := er.getDisks()
+
+	parityDrives := fi.Erasure.ParityBlocks
+	if parityDrives <= 0 || parityDrives > len(disks)/2 {
+		parityDrives = er.defaultParityCount
+	}
+	dataDrives := len(disks) - parityDrives
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum++
+	}
+
+	// The erasure layout of the set replaces the one of the set the
+	// version was written to.
+	tfi := newFileInfo(object, dataDrives, parityDrives)
+	tfi.VersionID = fi.VersionID
+	tfi.DataDir = fi.DataDir
+	tfi.ModTime = fi.ModTime
+	tfi.Size = fi.Size
+	tfi.Parts = fi.Parts
+	tfi.Metadata = cloneMSS(fi.Metadata)
+	tfi.TransitionStatus = fi.TransitionStatus
+	tfi.TransitionedObjName = fi.TransitionedObjName
+	tfi.TransitionTier = fi.TransitionTier
+
+	metaArr := make([]FileInfo, len(disks))
+	for index := range metaArr {
+		metaArr[index] = tfi
+	}
+	if _, err := writeUniqueFileInfo(ctx, disks, bucket, object, metaArr, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	return nil
+}
@@ -297,7 +297,8 @@ func TestGetObjectNoQuorum(t *testing.T) {
 	bucket := "bucket"
 	object := "object"
 	opts := ObjectOptions{}
-	buf := make([]byte, 129<<10)
+	// Large enough to not be inlined in xl.meta.
+	buf := make([]byte, smallFileThreshold*16)
 	if _, err = io.ReadFull(crand.Reader, buf); err != nil {
 		t.Fatal(err)
 	}
@@ -435,6 +436,118 @@ func TestHeadObjectNoQuorum(t *testing.T) {
 	}
 }
 
+func TestPutObjectInline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	disk := z.serverPools[0].sets[0].getDisks()[0]
+
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	putObject := func(data []byte, entries int) {
+		t.Helper()
+		oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := oi.UserDefined[inlineDataKey]; ok {
+			t.Fatal("expected inline data marker to be internal")
+		}
+		files, err := disk.ListDir(ctx, bucket, object, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) != entries {
+			t.Fatalf("expected %d entries, got %v", entries, files)
+		}
+	}
+
+	// Large objects have a data directory.
+	putObject(make([]byte, smallFileThreshold*16), 2)
+
+	// Small objects are inlined and replace the data directory.
+	data := bytes.Repeat([]byte("a"), 1024)
+	putObject(data, 1)
+
+	// Metadata updates keep the inlined data.
+	if _, err = obj.PutObjectTags(ctx, bucket, object, "key=value", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := disk.ReadVersion(ctx, bucket, object, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.InlineData() || len(fi.Data) == 0 {
+		t.Fatal("expected data to be inlined in xl.meta")
+	}
+	if fi, err = disk.ReadVersion(ctx, bucket, object, "", false); err != nil {
+		t.Fatal(err)
+	}
+	if len(fi.Data) != 0 {
+		t.Fatal("expected no data to be read")
+	}
+
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("unexpected object data")
+	}
+
+	// xl.meta with inlined data has a bumped minor version.
+	xlMeta, err := disk.ReadAll(ctx, bucket, pathJoin(object, xlStorageFormatFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(xlMeta[4:8], xlVersionV11[:]) {
+		t.Fatalf("expected xl.meta version %q, got %q", xlVersionV11[:], xlMeta[4:8])
+	}
+
+	// Versions are not inlined anymore once xl.meta exceeds its limit.
+	data = bytes.Repeat([]byte("b"), 64<<10)
+	for len(xlMeta) <= xlMetaInlineLimit {
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+		if xlMeta, err = disk.ReadAll(ctx, bucket, pathJoin(object, xlStorageFormatFile)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi, err = disk.ReadVersion(ctx, bucket, object, oi.VersionID, true); err != nil {
+		t.Fatal(err)
+	}
+	if fi.InlineData() {
+		t.Fatal("expected data not to be inlined once xl.meta exceeds its limit")
+	}
+	if err = disk.CheckParts(ctx, bucket, object, fi); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err = obj.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{VersionID: oi.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("unexpected object data")
+	}
+}
+
 func TestPutObjectNoQuorum(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
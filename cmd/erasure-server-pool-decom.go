@@ -0,0 +1,1010 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Status of the server pools is saved in .minio.sys/pool.json,
+	// it is not moved when a pool is decommissioned.
+	poolMetaName    = "pool.json"
+	poolMetaVersion = 1
+
+	// Interval at which the progress of a decommission is saved.
+	decommissionSaveInterval = 30 * time.Second
+)
+
+var (
+	errDecommissionAlreadyRunning = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission is already in progress",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionComplete = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission of the pool is already complete, remove the pool from the command line arguments",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionNotStarted = AdminError{
+		Code:       "XMinioDecommissionNotStarted",
+		Message:    "Decommission of the pool is not in progress",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionLastPool = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission needs at least one other pool which is not decommissioned",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionRebalanceRunning = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission is not allowed while the pools are rebalanced",
+		StatusCode: http.StatusBadRequest,
+	}
+	errPoolNotFound = AdminError{
+		Code:       "XMinioAdminPoolNotFound",
+		Message:    "Specified pool was not found, pools are identified by their command line argument",
+		StatusCode: http.StatusNotFound,
+	}
+)
+
+// poolDecommissionInfo is the saved progress of a pool decommission.
+type poolDecommissionInfo struct {
+	madmin.PoolDecommissionInfo
+
+	// Buckets and metadata prefixes left to decommission, the first one
+	// is walked again from the start when the decommission is resumed.
+	QueuedBuckets         []string `json:"queuedBuckets"`
+	DecommissionedBuckets []string `json:"decommissionedBuckets"`
+
+	// Objects overwritten on another pool while the pool was suspended
+	// are left on both pools until the pool is reconciled, after the
+	// decommission is canceled or failed.
+	Reconciling bool `json:"reconciling,omitempty"`
+}
+
+// poolStatus is the saved status of a server pool.
+type poolStatus struct {
+	ID           int                   `json:"id"`
+	CmdLine      string                `json:"cmdline"`
+	LastUpdate   time.Time             `json:"lastUpdate"`
+	Decommission *poolDecommissionInfo `json:"decommissionInfo,omitempty"`
+
+	// Drives marked offline to be replaced.
+	OfflineDrives []string `json:"offlineDrives,omitempty"`
+}
+
+// poolMeta is the saved status of all server pools.
+type poolMeta struct {
+	Version int          `json:"version"`
+	Pools   []poolStatus `json:"pools"`
+}
+
+// isSuspended returns true if the pool is not written to, while it
+// is being decommissioned and once it is decommissioned.
+func (p poolMeta) isSuspended(idx int) bool {
+	d := p.Pools[idx].Decommission
+	return d != nil && !d.Canceled && !d.Failed
+}
+
+// decommissionRunning returns true if the pool is being decommissioned.
+func (p poolMeta) decommissionRunning(idx int) bool {
+	d := p.Pools[idx].Decommission
+	return d != nil && !d.Complete && !d.Canceled && !d.Failed
+}
+
+// reconciling returns true if copies of objects left on the pool by a
+// canceled or failed decommission are not reconciled yet.
+func (p poolMeta) reconciling(idx int) bool {
+	d := p.Pools[idx].Decommission
+	return d != nil && d.Reconciling && (d.Canceled || d.Failed)
+}
+
+// toAdmin returns the status of the pool to report through madmin.
+func (p poolStatus) toAdmin() madmin.PoolStatus {
+	status := madmin.PoolStatus{
+		ID:            p.ID,
+		CmdLine:       p.CmdLine,
+		LastUpdate:    p.LastUpdate,
+		OfflineDrives: p.OfflineDrives,
+	}
+	if p.Decommission != nil {
+		info := p.Decommission.PoolDecommissionInfo
+		if len(p.Decommission.QueuedBuckets) > 0 {
+			info.Bucket = p.Decommission.QueuedBuckets[0]
+		}
+		status.Decommission = &info
+	}
+	return status
+}
+
+// newPoolMeta returns the status of the pools of the command line, none
+// of them being decommissioned.
+func newPoolMeta(endpointServerPools EndpointServerPools) poolMeta {
+	meta := poolMeta{
+		Version: poolMetaVersion,
+		Pools:   make([]poolStatus, len(endpointServerPools)),
+	}
+	for idx, ep := range endpointServerPools {
+		meta.Pools[idx] = poolStatus{
+			ID:         idx,
+			CmdLine:    ep.CmdLine,
+			LastUpdate: UTCNow(),
+		}
+	}
+	return meta
+}
+
+// readLatestConfig reads the latest config file of the pools, a config file
+// is written to another pool once its pool is suspended.
+func (z *erasureServerPools) readLatestConfig(ctx context.Context, configFile string) ([]byte, error) {
+	_, idx, err := z.getLatestObjectInfo(ctx, minioMetaBucket, configFile, ObjectOptions{})
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			return nil, errConfigNotFound
+		}
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err = z.serverPools[idx].GetObject(ctx, minioMetaBucket, configFile, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// loadPoolMeta reads the saved status of the pools.
+func (z *erasureServerPools) loadPoolMeta(ctx context.Context) (poolMeta, error) {
+	var meta poolMeta
+	data, err := z.readLatestConfig(ctx, poolMetaName)
+	if err != nil {
+		if err == errConfigNotFound {
+			return meta, nil
+		}
+		return meta, err
+	}
+
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return meta, err
+	}
+	if meta.Version != poolMetaVersion {
+		return meta, fmt.Errorf("unexpected pool.json version %d", meta.Version)
+	}
+	return meta, nil
+}
+
+// savePoolMeta saves the status of the pools.
+func (z *erasureServerPools) savePoolMeta(ctx context.Context) error {
+	z.poolMetaMutex.RLock()
+	data, err := json.Marshal(z.poolMeta)
+	z.poolMetaMutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, z, poolMetaName, data)
+}
+
+// Init validates the command line against the saved status of the pools, a
+// pool can only be removed from the command line once it is decommissioned.
+// Decommissioning is resumed on the server owning the first drive of a pool.
+func (z *erasureServerPools) Init(ctx context.Context) error {
+	saved, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	meta := z.poolMeta
+	for _, pool := range saved.Pools {
+		idx := -1
+		for i := range meta.Pools {
+			if meta.Pools[i].CmdLine == pool.CmdLine {
+				idx = i
+				break
+			}
+		}
+		if idx >= 0 {
+			meta.Pools[idx].LastUpdate = pool.LastUpdate
+			meta.Pools[idx].Decommission = pool.Decommission
+			meta.Pools[idx].OfflineDrives = pool.OfflineDrives
+			continue
+		}
+		switch {
+		case pool.Decommission == nil:
+			logger.LogIf(ctx, fmt.Errorf("pool %s is no longer in the command line arguments", pool.CmdLine))
+		case pool.Decommission.Complete:
+			logger.Info("Decommissioned pool %s was removed from the command line arguments", pool.CmdLine)
+		default:
+			z.poolMetaMutex.Unlock()
+			return fmt.Errorf("pool %s was removed from the command line arguments before it was decommissioned, add it back and wait for its decommission to complete", pool.CmdLine)
+		}
+	}
+	z.poolMeta = meta
+	z.poolMetaMutex.Unlock()
+
+	if err = z.savePoolMeta(ctx); err != nil {
+		return err
+	}
+
+	z.syncOfflineDrives()
+	z.syncDecommission()
+	return z.initRebalance(ctx)
+}
+
+// ReloadPoolMeta reloads the status of the pools saved by any server and
+// starts or stops decommissioning the pools owned by this server, as well
+// as rebalancing the pools. Drives marked offline are closed and replaced
+// drives are healed.
+func (z *erasureServerPools) ReloadPoolMeta(ctx context.Context) error {
+	meta, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	if len(meta.Pools) != len(z.poolMeta.Pools) {
+		z.poolMetaMutex.Unlock()
+		return fmt.Errorf("pool.json has %d pools, expected %d", len(meta.Pools), len(z.poolMeta.Pools))
+	}
+	z.poolMeta = meta
+	z.poolMetaMutex.Unlock()
+
+	z.syncOfflineDrives()
+	z.syncDecommission()
+	return z.reloadRebalance(ctx)
+}
+
+// IsSuspended returns true if the pool is not written to, while it is
+// decommissioned or rebalanced.
+func (z *erasureServerPools) IsSuspended(idx int) bool {
+	z.poolMetaMutex.RLock()
+	suspended := z.poolMeta.isSuspended(idx)
+	z.poolMetaMutex.RUnlock()
+	return suspended || z.IsRebalancing(idx)
+}
+
+// hasSuspendedPools returns true if any pool is not written to, the
+// versions of an object can then be spread across pools.
+func (z *erasureServerPools) hasSuspendedPools() bool {
+	for idx := range z.serverPools {
+		if z.IsSuspended(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSpreadObjects returns true if copies of an object can be on more than
+// one pool, while a pool is suspended or is reconciled after a decommission
+// or a rebalance.
+func (z *erasureServerPools) hasSpreadObjects() bool {
+	if z.hasSuspendedPools() || z.rebalanceReconciling() {
+		return true
+	}
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	for idx := range z.poolMeta.Pools {
+		if z.poolMeta.reconciling(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPoolIdxByCmdLine returns the index of the pool with the command line argument.
+func (z *erasureServerPools) GetPoolIdxByCmdLine(cmdLine string) (int, error) {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	for idx, pool := range z.poolMeta.Pools {
+		if pool.CmdLine == cmdLine {
+			return idx, nil
+		}
+	}
+	return -1, errPoolNotFound
+}
+
+// PoolStatus returns the status of the pool, the progress of a decommission
+// is read from the server running it.
+func (z *erasureServerPools) PoolStatus(ctx context.Context, idx int) (madmin.PoolStatus, error) {
+	z.poolMetaMutex.RLock()
+	status := z.poolMeta.Pools[idx].toAdmin()
+	running := z.poolMeta.decommissionRunning(idx)
+	owner := z.decommissionCancelers[idx] != nil
+	z.poolMetaMutex.RUnlock()
+
+	if running && !owner {
+		saved, err := z.loadPoolMeta(ctx)
+		if err != nil {
+			return madmin.PoolStatus{}, err
+		}
+		if len(saved.Pools) == len(z.serverPools) {
+			status = saved.Pools[idx].toAdmin()
+		}
+	}
+
+	if status.Decommission != nil {
+		status.Decommission.CurrentSize = poolUsedSize(z.serverPools[idx].StorageUsageInfo(ctx))
+	}
+	return status, nil
+}
+
+// poolUsedSize returns the space used on the drives of a pool.
+func poolUsedSize(info StorageInfo) (used int64) {
+	for _, disk := range info.Disks {
+		used += int64(disk.UsedSpace)
+	}
+	return used
+}
+
+// Decommission starts decommissioning the pool, the pool is not written to
+// anymore and its objects are moved to the remaining pools.
+func (z *erasureServerPools) Decommission(ctx context.Context, idx int) error {
+	if z.SinglePool() {
+		return errDecommissionLastPool
+	}
+	if z.rebalanceRunning() {
+		return errDecommissionRebalanceRunning
+	}
+
+	queued, err := z.decommissionQueue(ctx)
+	if err != nil {
+		return err
+	}
+
+	info := z.serverPools[idx].StorageUsageInfo(ctx)
+	var total int64
+	for _, disk := range info.Disks {
+		total += int64(disk.TotalSpace)
+	}
+	used := poolUsedSize(info)
+
+	z.poolMetaMutex.Lock()
+	if d := z.poolMeta.Pools[idx].Decommission; d != nil && !d.Canceled && !d.Failed {
+		z.poolMetaMutex.Unlock()
+		if d.Complete {
+			return errDecommissionComplete
+		}
+		return errDecommissionAlreadyRunning
+	}
+	active := 0
+	for i := range z.poolMeta.Pools {
+		if i != idx && !z.poolMeta.isSuspended(i) {
+			active++
+		}
+	}
+	if active == 0 {
+		z.poolMetaMutex.Unlock()
+		return errDecommissionLastPool
+	}
+	now := UTCNow()
+	z.poolMeta.Pools[idx].LastUpdate = now
+	z.poolMeta.Pools[idx].Decommission = &poolDecommissionInfo{
+		PoolDecommissionInfo: madmin.PoolDecommissionInfo{
+			StartTime:   now,
+			StartSize:   used,
+			TotalSize:   total,
+			CurrentSize: used,
+		},
+		QueuedBuckets: queued,
+	}
+	z.poolMetaMutex.Unlock()
+
+	if err = z.savePoolMeta(ctx); err != nil {
+		return err
+	}
+	z.syncDecommission()
+	return nil
+}
+
+// decommissionQueue returns the buckets and metadata prefixes to move out of
+// a pool, server config and bucket metadata are moved after all buckets.
+func (z *erasureServerPools) decommissionQueue(ctx context.Context) ([]string, error) {
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queued := make([]string, 0, len(buckets)+2)
+	for _, bucket := range buckets {
+		queued = append(queued, bucket.Name)
+	}
+	return append(queued,
+		pathJoin(minioMetaBucket, minioConfigPrefix),
+		pathJoin(minioMetaBucket, bucketMetaPrefix)), nil
+}
+
+// DecommissionCancel cancels decommissioning the pool, the pool is written
+// to again. Objects already moved are not moved back, the objects left on
+// the pool which were overwritten on another pool meanwhile are reconciled.
+func (z *erasureServerPools) DecommissionCancel(ctx context.Context, idx int) error {
+	z.poolMetaMutex.Lock()
+	if !z.poolMeta.decommissionRunning(idx) {
+		z.poolMetaMutex.Unlock()
+		return errDecommissionNotStarted
+	}
+	z.poolMeta.Pools[idx].Decommission.Canceled = true
+	z.poolMeta.Pools[idx].Decommission.Reconciling = true
+	z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+	z.poolMetaMutex.Unlock()
+
+	if err := z.savePoolMeta(ctx); err != nil {
+		return err
+	}
+	z.syncDecommission()
+	return nil
+}
+
+// syncDecommission starts decommissioning the pools owned by this server
+// which are being decommissioned and stops the ones which are canceled, the
+// pools left to reconcile are reconciled the same way. A pool is owned by the
+// server with its first drive.
+func (z *erasureServerPools) syncDecommission() {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	for idx := range z.serverPools {
+		owner := z.serverPools[idx].endpoints[0].IsLocal
+
+		running := z.poolMeta.decommissionRunning(idx)
+		cancel := z.decommissionCancelers[idx]
+		switch {
+		case running && cancel == nil && owner:
+			ctx, cancel := context.WithCancel(GlobalContext)
+			z.decommissionCancelers[idx] = cancel
+			go z.decommissionPool(ctx, idx)
+		case !running && cancel != nil:
+			cancel()
+			z.decommissionCancelers[idx] = nil
+		}
+
+		reconciling := z.poolMeta.reconciling(idx)
+		cancel = z.reconcileCancelers[idx]
+		switch {
+		case reconciling && cancel == nil && owner:
+			ctx, cancel := context.WithCancel(GlobalContext)
+			z.reconcileCancelers[idx] = cancel
+			go z.reconcileDecommission(ctx, idx)
+		case !reconciling && cancel != nil:
+			cancel()
+			z.reconcileCancelers[idx] = nil
+		}
+	}
+}
+
+// decommissionPool moves the objects of the queued buckets to the remaining
+// pools, it is resumed from the first queued bucket after a restart.
+func (z *erasureServerPools) decommissionPool(ctx context.Context, idx int) {
+	for {
+		z.poolMetaMutex.RLock()
+		d := z.poolMeta.Pools[idx].Decommission
+		var queued string
+		if len(d.QueuedBuckets) > 0 {
+			queued = d.QueuedBuckets[0]
+		}
+		z.poolMetaMutex.RUnlock()
+		if queued == "" {
+			break
+		}
+
+		bucket, prefix := path2BucketObject(queued)
+		if err := z.decommissionBucket(ctx, idx, bucket, prefix); err != nil {
+			if ctx.Err() != nil {
+				// Decommission was canceled.
+				return
+			}
+			logger.LogIf(ctx, fmt.Errorf("Unable to decommission %s: %w", queued, err))
+			z.decommissionDone(idx, true)
+			return
+		}
+
+		z.poolMetaMutex.Lock()
+		d = z.poolMeta.Pools[idx].Decommission
+		d.QueuedBuckets = d.QueuedBuckets[1:]
+		d.DecommissionedBuckets = append(d.DecommissionedBuckets, queued)
+		z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+		z.poolMetaMutex.Unlock()
+		logger.LogIf(ctx, z.savePoolMeta(ctx))
+	}
+
+	if err := z.drainPool(ctx, idx); err != nil {
+		if ctx.Err() != nil {
+			// Decommission was canceled.
+			return
+		}
+		logger.LogIf(ctx, fmt.Errorf("Unable to drain pool: %w", err))
+		z.decommissionDone(idx, true)
+		return
+	}
+
+	z.poolMetaMutex.RLock()
+	failed := z.poolMeta.Pools[idx].Decommission.ObjectsDecommissionFailed > 0
+	z.poolMetaMutex.RUnlock()
+	z.decommissionDone(idx, failed)
+}
+
+// drainPool aborts the pending multipart uploads of the pool, they are not
+// completed on the pool once it is decommissioned. The pool is then walked
+// again for the objects written to it while its buckets were walked.
+func (z *erasureServerPools) drainPool(ctx context.Context, idx int) error {
+	for _, set := range z.serverPools[idx].sets {
+		if err := set.abortMultipartUploads(ctx); err != nil {
+			return err
+		}
+	}
+
+	queued, err := z.decommissionQueue(ctx)
+	if err != nil {
+		return err
+	}
+	for _, q := range queued {
+		bucket, prefix := path2BucketObject(q)
+		if err = z.decommissionBucket(ctx, idx, bucket, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDecommissioned returns true if the pool is being decommissioned or was
+// decommissioned, unlike a rebalanced pool its uploads are not completed.
+func (z *erasureServerPools) isDecommissioned(idx int) bool {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	return z.poolMeta.isSuspended(idx)
+}
+
+// decommissionMultipartErr aborts an upload found on a decommissioned pool
+// and returns the error to reply with, the upload has to be started again
+// on another pool.
+func (z *erasureServerPools) decommissionMultipartErr(ctx context.Context, idx int, bucket, object, uploadID string) error {
+	if err := z.serverPools[idx].AbortMultipartUpload(ctx, bucket, object, uploadID, ObjectOptions{}); err != nil {
+		if _, ok := err.(InvalidUploadID); !ok {
+			logger.LogIf(ctx, err)
+		}
+	}
+	return InvalidUploadID{
+		Bucket:   bucket,
+		Object:   object,
+		UploadID: uploadID,
+	}
+}
+
+// decommissionDone marks the decommission of the pool as complete, or as
+// failed if objects were left on the pool.
+func (z *erasureServerPools) decommissionDone(idx int, failed bool) {
+	z.poolMetaMutex.Lock()
+	d := z.poolMeta.Pools[idx].Decommission
+	d.Complete = !failed
+	d.Failed = failed
+	d.Reconciling = failed
+	z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+	z.decommissionCancelers[idx] = nil
+	cmdLine := z.poolMeta.Pools[idx].CmdLine
+	z.poolMetaMutex.Unlock()
+
+	ctx := GlobalContext
+	logger.LogIf(ctx, z.savePoolMeta(ctx))
+	for _, nerr := range globalNotificationSys.ReloadPoolMeta(ctx) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	if failed {
+		z.syncDecommission()
+		logger.Info("Decommission of pool %s failed, objects were left on the pool", cmdLine)
+		return
+	}
+	logger.Info("Decommission of pool %s is complete, it can be removed from the command line arguments", cmdLine)
+}
+
+// decommissionProgress records a moved or failed object version, the
+// progress is saved at most every decommissionSaveInterval.
+func (z *erasureServerPools) decommissionProgress(ctx context.Context, idx int, size int64, failed bool) {
+	z.poolMetaMutex.Lock()
+	d := z.poolMeta.Pools[idx].Decommission
+	if failed {
+		d.ObjectsDecommissionFailed++
+		d.BytesFailed += size
+	} else {
+		d.ObjectsDecommissioned++
+		d.BytesDone += size
+	}
+	save := time.Since(z.poolMeta.Pools[idx].LastUpdate) > decommissionSaveInterval
+	if save {
+		z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+	}
+	z.poolMetaMutex.Unlock()
+
+	if save {
+		logger.LogIf(ctx, z.savePoolMeta(ctx))
+	}
+}
+
+// isMetacacheEntry returns true for the listing caches, they are not moved
+// as they are created again.
+func isMetacacheEntry(bucket string, entry metaCacheEntry) bool {
+	return bucket == minioMetaBucket && strings.Contains(entry.name, SlashSeparator+metacachePrefix+SlashSeparator)
+}
+
+// decommissionBucket moves the objects under the prefix of the bucket on
+// every erasure set of the pool.
+func (z *erasureServerPools) decommissionBucket(ctx context.Context, idx int, bucket, prefix string) error {
+	return z.listPoolBucket(ctx, idx, bucket, prefix, func(set *erasureObjects, entry metaCacheEntry) {
+		if isMetacacheEntry(bucket, entry) {
+			return
+		}
+		z.moveEntry(ctx, idx, set, bucket, entry, false, func(size int64, failed bool) {
+			z.decommissionProgress(ctx, idx, size, failed)
+		})
+	})
+}
+
+// reconcilePool moves the objects left on the pool which are also on
+// another pool written to, to that pool. The latest of the copies of every
+// version is kept.
+func (z *erasureServerPools) reconcilePool(ctx context.Context, idx int) error {
+	queued, err := z.decommissionQueue(ctx)
+	if err != nil {
+		return err
+	}
+	var failed int64
+	for _, q := range queued {
+		bucket, prefix := path2BucketObject(q)
+		err = z.listPoolBucket(ctx, idx, bucket, prefix, func(set *erasureObjects, entry metaCacheEntry) {
+			if isMetacacheEntry(bucket, entry) {
+				return
+			}
+			z.moveEntry(ctx, idx, set, bucket, entry, true, func(size int64, moveFailed bool) {
+				if moveFailed {
+					failed++
+				}
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d object versions were not moved", failed)
+	}
+	return nil
+}
+
+// reconcileDecommission reconciles the pool after its decommission was
+// canceled or failed, it is reconciled again after a restart until it
+// succeeds.
+func (z *erasureServerPools) reconcileDecommission(ctx context.Context, idx int) {
+	err := z.reconcilePool(ctx, idx)
+	if ctx.Err() != nil {
+		// Pool is decommissioned again.
+		return
+	}
+
+	z.poolMetaMutex.Lock()
+	z.reconcileCancelers[idx] = nil
+	cmdLine := z.poolMeta.Pools[idx].CmdLine
+	if err == nil {
+		z.poolMeta.Pools[idx].Decommission.Reconciling = false
+		z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+	}
+	z.poolMetaMutex.Unlock()
+
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to reconcile pool %s, it is reconciled again after a restart: %w", cmdLine, err))
+		return
+	}
+
+	logger.LogIf(ctx, z.savePoolMeta(ctx))
+	for _, nerr := range globalNotificationSys.ReloadPoolMeta(ctx) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	logger.Info("Pool %s is reconciled", cmdLine)
+}
+
+// listPoolBucket lists the objects under the prefix of the bucket on every
+// erasure set of the pool.
+func (z *erasureServerPools) listPoolBucket(ctx context.Context, idx int, bucket, prefix string, fn func(set *erasureObjects, entry metaCacheEntry)) error {
+	for _, set := range z.serverPools[idx].sets {
+		set := set
+		disks := set.getLoadBalancedDisks(true)
+		if len(disks) == 0 {
+			return errDiskNotFound
+		}
+
+		err := listPathRaw(ctx, listPathRawOptions{
+			disks:     disks,
+			bucket:    bucket,
+			path:      prefix,
+			recursive: true,
+			minDisks:  len(disks) / 2,
+			agreed: func(entry metaCacheEntry) {
+				fn(set, entry)
+			},
+			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+				if entry, _ := entries.firstFound(); entry != nil {
+					fn(set, *entry)
+				}
+			},
+		})
+		if err != nil && !errors.Is(err, errVolumeNotFound) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// lockObjectInPools acquires the write lock of the object on every pool,
+// the lock of an object is held by the erasure set of every pool it hashes
+// to. The returned function releases all of them.
+func (z *erasureServerPools) lockObjectInPools(ctx context.Context, bucket, object string) (func(), error) {
+	locks := make([]RWLocker, 0, len(z.serverPools))
+	unlock := func() {
+		for _, lk := range locks {
+			lk.Unlock()
+		}
+	}
+	for _, pool := range z.serverPools {
+		lk := pool.NewNSLock(bucket, object)
+		if err := lk.GetLock(ctx, globalOperationTimeout); err != nil {
+			unlock()
+			return nil, err
+		}
+		locks = append(locks, lk)
+	}
+	return unlock, nil
+}
+
+// getMoveTargetIdx returns the pool to move the object out of the pool idx
+// to, the pool written to which already has the object or else the pool with
+// the most available space. -1 is returned if the object is on no other pool
+// and duplicatesOnly is set. The caller holds the lock of the object.
+func (z *erasureServerPools) getMoveTargetIdx(ctx context.Context, idx int, bucket, object string, size int64, duplicatesOnly bool) (int, error) {
+	for i, pool := range z.serverPools {
+		if i == idx || z.IsSuspended(i) {
+			continue
+		}
+		objInfo, err := pool.GetObjectInfo(ctx, bucket, object, ObjectOptions{NoLock: true})
+		if err == nil || objInfo.DeleteMarker {
+			return i, nil
+		}
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return -1, err
+		}
+	}
+	if duplicatesOnly {
+		return -1, nil
+	}
+
+	// We multiply the size by 2 to account for erasure coding.
+	i := z.getAvailablePoolIdx(ctx, size*2)
+	if i < 0 || i == idx {
+		return -1, toObjectErr(errDiskFull)
+	}
+	return i, nil
+}
+
+// moveEntry moves all versions of the object out of the pool idx and
+// removes the object from the set once all of them are moved, progress is
+// reported for every version. The object is locked on every pool while it
+// is moved. With duplicatesOnly the object is only moved if another pool
+// written to has it too.
+func (z *erasureServerPools) moveEntry(ctx context.Context, idx int, set *erasureObjects, bucket string, entry metaCacheEntry, duplicatesOnly bool, progress func(size int64, failed bool)) {
+	if ctx.Err() != nil || entry.isDir() {
+		return
+	}
+
+	unlock, err := z.lockObjectInPools(ctx, bucket, entry.name)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to move %s/%s: %w", bucket, entry.name, err))
+			progress(0, true)
+		}
+		return
+	}
+	defer unlock()
+
+	// The versions are read again, the object could have changed since
+	// it was listed.
+	fivs, err := set.getObjectVersions(ctx, bucket, entry.name)
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			// Deleted since it was listed.
+			return
+		}
+		logger.LogIf(ctx, fmt.Errorf("Unable to move %s/%s: %w", bucket, entry.name, err))
+		progress(0, true)
+		return
+	}
+
+	var size int64
+	for _, version := range fivs.Versions {
+		size += version.Size
+	}
+	targetIdx, err := z.getMoveTargetIdx(ctx, idx, bucket, entry.name, size, duplicatesOnly)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to move %s/%s: %w", bucket, entry.name, err))
+		progress(size, true)
+		return
+	}
+	if targetIdx < 0 {
+		return
+	}
+	target := z.serverPools[targetIdx]
+
+	// Versions are moved oldest first, to keep the latest one last.
+	versions := fivs.Versions
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ModTime.Before(versions[j].ModTime)
+	})
+
+	failed := false
+	for _, version := range versions {
+		err = z.moveObjectVersion(ctx, set, target, bucket, version)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.LogIf(ctx, fmt.Errorf("Unable to move %s/%s (%s): %w", bucket, version.Name, version.VersionID, err))
+			failed = true
+		}
+		progress(version.Size, err != nil)
+	}
+	if failed {
+		return
+	}
+
+	writeQuorum := len(set.getDisks())/2 + 1
+	if err = set.deleteObject(ctx, bucket, entry.name, writeQuorum); err != nil && !isErrObjectNotFound(err) {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// moveObjectVersion writes the object version to the target pool with its
+// version ID, modification time and metadata, unless the target already has
+// the version with the same or a later modification time. The data is copied
+// as stored, encrypted and compressed objects are not transformed and
+// multipart objects keep their parts. Only the metadata of transitioned
+// versions is written, their data stays on the remote tier. The caller holds
+// the lock of the object on every pool.
+func (z *erasureServerPools) moveObjectVersion(ctx context.Context, set *erasureObjects, target *erasureSets, bucket string, fi FileInfo) error {
+	versionID := fi.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+
+	// An object overwritten on the target pool is newer than the copy
+	// left on the pool, the copy is not moved.
+	oi, err := target.GetObjectInfo(ctx, bucket, fi.Name, ObjectOptions{VersionID: versionID, NoLock: true})
+	if err != nil && oi.Name == "" && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+		return err
+	}
+	if oi.Name != "" && !oi.ModTime.Before(fi.ModTime) {
+		return nil
+	}
+
+	if fi.Deleted {
+		_, err = target.DeleteObject(ctx, bucket, fi.Name, ObjectOptions{
+			Versioned:                     fi.VersionID != "",
+			VersionSuspended:              fi.VersionID == "",
+			VersionID:                     fi.VersionID,
+			MTime:                         fi.ModTime,
+			DeleteMarker:                  true,
+			DeleteMarkerReplicationStatus: fi.DeleteMarkerReplicationStatus,
+			VersionPurgeStatus:            fi.VersionPurgeStatus,
+			NoLock:                        true,
+		})
+		return err
+	}
+
+	if fi.TransitionStatus == lifecycle.TransitionComplete {
+		return target.getHashedSet(fi.Name).putTransitionedVersion(ctx, bucket, fi.Name, fi)
+	}
+
+	// readPart reads the stored bytes of the object version.
+	readPart := func(offset int64, part ObjectPartInfo) (*PutObjReader, *io.PipeReader, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			err := set.GetObject(ctx, bucket, fi.Name, offset, part.Size, pw, "", ObjectOptions{VersionID: versionID, NoLock: true})
+			pw.CloseWithError(err)
+		}()
+		hr, err := hash.NewReader(pr, part.Size, "", "", part.ActualSize, false)
+		if err != nil {
+			pr.Close()
+			return nil, nil, err
+		}
+		return NewPutObjReader(hr, nil, nil), pr, nil
+	}
+
+	opts := ObjectOptions{
+		Versioned:   fi.VersionID != "",
+		VersionID:   fi.VersionID,
+		MTime:       fi.ModTime,
+		UserDefined: cloneMSS(fi.Metadata),
+		NoLock:      true,
+	}
+
+	if len(fi.Parts) <= 1 {
+		part := ObjectPartInfo{Size: fi.Size, ActualSize: fi.Size}
+		if len(fi.Parts) == 1 {
+			part = fi.Parts[0]
+		}
+		data, pr, err := readPart(0, part)
+		if err != nil {
+			return err
+		}
+		opts.IndexCB = func() []byte { return part.Index }
+		_, err = target.PutObject(ctx, bucket, fi.Name, data, opts)
+		pr.CloseWithError(err)
+		return err
+	}
+
+	uploadID, err := target.NewMultipartUpload(ctx, bucket, fi.Name, ObjectOptions{
+		Versioned:   opts.Versioned,
+		VersionID:   opts.VersionID,
+		UserDefined: cloneMSS(fi.Metadata),
+	})
+	if err != nil {
+		return err
+	}
+	parts := make([]CompletePart, len(fi.Parts))
+	var offset int64
+	for i, part := range fi.Parts {
+		data, pr, err := readPart(offset, part)
+		if err != nil {
+			target.AbortMultipartUpload(ctx, bucket, fi.Name, uploadID, ObjectOptions{})
+			return err
+		}
+		index, checksum := part.Index, hash.ParseChecksum(part.Checksum)
+		pi, err := target.PutObjectPart(ctx, bucket, fi.Name, uploadID, part.Number, data, ObjectOptions{
+			IndexCB:    func() []byte { return index },
+			ChecksumCB: func() *hash.Checksum { return checksum },
+		})
+		pr.CloseWithError(err)
+		if err != nil {
+			target.AbortMultipartUpload(ctx, bucket, fi.Name, uploadID, ObjectOptions{})
+			return err
+		}
+		parts[i] = CompletePart{PartNumber: part.Number, ETag: pi.ETag}
+		offset += part.Size
+	}
+	_, err = target.CompleteMultipartUpload(ctx, bucket, fi.Name, uploadID, parts, opts)
+	if err != nil {
+		target.AbortMultipartUpload(ctx, bucket, fi.Name, uploadID, ObjectOptions{})
+	}
+	return err
+}
@@ -0,0 +1,380 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDecommissionPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	if err := z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := map[string]string{}
+	for _, object := range []string{"object1", "dir/object2"} {
+		data := bytes.Repeat([]byte(object), 1024)
+		oi, err := z.serverPools[0].PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects[object] = oi.ETag
+	}
+
+	if err := z.DecommissionCancel(ctx, 0); err != errDecommissionNotStarted {
+		t.Fatalf("expected %v, got %v", errDecommissionNotStarted, err)
+	}
+
+	if err := z.Decommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) {
+		t.Fatal("expected pool being decommissioned to be suspended")
+	}
+	if err := z.Decommission(ctx, 0); err != errDecommissionAlreadyRunning && err != errDecommissionComplete {
+		t.Fatalf("expected decommission to be running, got %v", err)
+	}
+	if err := z.Decommission(ctx, 1); err != errDecommissionLastPool {
+		t.Fatalf("expected %v, got %v", errDecommissionLastPool, err)
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		status, err := z.PoolStatus(ctx, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Decommission.Failed {
+			t.Fatal("decommission failed")
+		}
+		if status.Decommission.Complete {
+			if status.Decommission.ObjectsDecommissioned < int64(len(objects)) {
+				t.Fatalf("expected at least %d objects decommissioned, got %d", len(objects), status.Decommission.ObjectsDecommissioned)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for decommission to complete")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	for object, etag := range objects {
+		if _, err := z.serverPools[0].GetObjectInfo(ctx, "bucket", object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("expected %s to be removed from the decommissioned pool, got %v", object, err)
+		}
+		oi, err := z.serverPools[1].GetObjectInfo(ctx, "bucket", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.ETag != etag {
+			t.Fatalf("expected ETag %s of %s, got %s", etag, object, oi.ETag)
+		}
+	}
+
+	// New objects are not written to the decommissioned pool.
+	data := []byte("new object")
+	if _, err := z.PutObject(ctx, "bucket", "object3", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := z.serverPools[1].GetObjectInfo(ctx, "bucket", "object3", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The saved status survives a restart.
+	if err := z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) {
+		t.Fatal("expected decommissioned pool to remain suspended")
+	}
+}
+
+func TestReconcilePool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	if err := z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An object overwritten on the second pool while the first pool was
+	// being decommissioned, the first pool keeps the older copy.
+	var etag string
+	for idx, data := range [][]byte{[]byte("older copy"), []byte("newer copy")} {
+		oi, err := z.serverPools[idx].PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		etag = oi.ETag
+	}
+
+	z.poolMetaMutex.Lock()
+	z.poolMeta.Pools[0].Decommission = &poolDecommissionInfo{Reconciling: true}
+	z.poolMeta.Pools[0].Decommission.Canceled = true
+	z.poolMetaMutex.Unlock()
+
+	oi, err := z.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.ETag != etag {
+		t.Fatalf("expected the newer copy with ETag %s to be read, got %s", etag, oi.ETag)
+	}
+
+	z.reconcileDecommission(ctx, 0)
+
+	if _, err = z.serverPools[0].GetObjectInfo(ctx, "bucket", "object", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected the older copy to be removed, got %v", err)
+	}
+	if oi, err = z.serverPools[1].GetObjectInfo(ctx, "bucket", "object", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if oi.ETag != etag {
+		t.Fatalf("expected the newer copy with ETag %s to be kept, got %s", etag, oi.ETag)
+	}
+	if z.hasSpreadObjects() {
+		t.Fatal("expected the pool to be reconciled")
+	}
+}
+
+func TestPoolMetaInit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	z.poolMetaMutex.RLock()
+	meta := poolMeta{
+		Version: poolMetaVersion,
+		Pools:   append([]poolStatus{}, z.poolMeta.Pools...),
+	}
+	z.poolMetaMutex.RUnlock()
+
+	// A pool removed while it is being decommissioned.
+	meta.Pools = append(meta.Pools, poolStatus{
+		ID:           2,
+		CmdLine:      "pool3",
+		Decommission: &poolDecommissionInfo{},
+	})
+	data, err := json.Marshal(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, z, poolMetaName, data); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.Init(ctx); err == nil {
+		t.Fatal("expected pool removed before being decommissioned to be rejected")
+	}
+
+	// A pool removed once it is decommissioned.
+	meta.Pools[2].Decommission.Complete = true
+	if data, err = json.Marshal(meta); err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, z, poolMetaName, data); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Pools) != 2 {
+		t.Fatalf("expected removed pool to be dropped, got %d pools", len(saved.Pools))
+	}
+}
+
+func TestDecommissionMultipartUploads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	if err := z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	uploadIDs := make([]string, 3)
+	for i := range uploadIDs {
+		uploadID, err := z.serverPools[0].NewMultipartUpload(ctx, "bucket", "object", ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploadIDs[i] = uploadID
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+	part, err := z.PutObjectPart(ctx, "bucket", "object", uploadIDs[1], 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An object written to the pool while its buckets were walked.
+	if _, err = z.serverPools[0].PutObject(ctx, "bucket", "late", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	z.poolMetaMutex.Lock()
+	z.poolMeta.Pools[0].Decommission = &poolDecommissionInfo{}
+	z.poolMetaMutex.Unlock()
+
+	// Uploads of the pool being decommissioned are not continued on it.
+	_, err = z.PutObjectPart(ctx, "bucket", "object", uploadIDs[0], 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if _, ok := err.(InvalidUploadID); !ok {
+		t.Fatalf("expected InvalidUploadID for a part of an upload of the pool, got %v", err)
+	}
+	_, err = z.CompleteMultipartUpload(ctx, "bucket", "object", uploadIDs[1], []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{})
+	if _, ok := err.(InvalidUploadID); !ok {
+		t.Fatalf("expected InvalidUploadID completing an upload of the pool, got %v", err)
+	}
+	for _, uploadID := range uploadIDs[:2] {
+		if _, err = z.serverPools[0].GetMultipartInfo(ctx, "bucket", "object", uploadID, ObjectOptions{}); err == nil {
+			t.Fatalf("expected upload %s to be aborted", uploadID)
+		}
+	}
+
+	if err = z.drainPool(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.serverPools[0].GetMultipartInfo(ctx, "bucket", "object", uploadIDs[2], ObjectOptions{}); err == nil {
+		t.Fatalf("expected upload %s to be aborted", uploadIDs[2])
+	}
+	if _, err = z.serverPools[0].GetObjectInfo(ctx, "bucket", "late", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected late object to be moved off the pool, got %v", err)
+	}
+	if _, err = z.serverPools[1].GetObjectInfo(ctx, "bucket", "late", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecommissionDeleteObjects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	if err := z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("object")
+	var oi ObjectInfo
+	for _, object := range []string{"object", "object2"} {
+		var err error
+		oi, err = z.serverPools[0].PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	z.poolMetaMutex.Lock()
+	z.poolMeta.Pools[0].Decommission = &poolDecommissionInfo{}
+	z.poolMetaMutex.Unlock()
+
+	dobjects, errs := z.DeleteObjects(ctx, "bucket", []ObjectToDelete{{ObjectName: "object"}}, ObjectOptions{Versioned: true})
+	if errs[0] != nil {
+		t.Fatal(errs[0])
+	}
+	if !dobjects[0].DeleteMarker {
+		t.Fatal("expected a delete marker to be added")
+	}
+	if dm, err := z.DeleteObject(ctx, "bucket", "object2", ObjectOptions{Versioned: true}); err != nil || !dm.DeleteMarker {
+		t.Fatalf("expected a delete marker to be added, got %v", err)
+	}
+
+	// The delete markers are added to the pool written to, the versions on
+	// the suspended pool are left to be moved.
+	if _, err := z.serverPools[0].GetObjectInfo(ctx, "bucket", "object2", ObjectOptions{VersionID: oi.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"object", "object2"} {
+		if _, err := z.serverPools[0].GetObjectInfo(ctx, "bucket", object, ObjectOptions{}); err != nil {
+			t.Fatalf("expected no delete marker for %s on the suspended pool, got %v", object, err)
+		}
+		dm, err := z.serverPools[1].GetObjectInfo(ctx, "bucket", object, ObjectOptions{})
+		if !dm.DeleteMarker {
+			t.Fatalf("expected the delete marker for %s on the pool written to, got %v", object, err)
+		}
+		if _, err = z.GetObjectInfo(ctx, "bucket", object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("expected %s to be deleted, got %v", object, err)
+		}
+	}
+}
+
+func TestSpreadObjectTags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	if err := z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The older copy is left on the first pool, it is not reconciled yet.
+	for idx, data := range [][]byte{[]byte("older copy"), []byte("newer copy")} {
+		if _, err := z.serverPools[idx].PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	z.poolMetaMutex.Lock()
+	z.poolMeta.Pools[0].Decommission = &poolDecommissionInfo{Reconciling: true}
+	z.poolMeta.Pools[0].Decommission.Canceled = true
+	z.poolMetaMutex.Unlock()
+
+	if _, err := z.PutObjectTags(ctx, "bucket", "object", "key=value", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if tags, err := z.serverPools[0].GetObjectTags(ctx, "bucket", "object", ObjectOptions{}); err != nil || tags.String() != "" {
+		t.Fatalf("expected the older copy not to be tagged, got %v %v", tags, err)
+	}
+	tags, err := z.GetObjectTags(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.String() != "key=value" {
+		t.Fatalf("expected the tags of the newer copy, got %s", tags)
+	}
+
+	if _, err = z.DeleteObjectTags(ctx, "bucket", "object", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if tags, err = z.GetObjectTags(ctx, "bucket", "object", ObjectOptions{}); err != nil || tags.String() != "" {
+		t.Fatalf("expected the tags to be removed, got %v %v", tags, err)
+	}
+}
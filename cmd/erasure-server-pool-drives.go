@@ -0,0 +1,200 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+)
+
+var (
+	errDriveNotFound = AdminError{
+		Code:       "XMinioAdminDriveNotFound",
+		Message:    "Specified drive was not found, drives are identified by their endpoint",
+		StatusCode: http.StatusNotFound,
+	}
+	errDriveNotOffline = AdminError{
+		Code:       "XMinioAdminDriveNotOffline",
+		Message:    "Specified drive is not marked offline, mark the drive offline before replacing it",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDriveOfflineQuorum = AdminError{
+		Code:       "XMinioAdminDriveOfflineNotAllowed",
+		Message:    "Marking the drive offline would leave its erasure set without write quorum",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// findDrive returns the pool and the erasure set of the drive endpoint,
+// the drives of a set are consecutive on the command line.
+func (z *erasureServerPools) findDrive(drive string) (poolIdx, setIdx int, err error) {
+	for poolIdx, pool := range z.serverPools {
+		for i, endpoint := range pool.endpoints {
+			if endpoint.String() == drive {
+				return poolIdx, i / pool.setDriveCount, nil
+			}
+		}
+	}
+	return -1, -1, errDriveNotFound
+}
+
+// isDriveOffline returns true if the drive was marked offline through the admin API.
+func (z *erasureServerPools) isDriveOffline(endpoint Endpoint) bool {
+	for _, pool := range z.serverPools {
+		if pool.isDriveOffline(endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// countOfflineDisks returns the number of drives which are not connected.
+func countOfflineDisks(disks []StorageAPI) (n int) {
+	for _, disk := range disks {
+		if disk == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// minParityCount returns the lowest parity objects of the pool may be
+// written with, depending on their storage class.
+func minParityCount(pool *erasureSets) int {
+	parity := pool.defaultParityCount
+	for _, sc := range []string{storageclass.STANDARD, storageclass.RRS} {
+		if p := globalStorageClass.GetParityForSC(sc); p > 0 && p < parity {
+			parity = p
+		}
+	}
+	return parity
+}
+
+// OfflineDrive marks the drive offline so that it can be replaced, the drive is
+// treated as missing by its erasure set, which keeps serving objects as long as
+// enough drives are online for its write quorum.
+func (z *erasureServerPools) OfflineDrive(ctx context.Context, drive string) error {
+	poolIdx, setIdx, err := z.findDrive(drive)
+	if err != nil {
+		return err
+	}
+	pool := z.serverPools[poolIdx]
+
+	// Drives which are not reachable, failing or being
+	// healed are offline as well.
+	online := make(map[string]bool)
+	for _, disk := range pool.sets[setIdx].getOnlineDisks() {
+		online[disk.Endpoint().String()] = true
+	}
+
+	z.poolMetaMutex.Lock()
+	for _, d := range z.poolMeta.Pools[poolIdx].OfflineDrives {
+		if d == drive {
+			// Already marked offline.
+			z.poolMetaMutex.Unlock()
+			return nil
+		}
+		delete(online, d)
+	}
+	disks := make([]StorageAPI, pool.setDriveCount)
+	for i, disk := range pool.sets[setIdx].getDisks() {
+		if disk == nil || !online[disk.Endpoint().String()] || disk.Endpoint().String() == drive {
+			continue
+		}
+		disks[i] = disk
+	}
+	// Objects with the lowest parity must keep their write quorum, which
+	// has one more drive than data drives when there are as many data
+	// drives as parity drives.
+	maxOffline := minParityCount(pool)
+	if pool.setDriveCount-maxOffline == maxOffline {
+		maxOffline--
+	}
+	if countOfflineDisks(disks) > maxOffline {
+		z.poolMetaMutex.Unlock()
+		return errDriveOfflineQuorum
+	}
+	z.poolMeta.Pools[poolIdx].OfflineDrives = append(z.poolMeta.Pools[poolIdx].OfflineDrives, drive)
+	z.poolMeta.Pools[poolIdx].LastUpdate = UTCNow()
+	z.poolMetaMutex.Unlock()
+
+	if err = z.savePoolMeta(ctx); err != nil {
+		return err
+	}
+	z.syncOfflineDrives()
+	return nil
+}
+
+// ReplaceDrive removes the offline mark of a drive once it was swapped, the
+// server hosting the drive heals its erasure set onto it right away.
+func (z *erasureServerPools) ReplaceDrive(ctx context.Context, drive string) error {
+	poolIdx, _, err := z.findDrive(drive)
+	if err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	drives := z.poolMeta.Pools[poolIdx].OfflineDrives
+	found := false
+	for i, d := range drives {
+		if d == drive {
+			z.poolMeta.Pools[poolIdx].OfflineDrives = append(drives[:i:i], drives[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		z.poolMetaMutex.Unlock()
+		return errDriveNotOffline
+	}
+	z.poolMeta.Pools[poolIdx].LastUpdate = UTCNow()
+	z.poolMetaMutex.Unlock()
+
+	if err = z.savePoolMeta(ctx); err != nil {
+		return err
+	}
+	z.syncOfflineDrives()
+	return nil
+}
+
+// syncOfflineDrives closes the drives marked offline, drives whose mark was
+// removed are connected again and healed if they are local.
+func (z *erasureServerPools) syncOfflineDrives() {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+
+	for idx, pool := range z.serverPools {
+		online := pool.setOfflineDrives(z.poolMeta.Pools[idx].OfflineDrives)
+		if len(online) == 0 {
+			continue
+		}
+		go func(pool *erasureSets, online Endpoints) {
+			pool.connectDisks()
+			var local Endpoints
+			for _, endpoint := range online {
+				if endpoint.IsLocal {
+					local = append(local, endpoint)
+				}
+			}
+			if len(local) > 0 && globalBackgroundHealState != nil {
+				globalBackgroundHealState.pushReplacedLocalDisks(local...)
+			}
+		}(pool, online)
+	}
+}
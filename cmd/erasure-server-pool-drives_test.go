@@ -0,0 +1,173 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOfflineAndReplaceDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	if err := z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := z.OfflineDrive(ctx, disks[0]); err != nil {
+		t.Fatal(err)
+	}
+	// Marking a drive offline again is a no-op.
+	if err := z.OfflineDrive(ctx, disks[0]); err != nil {
+		t.Fatal(err)
+	}
+	if !z.isDriveOffline(z.serverPools[0].endpoints[0]) {
+		t.Fatal("expected the drive to be offline")
+	}
+	if n := countOfflineDisks(z.serverPools[0].GetDisks(0)()); n != 1 {
+		t.Fatalf("expected 1 offline drive, got %d", n)
+	}
+
+	// Objects are written and read without the drive.
+	data := bytes.Repeat([]byte("a"), 1024)
+	if _, err := z.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := z.GetObject(ctx, "bucket", "object", 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("object data mismatch")
+	}
+
+	// Sets of 4 drives need 3 drives online for their write quorum.
+	if err := z.OfflineDrive(ctx, disks[1]); err != errDriveOfflineQuorum {
+		t.Fatalf("expected %v, got %v", errDriveOfflineQuorum, err)
+	}
+	if err := z.OfflineDrive(ctx, "/unknown"); err != errDriveNotFound {
+		t.Fatalf("expected %v, got %v", errDriveNotFound, err)
+	}
+	if err := z.ReplaceDrive(ctx, disks[2]); err != errDriveNotOffline {
+		t.Fatalf("expected %v, got %v", errDriveNotOffline, err)
+	}
+
+	if err := z.ReplaceDrive(ctx, disks[0]); err != nil {
+		t.Fatal(err)
+	}
+	meta, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offline := meta.Pools[0].OfflineDrives; len(offline) != 0 {
+		t.Fatalf("expected no saved offline drives, got %v", offline)
+	}
+
+	// The replaced drive is connected again in the background.
+	deadline := time.Now().Add(10 * time.Second)
+	for countOfflineDisks(z.serverPools[0].GetDisks(0)()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("replaced drive was not connected again")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if z.isDriveOffline(z.serverPools[0].endpoints[0]) {
+		t.Fatal("expected the replaced drive to be online")
+	}
+}
+
+func TestHealingTrackerETA(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := healingTracker{
+		Started:    start,
+		LastUpdate: start.Add(time.Hour),
+		BytesTotal: 400,
+	}
+	if d := h.toHealingDisk(); !d.ETA.IsZero() {
+		t.Fatalf("expected no ETA without progress, got %v", d.ETA)
+	}
+
+	h.objectHealed(100, nil)
+	h.objectHealed(50, errDiskNotFound)
+	if h.ObjectsHealed != 1 || h.ObjectsFailed != 1 || h.BytesDone != 100 || h.BytesFailed != 50 {
+		t.Fatalf("unexpected progress %+v", h)
+	}
+
+	h.objectHealed(50, nil)
+	// Half of the bytes took an hour, one more hour to go.
+	if d := h.toHealingDisk(); !d.ETA.Equal(start.Add(2 * time.Hour)) {
+		t.Fatalf("expected ETA %v, got %v", start.Add(2*time.Hour), d.ETA)
+	}
+}
+
+func TestOfflineDriveFailingDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	// A drive which is not connected counts against the write quorum.
+	pool := z.serverPools[0]
+	pool.erasureDisksMu.Lock()
+	for i, disk := range pool.erasureDisks[0] {
+		if disk != nil && disk.Endpoint().String() != disks[0] {
+			disk.Close()
+			pool.erasureDisks[0][i] = nil
+			break
+		}
+	}
+	pool.erasureDisksMu.Unlock()
+
+	if err := z.OfflineDrive(ctx, disks[0]); err != errDriveOfflineQuorum {
+		t.Fatalf("expected %v, got %v", errDriveOfflineQuorum, err)
+	}
+}
+
+func TestHealingTrackerResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	disk := z.serverPools[0].sets[0].getDisks()[0]
+	h := newHealingTracker(disk, "disk-id", 0, 0, 0)
+	h.BytesTotal = 400
+	h.objectHealed(100, nil)
+	h.HealedBuckets = append(h.HealedBuckets, "bucket")
+	if err := h.save(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadHealingTracker(ctx, disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ID != "disk-id" || loaded.BytesDone != 100 || loaded.BytesTotal != 400 || !loaded.Started.Equal(h.Started) {
+		t.Fatalf("unexpected tracker %+v", loaded)
+	}
+	if !loaded.isBucketHealed("bucket") || loaded.isBucketHealed("other") {
+		t.Fatalf("unexpected healed buckets %v", loaded.HealedBuckets)
+	}
+}
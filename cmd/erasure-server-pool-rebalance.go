@@ -0,0 +1,700 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Status of the last rebalance is saved in .minio.sys/rebalance.json.
+	rebalanceMetaName    = "rebalance.json"
+	rebalanceMetaVersion = 1
+
+	// Pools with a fraction of free space within the threshold of the
+	// goal do not take part in a rebalance, unless another one is given.
+	defaultRebalanceThreshold = 0.05
+
+	// Objects are moved by a rebalance at most a third of the time, with
+	// pauses of at most a second, unless other values are given.
+	defaultRebalanceSleepFactor = 2
+	defaultRebalanceMaxSleep    = time.Second
+
+	// Interval at which the progress of a rebalance is saved and the
+	// free space of the pools is checked against the goal.
+	rebalanceSaveInterval = 30 * time.Second
+)
+
+var (
+	errRebalanceAlreadyStarted = AdminError{
+		Code:       "XMinioRebalanceNotAllowed",
+		Message:    "Rebalance is already in progress",
+		StatusCode: http.StatusBadRequest,
+	}
+	errRebalanceDecommission = AdminError{
+		Code:       "XMinioRebalanceNotAllowed",
+		Message:    "Rebalance is not allowed while a pool is decommissioned",
+		StatusCode: http.StatusBadRequest,
+	}
+	errRebalanceReconciling = AdminError{
+		Code:       "XMinioRebalanceNotAllowed",
+		Message:    "Rebalance is not allowed while the pools are reconciled after a decommission or a rebalance",
+		StatusCode: http.StatusBadRequest,
+	}
+	errRebalanceInvalidOpts = AdminError{
+		Code:       "XMinioRebalanceInvalidArgument",
+		Message:    "Rebalance threshold must be between 0 and 1, sleep factor and maximum sleep cannot be negative",
+		StatusCode: http.StatusBadRequest,
+	}
+	errRebalanceNotNeeded = AdminError{
+		Code:       "XMinioRebalanceNotAllowed",
+		Message:    "Pools are already balanced, no pool has less free space than the goal",
+		StatusCode: http.StatusBadRequest,
+	}
+	errRebalanceNotRunning = AdminError{
+		Code:       "XMinioRebalanceNotStarted",
+		Message:    "Rebalance is not in progress",
+		StatusCode: http.StatusBadRequest,
+	}
+	errRebalanceNotStarted = AdminError{
+		Code:       "XMinioRebalanceNotStarted",
+		Message:    "No rebalance was started",
+		StatusCode: http.StatusNotFound,
+	}
+)
+
+// rebalancePoolStatus is the saved progress of a pool in a rebalance.
+type rebalancePoolStatus struct {
+	ID            int    `json:"id"`
+	CmdLine       string `json:"cmdline"`
+	Participating bool   `json:"participating"`
+	Status        string `json:"status,omitempty"`
+
+	// Free space and capacity of the pool when the rebalance started.
+	InitFreeSpace uint64 `json:"initFreeSpace"`
+	InitCapacity  uint64 `json:"initCapacity"`
+
+	Progress madmin.RebalancePoolProgress `json:"progress"`
+
+	// Buckets left to rebalance, the first one is walked again from
+	// the start when the rebalance is resumed.
+	QueuedBuckets     []string  `json:"queuedBuckets,omitempty"`
+	RebalancedBuckets []string  `json:"rebalancedBuckets,omitempty"`
+	LastUpdate        time.Time `json:"lastUpdate"`
+
+	// Objects overwritten on another pool while the pool was rebalanced
+	// are left on both pools until the pool is reconciled.
+	Reconciling bool `json:"reconciling,omitempty"`
+}
+
+// rebalanceMeta is the saved status of the last rebalance.
+type rebalanceMeta struct {
+	Version   int                   `json:"version"`
+	ID        string                `json:"id"`
+	StartTime time.Time             `json:"startTime"`
+	StoppedAt time.Time             `json:"stoppedAt,omitempty"`
+	FreeGoal  float64               `json:"freeGoal"`
+	Pools     []rebalancePoolStatus `json:"pools"`
+
+	// Parameters the rebalance was started with.
+	Threshold   float64       `json:"threshold"`
+	SleepFactor float64       `json:"sleepFactor"`
+	MaxSleep    time.Duration `json:"maxSleep"`
+}
+
+// isRebalancing returns true if objects are moved out of the pool.
+func (r *rebalanceMeta) isRebalancing(idx int) bool {
+	if r == nil || idx >= len(r.Pools) {
+		return false
+	}
+	p := r.Pools[idx]
+	return p.Participating && p.Status == madmin.RebalanceStarted
+}
+
+// running returns true if objects are moved out of any pool.
+func (r *rebalanceMeta) running() bool {
+	if r == nil {
+		return false
+	}
+	for idx := range r.Pools {
+		if r.isRebalancing(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconciling returns true if copies of objects left on the pool once its
+// rebalance ended are not reconciled yet.
+func (r *rebalanceMeta) reconciling(idx int) bool {
+	if r == nil || idx >= len(r.Pools) {
+		return false
+	}
+	return r.Pools[idx].Reconciling && !r.isRebalancing(idx)
+}
+
+// toAdmin returns the status of the rebalance to report through madmin.
+func (r *rebalanceMeta) toAdmin() madmin.RebalanceStatus {
+	status := madmin.RebalanceStatus{
+		ID:        r.ID,
+		StartTime: r.StartTime,
+		StoppedAt: r.StoppedAt,
+		FreeGoal:  r.FreeGoal,
+		Pools:     make([]madmin.RebalancePoolStatus, len(r.Pools)),
+	}
+	for idx, p := range r.Pools {
+		status.Pools[idx] = madmin.RebalancePoolStatus{
+			ID:       p.ID,
+			CmdLine:  p.CmdLine,
+			Status:   p.Status,
+			Progress: p.Progress,
+		}
+		if p.Status == madmin.RebalanceStarted && len(p.QueuedBuckets) > 0 {
+			status.Pools[idx].Progress.Bucket = p.QueuedBuckets[0]
+		}
+	}
+	return status
+}
+
+// poolFreeSpace returns the free space and the capacity of the drives of
+// a pool, tests replace it to simulate pools with different free space.
+var poolFreeSpace = func(info StorageInfo) (free, capacity uint64) {
+	for _, disk := range info.Disks {
+		capacity += disk.TotalSpace
+		free += disk.TotalSpace - disk.UsedSpace
+	}
+	return free, capacity
+}
+
+// loadRebalanceMeta reads the saved status of the last rebalance, nil is
+// returned if no rebalance was started.
+func (z *erasureServerPools) loadRebalanceMeta(ctx context.Context) (*rebalanceMeta, error) {
+	data, err := z.readLatestConfig(ctx, rebalanceMetaName)
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	meta := &rebalanceMeta{}
+	if err = json.Unmarshal(data, meta); err != nil {
+		return nil, err
+	}
+	if meta.Version != rebalanceMetaVersion {
+		return nil, fmt.Errorf("unexpected rebalance.json version %d", meta.Version)
+	}
+	return meta, nil
+}
+
+// saveRebalanceMeta saves the status of the rebalance.
+func (z *erasureServerPools) saveRebalanceMeta(ctx context.Context) error {
+	z.rebalMu.RLock()
+	if z.rebalMeta == nil {
+		z.rebalMu.RUnlock()
+		return nil
+	}
+	data, err := json.Marshal(z.rebalMeta)
+	z.rebalMu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, z, rebalanceMetaName, data)
+}
+
+// initRebalance loads the saved status of the last rebalance, a rebalance
+// is stopped and its pools are not reconciled if the pools of the command
+// line changed since it started.
+func (z *erasureServerPools) initRebalance(ctx context.Context) error {
+	meta, err := z.loadRebalanceMeta(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if meta.running() || meta.anyReconciling() {
+		z.poolMetaMutex.RLock()
+		changed = len(meta.Pools) != len(z.poolMeta.Pools)
+		for idx := 0; !changed && idx < len(meta.Pools); idx++ {
+			changed = meta.Pools[idx].CmdLine != z.poolMeta.Pools[idx].CmdLine
+		}
+		z.poolMetaMutex.RUnlock()
+	}
+	if changed {
+		for idx := range meta.Pools {
+			if meta.isRebalancing(idx) {
+				meta.Pools[idx].Status = madmin.RebalanceStopped
+			}
+			meta.Pools[idx].Reconciling = false
+		}
+		meta.StoppedAt = UTCNow()
+		logger.Info("Rebalance %s was stopped, the pools of the command line arguments changed", meta.ID)
+	}
+
+	z.rebalMu.Lock()
+	z.rebalMeta = meta
+	z.rebalMu.Unlock()
+
+	if changed {
+		if err = z.saveRebalanceMeta(ctx); err != nil {
+			return err
+		}
+	}
+
+	z.syncRebalance()
+	return nil
+}
+
+// reloadRebalance reloads the status of the rebalance saved by any server.
+func (z *erasureServerPools) reloadRebalance(ctx context.Context) error {
+	meta, err := z.loadRebalanceMeta(ctx)
+	if err != nil {
+		return err
+	}
+
+	z.rebalMu.Lock()
+	z.rebalMeta = meta
+	z.rebalMu.Unlock()
+
+	z.syncRebalance()
+	return nil
+}
+
+// IsRebalancing returns true if objects are moved out of the pool.
+func (z *erasureServerPools) IsRebalancing(idx int) bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+	return z.rebalMeta.isRebalancing(idx)
+}
+
+// anyReconciling returns true if any pool is left to reconcile.
+func (r *rebalanceMeta) anyReconciling() bool {
+	if r == nil {
+		return false
+	}
+	for idx := range r.Pools {
+		if r.reconciling(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// rebalanceReconciling returns true if pools are left to reconcile after
+// a rebalance.
+func (z *erasureServerPools) rebalanceReconciling() bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+	return z.rebalMeta.anyReconciling()
+}
+
+// rebalanceRunning returns true if a rebalance is in progress.
+func (z *erasureServerPools) rebalanceRunning() bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+	return z.rebalMeta.running()
+}
+
+// StartRebalance starts rebalancing the pools. The goal is the fraction of
+// free space of all pools, objects are moved out of the pools with less free
+// space, by more than the threshold, until they reach it. These pools are
+// not written to meanwhile. Zero options are replaced by their defaults.
+func (z *erasureServerPools) StartRebalance(ctx context.Context, opts madmin.RebalanceOpts) (string, error) {
+	if opts.Threshold < 0 || opts.Threshold >= 1 || opts.SleepFactor < 0 || opts.MaxSleep < 0 {
+		return "", errRebalanceInvalidOpts
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = defaultRebalanceThreshold
+	}
+	if opts.SleepFactor == 0 {
+		opts.SleepFactor = defaultRebalanceSleepFactor
+	}
+	if opts.MaxSleep == 0 {
+		opts.MaxSleep = defaultRebalanceMaxSleep
+	}
+
+	if z.rebalanceRunning() {
+		return "", errRebalanceAlreadyStarted
+	}
+	if z.hasSuspendedPools() {
+		return "", errRebalanceDecommission
+	}
+	if z.hasSpreadObjects() {
+		return "", errRebalanceReconciling
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	meta := &rebalanceMeta{
+		Version:     rebalanceMetaVersion,
+		ID:          mustGetUUID(),
+		StartTime:   UTCNow(),
+		Pools:       make([]rebalancePoolStatus, len(z.serverPools)),
+		Threshold:   opts.Threshold,
+		SleepFactor: opts.SleepFactor,
+		MaxSleep:    opts.MaxSleep,
+	}
+	var totalFree, totalCapacity uint64
+	for idx, pool := range z.serverPools {
+		free, capacity := poolFreeSpace(pool.StorageUsageInfo(ctx))
+		totalFree += free
+		totalCapacity += capacity
+
+		z.poolMetaMutex.RLock()
+		cmdLine := z.poolMeta.Pools[idx].CmdLine
+		z.poolMetaMutex.RUnlock()
+		meta.Pools[idx] = rebalancePoolStatus{
+			ID:            idx,
+			CmdLine:       cmdLine,
+			InitFreeSpace: free,
+			InitCapacity:  capacity,
+			LastUpdate:    meta.StartTime,
+		}
+	}
+	if totalCapacity == 0 {
+		return "", errRebalanceNotNeeded
+	}
+	meta.FreeGoal = float64(totalFree) / float64(totalCapacity)
+
+	participants := 0
+	for idx := range meta.Pools {
+		p := &meta.Pools[idx]
+		if p.InitCapacity == 0 || float64(p.InitFreeSpace)/float64(p.InitCapacity) >= meta.FreeGoal-meta.Threshold {
+			continue
+		}
+		p.Participating = true
+		p.Status = madmin.RebalanceStarted
+		for _, bucket := range buckets {
+			p.QueuedBuckets = append(p.QueuedBuckets, bucket.Name)
+		}
+		participants++
+	}
+	if participants == 0 {
+		return "", errRebalanceNotNeeded
+	}
+
+	z.rebalMu.Lock()
+	if z.rebalMeta.running() {
+		z.rebalMu.Unlock()
+		return "", errRebalanceAlreadyStarted
+	}
+	z.rebalMeta = meta
+	z.rebalMu.Unlock()
+
+	if err = z.saveRebalanceMeta(ctx); err != nil {
+		return "", err
+	}
+	z.syncRebalance()
+	return meta.ID, nil
+}
+
+// StopRebalance stops the rebalance, the pools are written to again.
+// Objects already moved are not moved back, the objects left on the pools
+// which were overwritten on another pool meanwhile are reconciled.
+func (z *erasureServerPools) StopRebalance(ctx context.Context) error {
+	z.rebalMu.Lock()
+	if !z.rebalMeta.running() {
+		z.rebalMu.Unlock()
+		return errRebalanceNotRunning
+	}
+	now := UTCNow()
+	for idx := range z.rebalMeta.Pools {
+		if z.rebalMeta.isRebalancing(idx) {
+			z.rebalMeta.Pools[idx].Status = madmin.RebalanceStopped
+			z.rebalMeta.Pools[idx].Reconciling = true
+			z.rebalMeta.Pools[idx].LastUpdate = now
+		}
+	}
+	z.rebalMeta.StoppedAt = now
+	z.rebalMu.Unlock()
+
+	if err := z.saveRebalanceMeta(ctx); err != nil {
+		return err
+	}
+	z.syncRebalance()
+	return nil
+}
+
+// RebalanceStatus returns the status of the last rebalance, the progress of
+// a rebalance is read from the server running it.
+func (z *erasureServerPools) RebalanceStatus(ctx context.Context) (madmin.RebalanceStatus, error) {
+	z.rebalMu.RLock()
+	var status madmin.RebalanceStatus
+	found := z.rebalMeta != nil
+	if found {
+		status = z.rebalMeta.toAdmin()
+	}
+	running := z.rebalMeta.running()
+	owner := z.rebalCancel != nil
+	z.rebalMu.RUnlock()
+
+	if !found {
+		return status, errRebalanceNotStarted
+	}
+
+	if running && !owner {
+		saved, err := z.loadRebalanceMeta(ctx)
+		if err != nil {
+			return madmin.RebalanceStatus{}, err
+		}
+		if saved != nil {
+			status = saved.toAdmin()
+		}
+	}
+
+	for idx := range status.Pools {
+		if idx >= len(z.serverPools) {
+			break
+		}
+		free, capacity := poolFreeSpace(z.serverPools[idx].StorageUsageInfo(ctx))
+		if capacity > 0 {
+			status.Pools[idx].Used = 1 - float64(free)/float64(capacity)
+		}
+	}
+	return status, nil
+}
+
+// syncRebalance starts rebalancing the pools if this server owns the
+// rebalance and stops it once it is stopped, the pools left to reconcile
+// are reconciled the same way. A rebalance is owned by the server with the
+// first drive of the first pool.
+func (z *erasureServerPools) syncRebalance() {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+	owner := z.serverPools[0].endpoints[0].IsLocal
+	running := z.rebalMeta.running()
+	switch {
+	case running && z.rebalCancel == nil && owner:
+		ctx, cancel := context.WithCancel(GlobalContext)
+		z.rebalCancel = cancel
+		sleeper := newDynamicSleeper(z.rebalMeta.SleepFactor, z.rebalMeta.MaxSleep)
+		for idx := range z.rebalMeta.Pools {
+			if z.rebalMeta.isRebalancing(idx) {
+				go z.rebalancePool(ctx, idx, sleeper)
+			}
+		}
+	case !running && z.rebalCancel != nil:
+		z.rebalCancel()
+		z.rebalCancel = nil
+	}
+
+	for idx, cancel := range z.rebalReconcileCancelers {
+		reconciling := z.rebalMeta.reconciling(idx)
+		switch {
+		case reconciling && cancel == nil && owner:
+			ctx, cancel := context.WithCancel(GlobalContext)
+			z.rebalReconcileCancelers[idx] = cancel
+			go z.reconcileRebalance(ctx, idx)
+		case !reconciling && cancel != nil:
+			cancel()
+			z.rebalReconcileCancelers[idx] = nil
+		}
+	}
+}
+
+// rebalanceGoalReached returns true if the fraction of free space of the
+// pool reached the goal of the rebalance.
+func (z *erasureServerPools) rebalanceGoalReached(ctx context.Context, idx int) bool {
+	free, capacity := poolFreeSpace(z.serverPools[idx].StorageUsageInfo(ctx))
+
+	z.rebalMu.RLock()
+	goal := z.rebalMeta.FreeGoal
+	z.rebalMu.RUnlock()
+	return capacity > 0 && float64(free)/float64(capacity) >= goal
+}
+
+// rebalancePool moves the objects of the queued buckets out of the pool
+// until it reaches the goal, it is resumed from the first queued bucket
+// after a restart.
+func (z *erasureServerPools) rebalancePool(ctx context.Context, idx int, sleeper *dynamicSleeper) {
+	for {
+		if z.rebalanceGoalReached(ctx, idx) {
+			z.rebalancePoolDone(idx, madmin.RebalanceCompleted)
+			return
+		}
+
+		z.rebalMu.RLock()
+		var bucket string
+		if z.rebalMeta.isRebalancing(idx) && len(z.rebalMeta.Pools[idx].QueuedBuckets) > 0 {
+			bucket = z.rebalMeta.Pools[idx].QueuedBuckets[0]
+		}
+		z.rebalMu.RUnlock()
+		if bucket == "" {
+			break
+		}
+
+		// Listing is canceled once the goal is reached.
+		bctx, cancel := context.WithCancel(ctx)
+		goalReached := false
+		lastCheck := time.Now()
+		err := z.listPoolBucket(bctx, idx, bucket, "", func(set *erasureObjects, entry metaCacheEntry) {
+			if time.Since(lastCheck) > rebalanceSaveInterval {
+				lastCheck = time.Now()
+				if z.rebalanceGoalReached(ctx, idx) {
+					goalReached = true
+					cancel()
+					return
+				}
+			}
+			wait := sleeper.Timer(bctx)
+			z.moveEntry(bctx, idx, set, bucket, entry, false, func(size int64, failed bool) {
+				z.rebalanceProgress(ctx, idx, size, failed)
+			})
+			wait()
+		})
+		cancel()
+		if goalReached {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				// Rebalance was stopped.
+				return
+			}
+			logger.LogIf(ctx, fmt.Errorf("Unable to rebalance %s: %w", bucket, err))
+			z.rebalancePoolDone(idx, madmin.RebalanceFailed)
+			return
+		}
+
+		z.rebalMu.Lock()
+		if z.rebalMeta.isRebalancing(idx) {
+			p := &z.rebalMeta.Pools[idx]
+			if len(p.QueuedBuckets) > 0 && p.QueuedBuckets[0] == bucket {
+				p.QueuedBuckets = p.QueuedBuckets[1:]
+				p.RebalancedBuckets = append(p.RebalancedBuckets, bucket)
+			}
+			p.LastUpdate = UTCNow()
+		}
+		z.rebalMu.Unlock()
+		logger.LogIf(ctx, z.saveRebalanceMeta(ctx))
+	}
+
+	if ctx.Err() == nil {
+		z.rebalancePoolDone(idx, madmin.RebalanceCompleted)
+	}
+}
+
+// rebalancePoolDone records the final status of the pool in the rebalance,
+// the pool is written to again and is reconciled.
+func (z *erasureServerPools) rebalancePoolDone(idx int, status string) {
+	z.rebalMu.Lock()
+	if !z.rebalMeta.isRebalancing(idx) {
+		z.rebalMu.Unlock()
+		return
+	}
+	now := UTCNow()
+	p := &z.rebalMeta.Pools[idx]
+	p.Status = status
+	p.Reconciling = true
+	p.LastUpdate = now
+	cmdLine := p.CmdLine
+	var cancel context.CancelFunc
+	if !z.rebalMeta.running() {
+		z.rebalMeta.StoppedAt = now
+		cancel, z.rebalCancel = z.rebalCancel, nil
+	}
+	z.rebalMu.Unlock()
+
+	ctx := GlobalContext
+	logger.LogIf(ctx, z.saveRebalanceMeta(ctx))
+	for _, nerr := range globalNotificationSys.ReloadPoolMeta(ctx) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	if cancel != nil {
+		cancel()
+	}
+	z.syncRebalance()
+
+	logger.Info("Rebalance of pool %s: %s", cmdLine, status)
+}
+
+// reconcileRebalance reconciles the pool once its rebalance ended, it is
+// reconciled again after a restart until it succeeds.
+func (z *erasureServerPools) reconcileRebalance(ctx context.Context, idx int) {
+	err := z.reconcilePool(ctx, idx)
+	if ctx.Err() != nil {
+		return
+	}
+
+	z.rebalMu.Lock()
+	z.rebalReconcileCancelers[idx] = nil
+	if !z.rebalMeta.reconciling(idx) {
+		z.rebalMu.Unlock()
+		return
+	}
+	p := &z.rebalMeta.Pools[idx]
+	cmdLine := p.CmdLine
+	if err == nil {
+		p.Reconciling = false
+		p.LastUpdate = UTCNow()
+	}
+	z.rebalMu.Unlock()
+
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to reconcile pool %s, it is reconciled again after a restart: %w", cmdLine, err))
+		return
+	}
+
+	logger.LogIf(ctx, z.saveRebalanceMeta(ctx))
+	for _, nerr := range globalNotificationSys.ReloadPoolMeta(ctx) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	logger.Info("Pool %s is reconciled", cmdLine)
+}
+
+// rebalanceProgress records a moved or failed object version, the progress
+// is saved at most every rebalanceSaveInterval.
+func (z *erasureServerPools) rebalanceProgress(ctx context.Context, idx int, size int64, failed bool) {
+	z.rebalMu.Lock()
+	if !z.rebalMeta.isRebalancing(idx) {
+		z.rebalMu.Unlock()
+		return
+	}
+	p := &z.rebalMeta.Pools[idx]
+	if failed {
+		p.Progress.ObjectsFailed++
+		p.Progress.BytesFailed += size
+	} else {
+		p.Progress.Objects++
+		p.Progress.Bytes += size
+	}
+	save := time.Since(p.LastUpdate) > rebalanceSaveInterval
+	if save {
+		p.LastUpdate = UTCNow()
+	}
+	z.rebalMu.Unlock()
+
+	if save {
+		logger.LogIf(ctx, z.saveRebalanceMeta(ctx))
+	}
+}
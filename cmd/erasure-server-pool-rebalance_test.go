@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestRebalancePools(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	if _, err := z.RebalanceStatus(ctx); err != errRebalanceNotStarted {
+		t.Fatalf("expected %v, got %v", errRebalanceNotStarted, err)
+	}
+	if err := z.StopRebalance(ctx); err != errRebalanceNotRunning {
+		t.Fatalf("expected %v, got %v", errRebalanceNotRunning, err)
+	}
+
+	if err := z.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := map[string]string{}
+	for _, object := range []string{"object1", "dir/object2"} {
+		data := bytes.Repeat([]byte(object), 1024)
+		oi, err := z.serverPools[0].PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects[object] = oi.ETag
+	}
+
+	// The drives of both pools share the same filesystem.
+	if _, err := z.StartRebalance(ctx, madmin.RebalanceOpts{}); err != errRebalanceNotNeeded {
+		t.Fatalf("expected %v, got %v", errRebalanceNotNeeded, err)
+	}
+	if _, err := z.StartRebalance(ctx, madmin.RebalanceOpts{Threshold: 1}); err != errRebalanceInvalidOpts {
+		t.Fatalf("expected %v, got %v", errRebalanceInvalidOpts, err)
+	}
+
+	// The first pool is reported with less free space than the goal, which
+	// it never reaches, so all of its objects are moved out of it.
+	firstPoolDrives := set.CreateStringSet(disks[:4]...)
+	freeSpace := poolFreeSpace
+	defer func() {
+		poolFreeSpace = freeSpace
+	}()
+	poolFreeSpace = func(info StorageInfo) (free, capacity uint64) {
+		if len(info.Disks) > 0 && firstPoolDrives.Contains(info.Disks[0].DrivePath) {
+			return 10, 100
+		}
+		return 90, 100
+	}
+
+	if _, err := z.StartRebalance(ctx, madmin.RebalanceOpts{SleepFactor: 0.5, MaxSleep: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	z.rebalMu.RLock()
+	meta := *z.rebalMeta
+	z.rebalMu.RUnlock()
+	if meta.Threshold != defaultRebalanceThreshold || meta.SleepFactor != 0.5 || meta.MaxSleep != time.Millisecond {
+		t.Fatalf("unexpected rebalance parameters %v %v %v", meta.Threshold, meta.SleepFactor, meta.MaxSleep)
+	}
+	if !meta.Pools[0].Participating || meta.Pools[1].Participating {
+		t.Fatal("expected only the first pool to take part in the rebalance")
+	}
+
+	if !z.IsSuspended(0) || z.IsSuspended(1) {
+		t.Fatal("expected only the rebalanced pool to be suspended")
+	}
+	if _, err := z.StartRebalance(ctx, madmin.RebalanceOpts{}); err != errRebalanceAlreadyStarted {
+		t.Fatalf("expected %v, got %v", errRebalanceAlreadyStarted, err)
+	}
+	if err := z.Decommission(ctx, 0); err != errDecommissionRebalanceRunning {
+		t.Fatalf("expected %v, got %v", errDecommissionRebalanceRunning, err)
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		status, err := z.RebalanceStatus(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pool := status.Pools[0]
+		if pool.Status == madmin.RebalanceFailed {
+			t.Fatal("rebalance failed")
+		}
+		if pool.Status == madmin.RebalanceCompleted {
+			if pool.Progress.Objects < int64(len(objects)) {
+				t.Fatalf("expected at least %d objects rebalanced, got %d", len(objects), pool.Progress.Objects)
+			}
+			if status.StoppedAt.IsZero() {
+				t.Fatal("expected rebalance to be stopped once all pools completed")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for rebalance to complete")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if z.IsSuspended(0) {
+		t.Fatal("expected pool to be written to once rebalanced")
+	}
+	deadline = time.Now().Add(time.Minute)
+	for z.hasSpreadObjects() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the rebalanced pool to be reconciled")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	for object, etag := range objects {
+		oi, err := z.GetObjectInfo(ctx, "bucket", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.ETag != etag {
+			t.Fatalf("expected ETag %s of %s, got %s", etag, object, oi.ETag)
+		}
+		if _, err = z.serverPools[1].GetObjectInfo(ctx, "bucket", object, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The saved status survives a restart.
+	if err := z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+	status, err := z.RebalanceStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Pools[0].Status != madmin.RebalanceCompleted {
+		t.Fatalf("expected saved status %s, got %s", madmin.RebalanceCompleted, status.Pools[0].Status)
+	}
+}
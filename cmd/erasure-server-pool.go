@@ -43,6 +43,20 @@ type erasureServerPools struct {
 
 	serverPools []*erasureSets
 
+	// Status of the pools and cancelers of the pools
+	// being decommissioned or reconciled by this server.
+	poolMetaMutex         sync.RWMutex
+	poolMeta              poolMeta
+	decommissionCancelers []context.CancelFunc
+	reconcileCancelers    []context.CancelFunc
+
+	// Status of the rebalance of the pools, its canceler and the
+	// cancelers of the pools reconciled after it by this server.
+	rebalMu                 sync.RWMutex
+	rebalMeta               *rebalanceMeta
+	rebalCancel             context.CancelFunc
+	rebalReconcileCancelers []context.CancelFunc
+
 	// Shut down async operations
 	shutdown context.CancelFunc
 }
@@ -61,7 +75,13 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 
 		formats      = make([]*formatErasureV3, len(endpointServerPools))
 		storageDisks = make([][]StorageAPI, len(endpointServerPools))
-		z            = &erasureServerPools{serverPools: make([]*erasureSets, len(endpointServerPools))}
+		z            = &erasureServerPools{
+			serverPools:             make([]*erasureSets, len(endpointServerPools)),
+			poolMeta:                newPoolMeta(endpointServerPools),
+			decommissionCancelers:   make([]context.CancelFunc, len(endpointServerPools)),
+			reconcileCancelers:      make([]context.CancelFunc, len(endpointServerPools)),
+			rebalReconcileCancelers: make([]context.CancelFunc, len(endpointServerPools)),
+		}
 	)
 
 	var localDrives []string
@@ -242,6 +262,10 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, s
 				available = 0
 			}
 		}
+		// Pools being decommissioned or rebalanced are not written to.
+		if z.IsSuspended(i) {
+			available = 0
+		}
 		serverPools[i] = poolAvailableSpace{
 			Index:     i,
 			Available: available,
@@ -251,12 +275,16 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, s
 }
 
 // getPoolIdx returns the found previous object and its corresponding pool idx,
-// if none are found falls back to most available space pool.
+// if none are found falls back to most available space pool. Suspended pools
+// are never returned.
 func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object string, opts ObjectOptions, size int64) (idx int, err error) {
 	if z.SinglePool() {
 		return 0, nil
 	}
 	for i, pool := range z.serverPools {
+		if z.IsSuspended(i) {
+			continue
+		}
 		objInfo, err := pool.GetObjectInfo(ctx, bucket, object, opts)
 		switch err.(type) {
 		case VersionNotFound:
@@ -288,6 +316,53 @@ func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object stri
 	return idx, nil
 }
 
+// getLatestObjectInfo returns the latest version of the object and its
+// pool, the versions of an object are spread across pools while a pool is
+// suspended or reconciled. The pools are queried in parallel, the error of
+// the latest version is returned along with it, such as for a delete marker.
+func (z *erasureServerPools) getLatestObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, int, error) {
+	objInfos := make([]ObjectInfo, len(z.serverPools))
+	g := errgroup.WithNErrs(len(z.serverPools))
+	for index := range z.serverPools {
+		index := index
+		g.Go(func() (err error) {
+			objInfos[index], err = z.serverPools[index].GetObjectInfo(ctx, bucket, object, opts)
+			return err
+		}, index)
+	}
+	errs := g.Wait()
+
+	idx := -1
+	for i, err := range errs {
+		if err != nil && !objInfos[i].DeleteMarker {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				continue
+			}
+			return ObjectInfo{}, -1, err
+		}
+		if idx < 0 || objInfos[i].ModTime.After(objInfos[idx].ModTime) {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return ObjectInfo{}, -1, ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	return objInfos[idx], idx, errs[idx]
+}
+
+// poolsToRead returns the pools to read the latest version of the object
+// from, in order.
+func (z *erasureServerPools) poolsToRead(ctx context.Context, bucket, object string, opts ObjectOptions) []*erasureSets {
+	if opts.VersionID != "" || !z.hasSpreadObjects() {
+		return z.serverPools
+	}
+	_, idx, _ := z.getLatestObjectInfo(ctx, bucket, object, opts)
+	if idx < 0 {
+		return z.serverPools
+	}
+	return z.serverPools[idx : idx+1]
+}
+
 func (z *erasureServerPools) Shutdown(ctx context.Context) error {
 	defer z.shutdown()
 
@@ -523,7 +598,7 @@ func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object
 
 	object = encodeDirObject(object)
 
-	for _, pool := range z.serverPools {
+	for _, pool := range z.poolsToRead(ctx, bucket, object, opts) {
 		gr, err = pool.GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
 		if err != nil {
 			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
@@ -553,7 +628,7 @@ func (z *erasureServerPools) GetObject(ctx context.Context, bucket, object strin
 
 	object = encodeDirObject(object)
 
-	for _, pool := range z.serverPools {
+	for _, pool := range z.poolsToRead(ctx, bucket, object, opts) {
 		if err := pool.GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts); err != nil {
 			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 				continue
@@ -574,15 +649,23 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 	}
 
 	object = encodeDirObject(object)
-	for _, pool := range z.serverPools {
-		objInfo, err = pool.GetObjectInfo(ctx, bucket, object, opts)
-		if err != nil {
-			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
-				continue
-			}
+	if opts.VersionID == "" && z.hasSpreadObjects() {
+		// The latest version is read from all pools at once.
+		objInfo, _, err = z.getLatestObjectInfo(ctx, bucket, object, opts)
+		if err == nil || (!isErrObjectNotFound(err) && !isErrVersionNotFound(err)) {
 			return objInfo, err
 		}
-		return objInfo, nil
+	} else {
+		for _, pool := range z.serverPools {
+			objInfo, err = pool.GetObjectInfo(ctx, bucket, object, opts)
+			if err != nil {
+				if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+					continue
+				}
+				return objInfo, err
+			}
+			return objInfo, nil
+		}
 	}
 	object = decodeDirObject(object)
 	// proxy HEAD to replication target if active-active replication configured on bucket
@@ -605,6 +688,11 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 		return ObjectInfo{}, err
 	}
 
+	if opts.UserDefined == nil {
+		opts.UserDefined = make(map[string]string)
+	}
+	setBucketStorageClass(bucket, object, opts.UserDefined)
+
 	object = encodeDirObject(object)
 
 	if z.SinglePool() {
@@ -630,6 +718,33 @@ func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, ob
 	if z.SinglePool() {
 		return z.serverPools[0].DeleteObject(ctx, bucket, object, opts)
 	}
+	if opts.VersionID == "" && (opts.Versioned || opts.VersionSuspended) && z.hasSuspendedPools() {
+		// Delete markers are not added to suspended pools.
+		idx, err := z.getPoolIdx(ctx, bucket, object, ObjectOptions{}, 0)
+		if err != nil {
+			return objInfo, err
+		}
+		return z.serverPools[idx].getHashedSet(object).addDeleteMarker(ctx, bucket, object, opts)
+	}
+	if (opts.VersionID != "" || !(opts.Versioned || opts.VersionSuspended)) && z.hasSpreadObjects() {
+		// The version can be on more than one pool, it is deleted from
+		// all of them for an older copy not to show up again.
+		found := false
+		for _, pool := range z.serverPools {
+			oi, derr := pool.DeleteObject(ctx, bucket, object, opts)
+			switch {
+			case derr == nil:
+				objInfo, err, found = oi, nil, true
+			case isErrObjectNotFound(derr) || isErrVersionNotFound(derr):
+				if !found {
+					objInfo, err = oi, derr
+				}
+			default:
+				return oi, derr
+			}
+		}
+		return objInfo, err
+	}
 	for _, pool := range z.serverPools {
 		objInfo, err = pool.DeleteObject(ctx, bucket, object, opts)
 		if err == nil {
@@ -668,13 +783,58 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 		return z.serverPools[0].DeleteObjects(ctx, bucket, objects, opts)
 	}
 
-	for _, pool := range z.serverPools {
-		deletedObjects, errs := pool.DeleteObjects(ctx, bucket, objects, opts)
-		for i, derr := range errs {
+	// Delete markers are not added to suspended pools, like DeleteObject
+	// each of them is added to the pool the object is written to. All other
+	// deletes are sent to all the pools, a version can be on more than one.
+	poolObjects := make([][]ObjectToDelete, len(z.serverPools))
+	poolIndexes := make([][]int, len(z.serverPools))
+	addMarkers := (opts.Versioned || opts.VersionSuspended) && z.hasSuspendedPools()
+	for i, obj := range objects {
+		if derrs[i] != nil {
+			continue
+		}
+		if addMarkers && obj.VersionID == "" {
+			idx, err := z.getPoolIdx(ctx, bucket, obj.ObjectName, ObjectOptions{NoLock: true}, 0)
+			if err != nil {
+				derrs[i] = err
+				continue
+			}
+			mopts := opts
+			mopts.NoLock = true
+			mopts.DeleteMarkerReplicationStatus = obj.DeleteMarkerReplicationStatus
+			mopts.VersionPurgeStatus = obj.VersionPurgeStatus
+			oi, err := z.serverPools[idx].getHashedSet(obj.ObjectName).addDeleteMarker(ctx, bucket, obj.ObjectName, mopts)
+			if err != nil {
+				derrs[i] = err
+				continue
+			}
+			dobjects[i] = DeletedObject{
+				DeleteMarker:                  true,
+				DeleteMarkerVersionID:         oi.VersionID,
+				DeleteMarkerMTime:             DeleteMarkerMTime{oi.ModTime},
+				DeleteMarkerReplicationStatus: obj.DeleteMarkerReplicationStatus,
+				ObjectName:                    obj.ObjectName,
+				VersionPurgeStatus:            obj.VersionPurgeStatus,
+			}
+			continue
+		}
+		for idx := range z.serverPools {
+			poolObjects[idx] = append(poolObjects[idx], obj)
+			poolIndexes[idx] = append(poolIndexes[idx], i)
+		}
+	}
+
+	for idx, pool := range z.serverPools {
+		if len(poolObjects[idx]) == 0 {
+			continue
+		}
+		deletedObjects, errs := pool.DeleteObjects(ctx, bucket, poolObjects[idx], opts)
+		for j, derr := range errs {
+			i := poolIndexes[idx][j]
 			if derr != nil {
 				derrs[i] = derr
 			}
-			dobjects[i] = deletedObjects[i]
+			dobjects[i] = deletedObjects[j]
 		}
 	}
 	return dobjects, derrs
@@ -719,7 +879,13 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		Versioned:            dstOpts.Versioned,
 		VersionID:            dstOpts.VersionID,
 		MTime:                dstOpts.MTime,
+		IndexCB:              dstOpts.IndexCB,
+		ChecksumCB:           dstOpts.ChecksumCB,
 	}
+	if putOpts.UserDefined == nil {
+		putOpts.UserDefined = make(map[string]string)
+	}
+	setBucketStorageClass(dstBucket, decodeDirObject(dstObject), putOpts.UserDefined)
 
 	return z.serverPools[poolIdx].PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
 }
@@ -865,6 +1031,11 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 		return "", err
 	}
 
+	if opts.UserDefined == nil {
+		opts.UserDefined = make(map[string]string)
+	}
+	setBucketStorageClass(bucket, object, opts.UserDefined)
+
 	if z.SinglePool() {
 		return z.serverPools[0].NewMultipartUpload(ctx, bucket, object, opts)
 	}
@@ -898,9 +1069,12 @@ func (z *erasureServerPools) PutObjectPart(ctx context.Context, bucket, object,
 		return z.serverPools[0].PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
 	}
 
-	for _, pool := range z.serverPools {
+	for idx, pool := range z.serverPools {
 		_, err := pool.GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 		if err == nil {
+			if z.isDecommissioned(idx) {
+				return PartInfo{}, z.decommissionMultipartErr(ctx, idx, bucket, object, uploadID)
+			}
 			return pool.PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
 		}
 		switch err.(type) {
@@ -1014,25 +1188,36 @@ func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket
 		return z.serverPools[0].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 	}
 
-	// Purge any existing object.
-	for _, pool := range z.serverPools {
-		pool.DeleteObject(ctx, bucket, object, opts)
-	}
-
-	for _, pool := range z.serverPools {
+	idx := -1
+	for i, pool := range z.serverPools {
 		result, err := pool.ListMultipartUploads(ctx, bucket, object, "", "", "", maxUploadsList)
 		if err != nil {
 			return objInfo, err
 		}
 		if result.Lookup(uploadID) {
-			return pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+			idx = i
+			break
 		}
 	}
-	return objInfo, InvalidUploadID{
-		Bucket:   bucket,
-		Object:   object,
-		UploadID: uploadID,
+	if idx < 0 {
+		return objInfo, InvalidUploadID{
+			Bucket:   bucket,
+			Object:   object,
+			UploadID: uploadID,
+		}
+	}
+	// Uploads started on a pool before its decommission are not completed
+	// on it, the object would be left on the pool.
+	if z.isDecommissioned(idx) {
+		return objInfo, z.decommissionMultipartErr(ctx, idx, bucket, object, uploadID)
 	}
+
+	// Purge any existing object.
+	for _, pool := range z.serverPools {
+		pool.DeleteObject(ctx, bucket, object, opts)
+	}
+
+	return z.serverPools[idx].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 }
 
 // GetBucketInfo - returns bucket info from one of the erasure coded serverPools.
@@ -1564,14 +1749,25 @@ func (z *erasureServerPools) PutObjectTags(ctx context.Context, bucket, object s
 		return z.serverPools[0].PutObjectTags(ctx, bucket, object, tags, opts)
 	}
 
-	for _, pool := range z.serverPools {
-		objInfo, err := pool.PutObjectTags(ctx, bucket, object, tags, opts)
+	// The version can be on more than one pool, its tags are updated on
+	// all of them for an older copy not to show up again.
+	allPools := opts.VersionID != "" && z.hasSpreadObjects()
+	var objInfo ObjectInfo
+	found := false
+	for _, pool := range z.poolsToRead(ctx, bucket, object, opts) {
+		oi, err := pool.PutObjectTags(ctx, bucket, object, tags, opts)
 		if err != nil {
 			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 				continue
 			}
 			return ObjectInfo{}, err
 		}
+		if !allPools {
+			return oi, nil
+		}
+		objInfo, found = oi, true
+	}
+	if found {
 		return objInfo, nil
 	}
 	if opts.VersionID != "" {
@@ -1593,14 +1789,25 @@ func (z *erasureServerPools) DeleteObjectTags(ctx context.Context, bucket, objec
 	if z.SinglePool() {
 		return z.serverPools[0].DeleteObjectTags(ctx, bucket, object, opts)
 	}
-	for _, pool := range z.serverPools {
-		objInfo, err := pool.DeleteObjectTags(ctx, bucket, object, opts)
+	// The version can be on more than one pool, its tags are updated on
+	// all of them for an older copy not to show up again.
+	allPools := opts.VersionID != "" && z.hasSpreadObjects()
+	var objInfo ObjectInfo
+	found := false
+	for _, pool := range z.poolsToRead(ctx, bucket, object, opts) {
+		oi, err := pool.DeleteObjectTags(ctx, bucket, object, opts)
 		if err != nil {
 			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 				continue
 			}
 			return ObjectInfo{}, err
 		}
+		if !allPools {
+			return oi, nil
+		}
+		objInfo, found = oi, true
+	}
+	if found {
 		return objInfo, nil
 	}
 	if opts.VersionID != "" {
@@ -1622,7 +1829,7 @@ func (z *erasureServerPools) GetObjectTags(ctx context.Context, bucket, object s
 	if z.SinglePool() {
 		return z.serverPools[0].GetObjectTags(ctx, bucket, object, opts)
 	}
-	for _, pool := range z.serverPools {
+	for _, pool := range z.poolsToRead(ctx, bucket, object, opts) {
 		tags, err := pool.GetObjectTags(ctx, bucket, object, opts)
 		if err != nil {
 			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
@@ -95,6 +95,10 @@ type erasureSets struct {
 
 	mrfMU         sync.Mutex
 	mrfOperations map[healSource]int
+
+	// Endpoints of the drives marked offline through the admin
+	// API, protected by erasureDisksMu.
+	offlineDrives map[string]struct{}
 }
 
 func isEndpointConnected(diskMap map[string]StorageAPI, endpoint string) bool {
@@ -204,6 +208,9 @@ func (s *erasureSets) connectDisks() {
 		if isEndpointConnected(diskMap, diskPath) {
 			continue
 		}
+		if s.isDriveOffline(endpoint) {
+			continue
+		}
 		wg.Add(1)
 		go func(endpoint Endpoint) {
 			defer wg.Done()
@@ -230,6 +237,12 @@ func (s *erasureSets) connectDisks() {
 			}
 
 			s.erasureDisksMu.Lock()
+			if _, ok := s.offlineDrives[endpoint.String()]; ok {
+				// Marked offline while connecting.
+				s.erasureDisksMu.Unlock()
+				disk.Close()
+				return
+			}
 			if s.erasureDisks[setIndex][diskIndex] != nil {
 				s.erasureDisks[setIndex][diskIndex].Close()
 			}
@@ -263,6 +276,50 @@ func (s *erasureSets) connectDisks() {
 	wg.Wait()
 }
 
+// isDriveOffline returns true if the drive was marked offline through the admin API.
+func (s *erasureSets) isDriveOffline(endpoint Endpoint) bool {
+	s.erasureDisksMu.RLock()
+	defer s.erasureDisksMu.RUnlock()
+
+	_, ok := s.offlineDrives[endpoint.String()]
+	return ok
+}
+
+// setOfflineDrives closes the drives marked offline, they are treated as
+// missing until the mark is removed. The endpoints of the drives whose
+// mark was removed are returned.
+func (s *erasureSets) setOfflineDrives(drives []string) (online Endpoints) {
+	offline := make(map[string]struct{}, len(drives))
+	for _, drive := range drives {
+		offline[drive] = struct{}{}
+	}
+
+	s.erasureDisksMu.Lock()
+	defer s.erasureDisksMu.Unlock()
+
+	for _, endpoint := range s.endpoints {
+		_, wasOffline := s.offlineDrives[endpoint.String()]
+		_, isOffline := offline[endpoint.String()]
+		if wasOffline && !isOffline {
+			online = append(online, endpoint)
+		}
+	}
+	s.offlineDrives = offline
+
+	for i := range s.erasureDisks {
+		for j, disk := range s.erasureDisks[i] {
+			if disk == nil {
+				continue
+			}
+			if _, ok := offline[disk.Endpoint().String()]; ok {
+				disk.Close()
+				s.erasureDisks[i][j] = nil
+			}
+		}
+	}
+	return online
+}
+
 // monitorAndConnectEndpoints this is a monitoring loop to keep track of disconnected
 // endpoints by reconnecting them and making sure to place them into right position in
 // the set topology, this monitoring happens at a given monitoring interval.
@@ -904,6 +961,8 @@ func (s *erasureSets) CopyObject(ctx context.Context, srcBucket, srcObject, dstB
 		Versioned:            dstOpts.Versioned,
 		VersionID:            dstOpts.VersionID,
 		MTime:                dstOpts.MTime,
+		IndexCB:              dstOpts.IndexCB,
+		ChecksumCB:           dstOpts.ChecksumCB,
 	}
 
 	return dstSet.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
@@ -1252,6 +1311,14 @@ func markRootDisksAsDown(storageDisks []StorageAPI, errs []error) {
 func (s *erasureSets) HealFormat(ctx context.Context, dryRun bool) (res madmin.HealResultItem, err error) {
 	storageDisks, errs := initStorageDisksWithErrorsWithoutHealthCheck(s.endpoints)
 	for i, derr := range errs {
+		if s.isDriveOffline(s.endpoints[i]) {
+			// Drives marked offline are formatted once replaced.
+			if storageDisks[i] != nil {
+				storageDisks[i].Close()
+				storageDisks[i] = nil
+			}
+			continue
+		}
 		if derr != nil && derr != errDiskNotFound {
 			return madmin.HealResultItem{}, fmt.Errorf("Disk %s: %w", s.endpoints[i], derr)
 		}
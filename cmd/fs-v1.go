@@ -1204,6 +1204,11 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	if opts.ChecksumCB != nil {
+		if c := opts.ChecksumCB(); c != nil {
+			fsMeta.Meta[objectChecksumKey] = c.String()
+		}
+	}
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
@@ -359,19 +359,13 @@ func setTimeValidityHandler(h http.Handler) http.Handler {
 
 var supportedDummyBucketAPIs = map[string][]string{
 	"acl":            {http.MethodPut, http.MethodGet},
-	"cors":           {http.MethodGet},
-	"website":        {http.MethodGet, http.MethodDelete},
-	"logging":        {http.MethodGet},
 	"accelerate":     {http.MethodGet},
 	"requestPayment": {http.MethodGet},
 }
 
 // List of not implemented bucket queries
 var notImplementedBucketResourceNames = map[string]struct{}{
-	"cors":           {},
 	"metrics":        {},
-	"website":        {},
-	"logging":        {},
 	"inventory":      {},
 	"accelerate":     {},
 	"requestPayment": {},
@@ -94,6 +94,7 @@ func getLocalBackgroundHealStatus() (madmin.BgHealState, bool) {
 		ScannedItemsCount: bgSeq.getScannedItemsCount(),
 		LastHealActivity:  bgSeq.lastHealActivity,
 		HealDisks:         healDisks,
+		HealingDisks:      globalBackgroundHealState.getHealStatus(),
 		NextHealRound:     UTCNow(),
 	}, true
 }
@@ -119,8 +120,9 @@ func mustGetHealSequence(ctx context.Context) *healSequence {
 	}
 }
 
-// healErasureSet lists and heals all objects in a specific erasure set
-func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, disks []StorageAPI) error {
+// healErasureSet lists and heals all objects in a specific erasure set,
+// the progress is recorded by the tracker of the disk being healed.
+func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, disks []StorageAPI, tracker *healingTracker) error {
 	bgSeq := mustGetHealSequence(ctx)
 
 	buckets = append(buckets, BucketInfo{
@@ -139,6 +141,12 @@ func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, dis
 
 	// Heal all buckets with all objects
 	for _, bucket := range buckets {
+		if tracker.isBucketHealed(bucket.Name) {
+			continue
+		}
+		tracker.Bucket = bucket.Name
+		tracker.update(ctx)
+
 		// Heal current bucket
 		if err := bgSeq.queueHealTask(healSource{
 			bucket: bucket.Name,
@@ -184,17 +192,21 @@ func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, dis
 			}
 
 			for _, version := range entry.Versions {
-				if err := bgSeq.queueHealTask(healSource{
+				err := bgSeq.queueHealTask(healSource{
 					bucket:    bucket.Name,
 					object:    version.Name,
 					versionID: version.VersionID,
-				}, madmin.HealItemObject); err != nil {
-					if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
-						logger.LogIf(ctx, err)
-					}
+				}, madmin.HealItemObject)
+				if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+					// Object was deleted in the meantime.
+					continue
 				}
+				logger.LogIf(ctx, err)
+				tracker.objectHealed(version.Size, err)
+				tracker.update(ctx)
 			}
 		}
+		tracker.bucketHealed(ctx, bucket.Name)
 	}
 
 	return nil
@@ -383,7 +383,7 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 
 		statsWriter := logger.NewResponseWriter(w)
 
-		f.ServeHTTP(statsWriter, r)
+		logBucketAccess(statsWriter, r, f)
 
 		globalHTTPStats.updateStats(api, r, statsWriter)
 	}
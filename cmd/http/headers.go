@@ -41,6 +41,20 @@ const (
 	Range              = "Range"
 )
 
+// Standard CORS HTTP header constants
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+)
+
 // Non standard S3 HTTP response constants
 const (
 	XCache       = "X-Cache"
@@ -91,6 +105,14 @@ const (
 	// Multipart parts count
 	AmzMpPartsCount = "x-amz-mp-parts-count"
 
+	// S3 additional checksums
+	AmzChecksumMode = "X-Amz-Checksum-Mode"
+
+	// S3 object attributes
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
+
 	// Object date/time of expiration
 	AmzExpiration = "x-amz-expiration"
 
@@ -31,6 +31,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/cmd/config/api"
+	xtls "github.com/minio/minio/cmd/config/identity/tls"
 	"github.com/minio/minio/pkg/certs"
 	"github.com/minio/minio/pkg/env"
 )
@@ -194,6 +195,12 @@ func NewServer(addrs []string, handler http.Handler, getCert certs.GetCertificat
 			NextProtos:               []string{"h2", "http/1.1"},
 		}
 		tlsConfig.GetCertificate = getCert
+
+		// Client certificates are verified by the STS API,
+		// clients without certificate are still accepted.
+		if xtls.IsEnabled() {
+			tlsConfig.ClientAuth = tls.RequestClientCert
+		}
 	}
 
 	if secureCiphers && tlsConfig != nil {
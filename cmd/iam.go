@@ -33,6 +33,7 @@ import (
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -218,6 +219,9 @@ type IAMSys struct {
 	iamUserPolicyMap map[string]MappedPolicy
 	// map of group names to policy names
 	iamGroupPolicyMap map[string]MappedPolicy
+	// true if any policy has a condition on existing object tags,
+	// updated whenever the policies change
+	iamPolicyExistingObjectTag bool
 
 	// Persistence layer for IAM subsystem
 	store         IAMStorageAPI
@@ -334,7 +338,9 @@ func (sys *IAMSys) LoadPolicy(objAPI ObjectLayer, policyName string) error {
 	defer sys.store.unlock()
 
 	if globalEtcdClient == nil {
-		return sys.store.loadPolicyDoc(context.Background(), policyName, sys.iamPolicyDocsMap)
+		err := sys.store.loadPolicyDoc(context.Background(), policyName, sys.iamPolicyDocsMap)
+		sys.updatePolicyConditionKeys()
+		return err
 	}
 
 	// When etcd is set, we use watch APIs so this code is not needed.
@@ -500,6 +506,7 @@ func (sys *IAMSys) Load(ctx context.Context, store IAMStorageAPI) error {
 	for k, v := range iamPolicyDocsMap {
 		sys.iamPolicyDocsMap[k] = v
 	}
+	sys.updatePolicyConditionKeys()
 
 	// Merge the new reloaded entries into global map.
 	// See issue https://github.com/minio/minio/issues/9651
@@ -677,6 +684,7 @@ func (sys *IAMSys) DeletePolicy(policyName string) error {
 	}
 
 	delete(sys.iamPolicyDocsMap, policyName)
+	sys.updatePolicyConditionKeys()
 
 	// Delete user-policy mappings that will no longer apply
 	for u, mp := range sys.iamUserPolicyMap {
@@ -771,6 +779,7 @@ func (sys *IAMSys) SetPolicy(policyName string, p iampolicy.Policy) error {
 	}
 
 	sys.iamPolicyDocsMap[policyName] = p
+	sys.updatePolicyConditionKeys()
 	return nil
 }
 
@@ -1322,6 +1331,7 @@ func (sys *IAMSys) loadUserFromStore(accessKey string) {
 			sys.store.loadPolicyDoc(context.Background(), policy, sys.iamPolicyDocsMap)
 		}
 	}
+	sys.updatePolicyConditionKeys()
 
 	sys.buildUserGroupMemberships()
 	sys.store.unlock()
@@ -2019,6 +2029,94 @@ func (sys *IAMSys) GetCombinedPolicy(policies ...string) iampolicy.Policy {
 	return combinedPolicy
 }
 
+// simulatedPolicy - a policy evaluated by the policy simulator, with the
+// user, group or bucket it applies from.
+type simulatedPolicy struct {
+	source     string
+	name       string
+	policyName string
+	policy     iampolicy.Policy
+}
+
+// cannedPoliciesOf - returns the canned policies evaluated for the requests
+// of the credentials, with the user or group they are attached to.
+func (sys *IAMSys) cannedPoliciesOf(cred auth.Credentials, claims map[string]interface{}) []simulatedPolicy {
+	sys.store.rlock()
+	defer sys.store.runlock()
+
+	var policies []simulatedPolicy
+	add := func(source, name string, policyNames []string) {
+		for _, pname := range policyNames {
+			if p, found := sys.iamPolicyDocsMap[pname]; found {
+				policies = append(policies, simulatedPolicy{source, name, pname, p})
+			}
+		}
+	}
+
+	switch {
+	case cred.IsTemp() && sys.usersSysType == LDAPUsersSysType:
+		user, _ := claims[ldapUser].(string)
+		add(madmin.PolicySourceUser, user, sys.iamUserPolicyMap[user].toSlice())
+		for _, group := range cred.Groups {
+			add(madmin.PolicySourceGroup, group, sys.iamGroupPolicyMap[group].toSlice())
+		}
+	case cred.IsTemp():
+		policySet, _ := iampolicy.GetPoliciesFromClaims(claims, iamPolicyClaimNameOpenID())
+		add(madmin.PolicySourceSTS, cred.AccessKey, policySet.ToSlice())
+	default:
+		// Service accounts are evaluated with the policies of their parent.
+		name := cred.AccessKey
+		if cred.IsServiceAccount() {
+			name = cred.ParentUser
+		}
+		if u, ok := sys.iamUsersMap[name]; !ok || u.Status == statusDisabled {
+			return nil
+		}
+		add(madmin.PolicySourceUser, name, sys.iamUserPolicyMap[name].toSlice())
+		for _, group := range sys.iamUserGroupMemberships[name].ToSlice() {
+			if gi, ok := sys.iamGroupsMap[group]; !ok || gi.Status == statusDisabled {
+				continue
+			}
+			add(madmin.PolicySourceGroup, group, sys.iamGroupPolicyMap[group].toSlice())
+		}
+	}
+
+	return policies
+}
+
+// updatePolicyConditionKeys - records whether any policy has a condition
+// on existing object tags, called with the store locked whenever the
+// policies change.
+func (sys *IAMSys) updatePolicyConditionKeys() {
+	sys.iamPolicyExistingObjectTag = false
+	for _, p := range sys.iamPolicyDocsMap {
+		if hasConditionKey(p.ConditionKeys(), condition.S3ExistingObjectTag) {
+			sys.iamPolicyExistingObjectTag = true
+			return
+		}
+	}
+}
+
+// hasExistingObjectTagCondition - returns true if any policy, or the
+// session policy in the claims, has a condition on existing object tags.
+func (sys *IAMSys) hasExistingObjectTagCondition(claims map[string]interface{}) bool {
+	if !sys.Initialized() {
+		return false
+	}
+
+	if spolicy, ok := claims[iampolicy.SessionPolicyName].(string); ok {
+		subPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(spolicy)))
+		if err == nil && hasConditionKey(subPolicy.ConditionKeys(), condition.S3ExistingObjectTag) {
+			return true
+		}
+	}
+
+	sys.store.rlock()
+	defer sys.store.runlock()
+
+	return sys.iamPolicyExistingObjectTag
+}
+
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	// If opa is configured, use OPA always.
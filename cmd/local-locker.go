@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -229,6 +230,27 @@ func (l *localLocker) ForceUnlock(ctx context.Context, args dsync.LockArgs) (rep
 		if len(args.UID) != 0 {
 			return false, fmt.Errorf("ForceUnlock called with non-empty UID: %s", args.UID)
 		}
+		if len(args.Resources) == 0 {
+			if args.Owner == "" {
+				return false, errors.New("ForceUnlock called without resources and owner")
+			}
+			// Remove all the locks of the owner, such as a server that went down.
+			for resource, lris := range l.lockMap {
+				var kept []lockRequesterInfo
+				for _, lri := range lris {
+					if lri.Owner != args.Owner {
+						kept = append(kept, lri)
+					}
+				}
+				switch {
+				case len(kept) == 0:
+					delete(l.lockMap, resource)
+				case len(kept) != len(lris):
+					l.lockMap[resource] = kept
+				}
+			}
+			return true, nil
+		}
 		for _, resource := range args.Resources {
 			delete(l.lockMap, resource) // Remove the lock (irrespective of write or read lock)
 		}
@@ -236,6 +258,28 @@ func (l *localLocker) ForceUnlock(ctx context.Context, args dsync.LockArgs) (rep
 	}
 }
 
+// ListLocks returns the locks held on this server.
+func (l *localLocker) ListLocks(ctx context.Context) ([]dsync.LockInfo, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var locks []dsync.LockInfo
+	for _, lris := range l.lockMap {
+		for _, lri := range lris {
+			locks = append(locks, dsync.LockInfo{
+				Resource:  lri.Name,
+				Writer:    lri.Writer,
+				UID:       lri.UID,
+				Owner:     lri.Owner,
+				Source:    lri.Source,
+				Quorum:    lri.Quorum,
+				Timestamp: lri.Timestamp,
+			})
+		}
+	}
+	return locks, nil
+}
+
 func (l *localLocker) Expired(ctx context.Context, args dsync.LockArgs) (expired bool, err error) {
 	select {
 	case <-ctx.Done():
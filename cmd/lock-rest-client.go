@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/gob"
 	"io"
 	"net/url"
 	"strconv"
@@ -140,11 +141,38 @@ func (client *lockRESTClient) ForceUnlock(ctx context.Context, args dsync.LockAr
 	return client.restCall(ctx, lockRESTMethodForceUnlock, args)
 }
 
-func newLockAPI(endpoint Endpoint) dsync.NetLocker {
-	if endpoint.IsLocal {
+// ListLocks calls list locks handler to fetch the locks held on the server.
+func (client *lockRESTClient) ListLocks(ctx context.Context) (locks []dsync.LockInfo, err error) {
+	respBody, err := client.callWithContext(ctx, lockRESTMethodListLocks, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&locks)
+	return locks, err
+}
+
+// lockBackendREST is the name of the lock backend served by the
+// lock REST servers of the cluster, used by default.
+const lockBackendREST = "rest"
+
+func init() {
+	dsync.RegisterBackend(lockBackendREST, newLockRESTAPI)
+}
+
+// newLockRESTAPI returns the locker of the lock REST server at the endpoint.
+func newLockRESTAPI(endpoint *url.URL, local bool) dsync.NetLocker {
+	if local {
 		return globalLockServer
 	}
-	return newlockRESTClient(endpoint)
+	return newlockRESTClient(Endpoint{URL: endpoint, IsLocal: local})
+}
+
+func newLockAPI(endpoint Endpoint) dsync.NetLocker {
+	return globalLockBackend(&url.URL{
+		Scheme: endpoint.Scheme,
+		Host:   endpoint.Host,
+	}, endpoint.IsLocal)
 }
 
 // Returns a lock rest client.
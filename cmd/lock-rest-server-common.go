@@ -21,7 +21,7 @@ import (
 )
 
 const (
-	lockRESTVersion       = "v5" // Add Quorum query param
+	lockRESTVersion       = "v6" // Add ListLocks method
 	lockRESTVersionPrefix = SlashSeparator + lockRESTVersion
 	lockRESTPrefix        = minioReservedBucketPath + "/lock"
 )
@@ -34,6 +34,7 @@ const (
 	lockRESTMethodRUnlock     = "/runlock"
 	lockRESTMethodExpired     = "/expired"
 	lockRESTMethodForceUnlock = "/force-unlock"
+	lockRESTMethodListLocks   = "/list-locks"
 
 	// lockRESTOwner represents owner UUID
 	lockRESTOwner = "owner"
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"reflect"
 	"sync"
@@ -110,3 +111,41 @@ func TestLockRpcServerRemoveEntry(t *testing.T) {
 		}
 	}
 }
+
+// Test function to release all the locks of an owner
+func TestLockRpcServerForceUnlockOwner(t *testing.T) {
+	testPath, locker, _ := createLockTestServer(t)
+	defer os.RemoveAll(testPath)
+
+	ctx := context.Background()
+	for _, args := range []dsync.LockArgs{
+		{UID: "1", Owner: "owner1", Resources: []string{"name1", "name2"}},
+		{UID: "2", Owner: "owner1", Resources: []string{"name3"}},
+		{UID: "3", Owner: "owner2", Resources: []string{"name3"}},
+	} {
+		var err error
+		if len(args.Resources) > 1 {
+			_, err = locker.ll.Lock(ctx, args)
+		} else {
+			_, err = locker.ll.RLock(ctx, args)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := locker.ll.ForceUnlock(ctx, dsync.LockArgs{}); err == nil {
+		t.Fatal("expected an error without resources and owner")
+	}
+	if _, err := locker.ll.ForceUnlock(ctx, dsync.LockArgs{Owner: "owner1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	locks, err := locker.ll.ListLocks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 || locks[0].Owner != "owner2" || locks[0].Resource != "name3" || locks[0].Writer {
+		t.Fatalf("expected the read lock of owner2 only, got %+v", locks)
+	}
+}
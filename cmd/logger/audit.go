@@ -172,3 +172,24 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 		_ = t.Send(entry, string(All))
 	}
 }
+
+// AuditLogInternal - logs operations performed by the server itself, e.g
+// by lifecycle rules, to all audit targets.
+func AuditLogInternal(ctx context.Context, api, bucket, object string) {
+	// Fast exit if there is not audit target configured
+	if len(AuditTargets) == 0 {
+		return
+	}
+
+	entry := audit.NewEntry(globalDeploymentID)
+	entry.API.Name = api
+	entry.API.Bucket = bucket
+	entry.API.Object = object
+	if reqInfo := GetReqInfo(ctx); reqInfo != nil {
+		entry.Tags = reqInfo.GetTagsMap()
+	}
+
+	for _, t := range AuditTargets {
+		_ = t.Send(entry, string(All))
+	}
+}
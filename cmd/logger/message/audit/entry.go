@@ -52,6 +52,15 @@ type Entry struct {
 	Tags       map[string]interface{} `json:"tags,omitempty"`
 }
 
+// NewEntry - constructs an audit entry object with the version and time set.
+func NewEntry(deploymentID string) Entry {
+	return Entry{
+		Version:      Version,
+		DeploymentID: deploymentID,
+		Time:         time.Now().UTC().Format(time.RFC3339Nano),
+	}
+}
+
 // ToEntry - constructs an audit entry object.
 func ToEntry(w http.ResponseWriter, r *http.Request, reqClaims map[string]interface{}, deploymentID string) Entry {
 	q := r.URL.Query()
@@ -72,18 +81,14 @@ func ToEntry(w http.ResponseWriter, r *http.Request, reqClaims map[string]interf
 		respHeader[xhttp.ETag] = strings.Trim(etag, `"`)
 	}
 
-	entry := Entry{
-		Version:      Version,
-		DeploymentID: deploymentID,
-		RemoteHost:   handlers.GetSourceIP(r),
-		RequestID:    wh.Get(xhttp.AmzRequestID),
-		UserAgent:    r.UserAgent(),
-		Time:         time.Now().UTC().Format(time.RFC3339Nano),
-		ReqQuery:     reqQuery,
-		ReqHeader:    reqHeader,
-		ReqClaims:    reqClaims,
-		RespHeader:   respHeader,
-	}
+	entry := NewEntry(deploymentID)
+	entry.RemoteHost = handlers.GetSourceIP(r)
+	entry.RequestID = wh.Get(xhttp.AmzRequestID)
+	entry.UserAgent = r.UserAgent()
+	entry.ReqQuery = reqQuery
+	entry.ReqHeader = reqHeader
+	entry.ReqClaims = reqClaims
+	entry.RespHeader = respHeader
 
 	return entry
 }
@@ -23,6 +23,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
@@ -55,12 +56,15 @@ const (
 	diskSubsystem           MetricSubsystem = "disk"
 	fileDescriptorSubsystem MetricSubsystem = "file_descriptor"
 	goRoutines              MetricSubsystem = "go_routine"
+	ilmSubsystem            MetricSubsystem = "ilm"
 	ioSubsystem             MetricSubsystem = "io"
 	nodesSubsystem          MetricSubsystem = "nodes"
 	objectsSubsystem        MetricSubsystem = "objects"
 	processSubsystem        MetricSubsystem = "process"
 	replicationSubsystem    MetricSubsystem = "replication"
+	replicationTgtSubsystem MetricSubsystem = "replication_target"
 	requestsSubsystem       MetricSubsystem = "requests"
+	scrubSubsystem          MetricSubsystem = "scrub"
 	timeSubsystem           MetricSubsystem = "time"
 	trafficSubsystem        MetricSubsystem = "traffic"
 	softwareSubsystem       MetricSubsystem = "software"
@@ -78,11 +82,15 @@ const (
 	inflightTotal MetricName = "inflight_total"
 	limitTotal    MetricName = "limit_total"
 	missedTotal   MetricName = "missed_total"
+	abortedTotal  MetricName = "aborted_uploads_total"
 	objectTotal   MetricName = "object_total"
 	offlineTotal  MetricName = "offline_total"
 	onlineTotal   MetricName = "online_total"
 	openTotal     MetricName = "open_total"
+	queuedTotal   MetricName = "queued_total"
 	readTotal     MetricName = "read_total"
+	replayedTotal MetricName = "replayed_total"
+	spilledTotal  MetricName = "spilled_total"
 	writeTotal    MetricName = "write_total"
 	total         MetricName = "total"
 
@@ -98,6 +106,12 @@ const (
 	writeBytes    MetricName = "write_bytes"
 	wcharBytes    MetricName = "wchar_bytes"
 
+	completedBytes MetricName = "completed_bytes"
+	completedTotal MetricName = "completed_total"
+	failedTotal    MetricName = "failed_total"
+	inflightBytes  MetricName = "inflight_bytes"
+	queuedBytes    MetricName = "queued_bytes"
+
 	usagePercent MetricName = "update_percent"
 
 	commitInfo  MetricName = "commit_info"
@@ -108,6 +122,8 @@ const (
 	ttfbDistribution = "ttbf_seconds_distribution"
 
 	lastActivityTime = "last_activity_nano_seconds"
+	lastSuccessTime  = "last_success_seconds"
+	latencyMilliSec  = "latency_ms"
 	startTime        = "starttime_seconds"
 )
 
@@ -176,11 +192,15 @@ func GetGeneratorsForPeer() []MetricsGenerator {
 		getCacheMetrics,
 		getGoMetrics,
 		getHTTPMetrics,
+		getILMMetrics,
 		getLocalStorageMetrics,
 		getMinioProcMetrics,
 		getMinioVersionMetrics,
 		getNetworkMetrics,
+		getReplicationQueueMetrics,
+		getReplicationTargetMetrics,
 		getS3TTFBMetric,
+		getScrubMetrics,
 	}
 	return g
 }
@@ -191,9 +211,13 @@ func GetSingleNodeGenerators() []MetricsGenerator {
 		getNodeHealthMetrics,
 		getCacheMetrics,
 		getHTTPMetrics,
+		getILMMetrics,
 		getNetworkMetrics,
 		getMinioVersionMetrics,
+		getReplicationQueueMetrics,
+		getReplicationTargetMetrics,
 		getS3TTFBMetric,
+		getScrubMetrics,
 	}
 	return g
 }
@@ -336,6 +360,78 @@ func getBucketRepReceivedBytesMD() MetricDescription {
 		Type:      gaugeMetric,
 	}
 }
+func getNodeRepQueuedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      queuedTotal,
+		Help:      "Total number of replication tasks waiting in the queue.",
+		Type:      gaugeMetric,
+	}
+}
+func getNodeILMAbortedUploadsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      abortedTotal,
+		Help:      "Total number of incomplete multipart uploads aborted by lifecycle rules.",
+		Type:      counterMetric,
+	}
+}
+func getNodeScrubObjectsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: scrubSubsystem,
+		Name:      objectTotal,
+		Help:      "Total number of object versions verified by the bitrot scrub of the local drives.",
+		Type:      counterMetric,
+	}
+}
+func getNodeScrubReadBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: scrubSubsystem,
+		Name:      readBytes,
+		Help:      "Total bytes verified by the bitrot scrub of the local drives.",
+		Type:      counterMetric,
+	}
+}
+func getNodeScrubErrorsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: scrubSubsystem,
+		Name:      errorsTotal,
+		Help:      "Total number of corrupted or missing shards found by the bitrot scrub of the local drives.",
+		Type:      counterMetric,
+	}
+}
+func getNodeRepSpilledTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      spilledTotal,
+		Help:      "Total number of replication tasks left in the journal as the queue was full.",
+		Type:      counterMetric,
+	}
+}
+func getNodeRepReplayedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      replayedTotal,
+		Help:      "Total number of replication tasks queued from the journal.",
+		Type:      counterMetric,
+	}
+}
+func getRepTargetMD(name MetricName, help string, typ GaugeMetricType) MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationTgtSubsystem,
+		Name:      name,
+		Help:      help,
+		Type:      typ,
+	}
+}
 func getBucketObjectDistributionMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -738,6 +834,92 @@ func getGoMetrics() MetricsGroup {
 		},
 	}
 }
+func getReplicationQueueMetrics() MetricsGroup {
+	return MetricsGroup{
+		Metrics: []Metric{},
+		initialize: func(ctx context.Context, metrics *MetricsGroup) {
+			if globalReplicationState == nil {
+				return
+			}
+			metrics.Metrics = append(metrics.Metrics, Metric{
+				Description: getNodeRepQueuedTotalMD(),
+				Value:       float64(globalReplicationState.queued()),
+			})
+			metrics.Metrics = append(metrics.Metrics, Metric{
+				Description: getNodeRepSpilledTotalMD(),
+				Value:       float64(atomic.LoadUint64(&globalReplicationState.spilled)),
+			})
+			metrics.Metrics = append(metrics.Metrics, Metric{
+				Description: getNodeRepReplayedTotalMD(),
+				Value:       float64(atomic.LoadUint64(&globalReplicationState.replayed)),
+			})
+		},
+	}
+}
+func getILMMetrics() MetricsGroup {
+	return MetricsGroup{
+		Metrics: []Metric{},
+		initialize: func(ctx context.Context, metrics *MetricsGroup) {
+			metrics.Metrics = append(metrics.Metrics, Metric{
+				Description: getNodeILMAbortedUploadsTotalMD(),
+				Value:       float64(atomic.LoadUint64(&globalILMAbortedUploads)),
+			})
+		},
+	}
+}
+func getScrubMetrics() MetricsGroup {
+	return MetricsGroup{
+		Metrics: []Metric{},
+		initialize: func(ctx context.Context, metrics *MetricsGroup) {
+			if !globalIsErasure {
+				return
+			}
+			metrics.Metrics = append(metrics.Metrics, Metric{
+				Description: getNodeScrubObjectsTotalMD(),
+				Value:       float64(atomic.LoadUint64(&globalScrubStats.objects)),
+			})
+			metrics.Metrics = append(metrics.Metrics, Metric{
+				Description: getNodeScrubReadBytesMD(),
+				Value:       float64(atomic.LoadUint64(&globalScrubStats.bytes)),
+			})
+			metrics.Metrics = append(metrics.Metrics, Metric{
+				Description: getNodeScrubErrorsTotalMD(),
+				Value:       float64(atomic.LoadUint64(&globalScrubStats.corrupted)),
+			})
+		},
+	}
+}
+func getReplicationTargetMetrics() MetricsGroup {
+	return MetricsGroup{
+		Metrics: []Metric{},
+		initialize: func(ctx context.Context, metrics *MetricsGroup) {
+			for bucket, m := range globalReplicationStats.getAll() {
+				for arn, t := range m.Targets {
+					labels := map[string]string{"bucket": bucket, "targetArn": arn}
+					add := func(md MetricDescription, value float64) {
+						metrics.Metrics = append(metrics.Metrics, Metric{
+							Description:    md,
+							Value:          value,
+							VariableLabels: labels,
+						})
+					}
+					add(getRepTargetMD(queuedTotal, "Number of objects queued for replication to the target.", gaugeMetric), float64(t.QueuedCount))
+					add(getRepTargetMD(queuedBytes, "Total bytes queued for replication to the target.", gaugeMetric), float64(t.QueuedSize))
+					add(getRepTargetMD(inflightTotal, "Number of objects being replicated to the target.", gaugeMetric), float64(t.InflightCount))
+					add(getRepTargetMD(inflightBytes, "Total bytes being replicated to the target.", gaugeMetric), float64(t.InflightSize))
+					add(getRepTargetMD(failedTotal, "Number of objects which failed to replicate to the target.", counterMetric), float64(t.FailedCount))
+					add(getRepTargetMD(failedBytes, "Total bytes which failed to replicate to the target.", counterMetric), float64(t.FailedSize))
+					add(getRepTargetMD(completedTotal, "Number of objects replicated to the target.", counterMetric), float64(t.CompletedCount))
+					add(getRepTargetMD(completedBytes, "Total bytes replicated to the target.", counterMetric), float64(t.CompletedSize))
+					add(getRepTargetMD(latencyMilliSec, "Moving average of the round trip time of requests to the target in milliseconds.", gaugeMetric), float64(t.Latency)/float64(time.Millisecond))
+					if !t.LastReplicationTime.IsZero() {
+						add(getRepTargetMD(lastSuccessTime, "Time of the last successful replication to the target in seconds since epoch.", gaugeMetric), float64(t.LastReplicationTime.Unix()))
+					}
+				}
+			}
+		},
+	}
+}
 func getS3TTFBMetric() MetricsGroup {
 	return MetricsGroup{
 		Metrics: []Metric{},
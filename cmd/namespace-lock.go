@@ -36,6 +36,9 @@ import (
 // local lock servers
 var globalLockServer *localLocker
 
+// lock backend of the erasure sets, selected with MINIO_LOCK_BACKEND.
+var globalLockBackend dsync.NewLockerFunc = newLockRESTAPI
+
 // RWLocker - locker interface to introduce GetRLock, RUnlock.
 type RWLocker interface {
 	GetLock(ctx context.Context, timeout *dynamicTimeout) (timedOutErr error)
@@ -671,6 +671,7 @@ func (sys *NotificationSys) LoadBucketMetadata(ctx context.Context, bucketName s
 // DeleteBucketMetadata - calls DeleteBucketMetadata call on all peers
 func (sys *NotificationSys) DeleteBucketMetadata(ctx context.Context, bucketName string) {
 	globalBucketMetadataSys.Remove(bucketName)
+	globalReplicationStats.delete(bucketName)
 	if localMetacacheMgr != nil {
 		localMetacacheMgr.deleteBucketCache(bucketName)
 	}
@@ -1449,6 +1450,62 @@ func (sys *NotificationSys) GetBandwidthReports(ctx context.Context, buckets ...
 	return consolidatedReport
 }
 
+// GetReplicationMetrics - gets the live replication metrics of the bucket from all nodes including self.
+func (sys *NotificationSys) GetReplicationMetrics(ctx context.Context, bucket string) madmin.BucketReplicationMetrics {
+	metrics := make([]madmin.BucketReplicationMetrics, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			metrics[index], err = sys.peerClients[index].GetReplicationMetrics(ctx, bucket)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+			sys.peerClients[index].host.String())
+		ctx := logger.SetReqInfo(ctx, reqInfo)
+		logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+	}
+	metrics = append(metrics, globalReplicationStats.getBucket(bucket))
+	return mergeBucketReplicationMetrics(bucket, metrics...)
+}
+
+// LoadTransitionTierConfig - reloads the remote tiers on all peers.
+func (sys *NotificationSys) LoadTransitionTierConfig(ctx context.Context) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.LoadTransitionTierConfig(ctx)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// ReloadPoolMeta - reloads the status of the server pools on all peers.
+func (sys *NotificationSys) ReloadPoolMeta(ctx context.Context) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ReloadPoolMeta(ctx)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // GetClusterMetrics - gets the cluster metrics from all nodes excluding self.
 func (sys *NotificationSys) GetClusterMetrics(ctx context.Context) chan Metric {
 	g := errgroup.WithNErrs(len(sys.peerClients))
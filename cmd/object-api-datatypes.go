@@ -183,6 +183,11 @@ type ObjectInfo struct {
 
 	// TransitionStatus indicates if transition is complete/pending
 	TransitionStatus string
+	// TransitionedObjName is the name of the object on the remote tier
+	// holding the data of a transitioned version.
+	TransitionedObjName string
+	// TransitionTier is the name of the remote tier of a transitioned version.
+	TransitionTier string
 
 	// RestoreExpires indicates date a restored object expires
 	RestoreExpires time.Time
@@ -247,40 +252,42 @@ type ObjectInfo struct {
 // Clone - Returns a cloned copy of current objectInfo
 func (o ObjectInfo) Clone() (cinfo ObjectInfo) {
 	cinfo = ObjectInfo{
-		Bucket:             o.Bucket,
-		Name:               o.Name,
-		ModTime:            o.ModTime,
-		Size:               o.Size,
-		IsDir:              o.IsDir,
-		ETag:               o.ETag,
-		InnerETag:          o.InnerETag,
-		VersionID:          o.VersionID,
-		IsLatest:           o.IsLatest,
-		DeleteMarker:       o.DeleteMarker,
-		TransitionStatus:   o.TransitionStatus,
-		RestoreExpires:     o.RestoreExpires,
-		RestoreOngoing:     o.RestoreOngoing,
-		ContentType:        o.ContentType,
-		ContentEncoding:    o.ContentEncoding,
-		Expires:            o.Expires,
-		CacheStatus:        o.CacheStatus,
-		CacheLookupStatus:  o.CacheLookupStatus,
-		StorageClass:       o.StorageClass,
-		ReplicationStatus:  o.ReplicationStatus,
-		UserTags:           o.UserTags,
-		Parts:              o.Parts,
-		Writer:             o.Writer,
-		Reader:             o.Reader,
-		PutObjReader:       o.PutObjReader,
-		metadataOnly:       o.metadataOnly,
-		versionOnly:        o.versionOnly,
-		keyRotation:        o.keyRotation,
-		backendType:        o.backendType,
-		AccTime:            o.AccTime,
-		Legacy:             o.Legacy,
-		VersionPurgeStatus: o.VersionPurgeStatus,
-		NumVersions:        o.NumVersions,
-		SuccessorModTime:   o.SuccessorModTime,
+		Bucket:              o.Bucket,
+		Name:                o.Name,
+		ModTime:             o.ModTime,
+		Size:                o.Size,
+		IsDir:               o.IsDir,
+		ETag:                o.ETag,
+		InnerETag:           o.InnerETag,
+		VersionID:           o.VersionID,
+		IsLatest:            o.IsLatest,
+		DeleteMarker:        o.DeleteMarker,
+		TransitionStatus:    o.TransitionStatus,
+		TransitionedObjName: o.TransitionedObjName,
+		TransitionTier:      o.TransitionTier,
+		RestoreExpires:      o.RestoreExpires,
+		RestoreOngoing:      o.RestoreOngoing,
+		ContentType:         o.ContentType,
+		ContentEncoding:     o.ContentEncoding,
+		Expires:             o.Expires,
+		CacheStatus:         o.CacheStatus,
+		CacheLookupStatus:   o.CacheLookupStatus,
+		StorageClass:        o.StorageClass,
+		ReplicationStatus:   o.ReplicationStatus,
+		UserTags:            o.UserTags,
+		Parts:               o.Parts,
+		Writer:              o.Writer,
+		Reader:              o.Reader,
+		PutObjReader:        o.PutObjReader,
+		metadataOnly:        o.metadataOnly,
+		versionOnly:         o.versionOnly,
+		keyRotation:         o.keyRotation,
+		backendType:         o.backendType,
+		AccTime:             o.AccTime,
+		Legacy:              o.Legacy,
+		VersionPurgeStatus:  o.VersionPurgeStatus,
+		NumVersions:         o.NumVersions,
+		SuccessorModTime:    o.SuccessorModTime,
 	}
 	cinfo.UserDefined = make(map[string]string, len(o.UserDefined))
 	for k, v := range o.UserDefined {
@@ -522,6 +529,13 @@ type CompletePart struct {
 
 	// Entity tag returned when the part was uploaded.
 	ETag string
+
+	// Additional checksum returned when the part was uploaded, at most
+	// one of them is set.
+	ChecksumCRC32  string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumSHA1   string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256,omitempty"`
 }
 
 // CompletedParts - is a collection satisfying sort.Interface.
@@ -366,6 +366,27 @@ func (e BucketSSEConfigNotFound) Error() string {
 	return "No bucket encryption configuration found for bucket: " + e.Bucket
 }
 
+// BucketCorsNotFound - no bucket CORS config found
+type BucketCorsNotFound GenericError
+
+func (e BucketCorsNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket: " + e.Bucket
+}
+
+// BucketWebsiteNotFound - no bucket website config found
+type BucketWebsiteNotFound GenericError
+
+func (e BucketWebsiteNotFound) Error() string {
+	return "No bucket website configuration found for bucket: " + e.Bucket
+}
+
+// BucketLoggingNotFound - no bucket logging config found
+type BucketLoggingNotFound GenericError
+
+func (e BucketLoggingNotFound) Error() string {
+	return "No bucket logging configuration found for bucket: " + e.Bucket
+}
+
 // BucketTaggingNotFound - no bucket tags found
 type BucketTaggingNotFound GenericError
 
@@ -387,6 +408,20 @@ func (e BucketQuotaConfigNotFound) Error() string {
 	return "No quota config found for bucket : " + e.Bucket
 }
 
+// BucketStorageClassConfigNotFound - no bucket storage class config found.
+type BucketStorageClassConfigNotFound GenericError
+
+func (e BucketStorageClassConfigNotFound) Error() string {
+	return "No storage class config found for bucket: " + e.Bucket
+}
+
+// BucketCompressionConfigNotFound - no bucket compression config found.
+type BucketCompressionConfigNotFound GenericError
+
+func (e BucketCompressionConfigNotFound) Error() string {
+	return "No compression config found for bucket: " + e.Bucket
+}
+
 // BucketQuotaExceeded - bucket quota exceeded.
 type BucketQuotaExceeded GenericError
 
@@ -478,6 +513,20 @@ func (e BucketReplicationSourceNotVersioned) Error() string {
 	return "Replication source does not have versioning enabled: " + e.Bucket
 }
 
+// BucketReplicationResyncInProgress replication resync to the remote target is already running.
+type BucketReplicationResyncInProgress GenericError
+
+func (e BucketReplicationResyncInProgress) Error() string {
+	return "Replication resync already in progress: " + e.Bucket
+}
+
+// BucketReplicationResyncNotFound no replication resync was started for the remote target.
+type BucketReplicationResyncNotFound GenericError
+
+func (e BucketReplicationResyncNotFound) Error() string {
+	return "Replication resync not found: " + e.Bucket
+}
+
 /// Bucket related errors.
 
 // BucketNameInvalid - bucketname provided is invalid.
@@ -25,6 +25,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -51,10 +52,15 @@ type ObjectOptions struct {
 	DeleteMarkerReplicationStatus string                                                // Is only set in DELETE operations
 	VersionPurgeStatus            VersionPurgeStatusType                                // Is only set in DELETE operations for delete marker version to be permanently deleted.
 	TransitionStatus              string                                                // status of the transition
+	TransitionedObjName           string                                                // only set when completing a transition, name of the object on the remote tier
+	TransitionTier                string                                                // only set when completing a transition, name of the remote tier
 	NoLock                        bool                                                  // indicates to lower layers if the caller is expecting to hold locks.
 	ProxyRequest                  bool                                                  // only set for GET/HEAD in active-active replication scenario
 	ProxyHeaderSet                bool                                                  // only set for GET/HEAD in active-active replication scenario
 	ParentIsObject                func(ctx context.Context, bucket, parent string) bool // Used to verify if parent is an object.
+	IndexCB                       func() []byte                                         // Returns the index of the compressed data, only called once the data is written.
+	ChecksumCB                    func() *hash.Checksum                                 // Returns the additional checksum of the data, only called once the data is written.
+	WantChecksum                  bool                                                  // only set in GetObject/HeadObject to return the additional checksum of the object
 }
 
 // BucketOptions represents bucket options for ObjectLayer bucket operations
@@ -127,6 +127,7 @@ func getOpts(ctx context.Context, r *http.Request, bucket, object string) (Objec
 	}
 	opts.PartNumber = partNumber
 	opts.VersionID = vid
+	opts.WantChecksum = strings.EqualFold(r.Header.Get(xhttp.AmzChecksumMode), "ENABLED")
 	delMarker := strings.TrimSpace(r.Header.Get(xhttp.MinIOSourceDeleteMarker))
 	if delMarker != "" {
 		switch delMarker {
@@ -35,6 +35,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
 	"github.com/klauspost/readahead"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio/cmd/config/compress"
@@ -46,6 +47,7 @@ import (
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/trie"
 	"github.com/minio/minio/pkg/wildcard"
 )
@@ -256,8 +258,9 @@ func getCompleteMultipartMD5(parts []CompletePart) string {
 func cleanMetadata(metadata map[string]string) map[string]string {
 	// Remove STANDARD StorageClass
 	metadata = removeStandardStorageClass(metadata)
-	// Clean meta etag keys 'md5Sum', 'etag', "expires", "x-amz-tagging".
-	return cleanMetadataKeys(metadata, "md5Sum", "etag", "expires", xhttp.AmzObjectTagging)
+	// Clean meta etag keys 'md5Sum', 'etag', "expires", "x-amz-tagging"
+	// and the inline data marker.
+	return cleanMetadataKeys(metadata, "md5Sum", "etag", "expires", xhttp.AmzObjectTagging, inlineDataKey)
 }
 
 // Filter X-Amz-Storage-Class field only if it is set to STANDARD.
@@ -395,7 +398,7 @@ func (o ObjectInfo) IsCompressedOK() (bool, error) {
 		return false, nil
 	}
 	switch scheme {
-	case compressionAlgorithmV1, compressionAlgorithmV2:
+	case compressionAlgorithmV1, compressionAlgorithmV2, compressionAlgorithmZstd:
 		return true, nil
 	}
 	return true, fmt.Errorf("unknown compression scheme: %s", scheme)
@@ -433,10 +436,8 @@ func (o ObjectInfo) GetActualSize() (int64, error) {
 // Disabling compression for encrypted enabled requests.
 // Using compression and encryption together enables room for side channel attacks.
 // Eliminate non-compressible objects by extensions/content-types.
-func isCompressible(header http.Header, object string) bool {
-	globalCompressConfigMu.Lock()
-	cfg := globalCompressConfig
-	globalCompressConfigMu.Unlock()
+func isCompressible(header http.Header, bucket, object string) bool {
+	cfg, _ := bucketCompressionConfig(bucket)
 
 	_, ok := crypto.IsRequested(header)
 	if !cfg.Enabled || (ok && !cfg.AllowEncrypted) || excludeForCompression(header, object, cfg) {
@@ -519,7 +520,13 @@ func partNumberToRangeSpec(oi ObjectInfo, partNumber int) *HTTPRangeSpec {
 // Returns the compressed offset which should be skipped.
 // If encrypted offsets are adjusted for encrypted block headers/trailers.
 // Since de-compression is after decryption encryption overhead is only added to compressedOffset.
-func getCompressedOffsets(objectInfo ObjectInfo, offset int64) (compressedOffset int64, partSkip int64, firstPart int) {
+//
+// When the part has an index of its compressed blocks the offset is moved
+// forward to the last block starting before the requested offset. For
+// encrypted objects the offset is rounded down to the encrypted package
+// holding that block, seqNumber is the sequence number of the package and
+// decryptSkip the number of decrypted bytes to skip to reach the block.
+func getCompressedOffsets(objectInfo ObjectInfo, offset int64) (compressedOffset int64, partSkip int64, firstPart int, decryptSkip int64, seqNumber uint32) {
 	var skipLength int64
 	var cumulativeActualSize int64
 	var firstPartIdx int
@@ -535,13 +542,36 @@ func getCompressedOffsets(objectInfo ObjectInfo, offset int64) (compressedOffset
 			}
 		}
 	}
+	partSkip = offset - skipLength
 
-	if isEncryptedMultipart(objectInfo) && firstPartIdx > 0 {
-		off, _, _, _, _, err := objectInfo.GetDecryptedRange(partNumberToRangeSpec(objectInfo, firstPartIdx))
+	if partSkip == 0 || len(objectInfo.Parts) <= firstPartIdx || len(objectInfo.Parts[firstPartIdx].Index) == 0 {
+		return compressedOffset + encryptedPartOffset(objectInfo, firstPartIdx), partSkip, firstPartIdx, 0, 0
+	}
+	idx, err := loadCompressIndex(objectInfo.Parts[firstPartIdx].Index)
+	if err != nil {
+		// Skip from the start of the part.
 		logger.LogIf(context.Background(), err)
-		compressedOffset += off
+		return compressedOffset + encryptedPartOffset(objectInfo, firstPartIdx), partSkip, firstPartIdx, 0, 0
 	}
-	return compressedOffset, offset - skipLength, firstPartIdx
+	blockOffset, blockSkip := idx.find(partSkip)
+	partSkip -= blockSkip
+	if _, ok := crypto.IsEncrypted(objectInfo.UserDefined); ok {
+		seqNumber = uint32(blockOffset / SSEDAREPackageBlockSize)
+		decryptSkip = blockOffset % SSEDAREPackageBlockSize
+		blockOffset = int64(seqNumber) * (SSEDAREPackageBlockSize + SSEDAREPackageMetaSize)
+	}
+	return compressedOffset + blockOffset, partSkip, firstPartIdx, decryptSkip, seqNumber
+}
+
+// encryptedPartOffset returns the offset to add for parts of encrypted
+// multipart objects read without an index of their compressed blocks.
+func encryptedPartOffset(objectInfo ObjectInfo, firstPartIdx int) int64 {
+	if !isEncryptedMultipart(objectInfo) || firstPartIdx == 0 {
+		return 0
+	}
+	off, _, _, _, _, err := objectInfo.GetDecryptedRange(partNumberToRangeSpec(objectInfo, firstPartIdx))
+	logger.LogIf(context.Background(), err)
+	return off
 }
 
 // GetObjectReader is a type that wraps a reader with a lock to
@@ -616,6 +646,8 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 	switch {
 	case isCompressed:
 		var firstPart int
+		var decryptSkip int64
+		var seqNumber uint32
 		if opts.PartNumber > 0 {
 			// firstPart is an index to Parts slice,
 			// make sure that PartNumber uses the
@@ -637,7 +669,7 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 				return nil, 0, 0, err
 			}
 			// In case of range based queries on multiparts, the offset and length are reduced.
-			off, decOff, firstPart = getCompressedOffsets(oi, off)
+			off, decOff, firstPart, decryptSkip, seqNumber = getCompressedOffsets(oi, off)
 			decLength = length
 			length = oi.Size - off
 			// For negative length we read everything.
@@ -662,7 +694,7 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 			if isEncrypted {
 				copySource := h.Get(xhttp.AmzServerSideEncryptionCopyCustomerAlgorithm) != ""
 				// Attach decrypter on inputReader
-				inputReader, err = DecryptBlocksRequestR(inputReader, h, 0, firstPart, oi, copySource)
+				inputReader, err = DecryptBlocksRequestR(inputReader, h, seqNumber, firstPart, oi, copySource)
 				if err != nil {
 					// Call the cleanup funcs
 					for i := len(cFns) - 1; i >= 0; i-- {
@@ -670,22 +702,44 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 					}
 					return nil, err
 				}
+				if decryptSkip > 0 {
+					inputReader = ioutil.NewSkipReader(inputReader, decryptSkip)
+				}
 				oi.Size = decLength
 			}
-			// Decompression reader.
-			s2Reader := s2.NewReader(inputReader)
-			// Apply the skipLen and limit on the decompressed stream.
-			if decOff > 0 {
-				if err = s2Reader.Skip(decOff); err != nil {
+			var decReader io.Reader
+			if oi.UserDefined[ReservedMetadataPrefix+"compression"] == compressionAlgorithmZstd {
+				zstdReader, err := zstd.NewReader(inputReader, zstd.WithDecoderConcurrency(2))
+				if err != nil {
 					// Call the cleanup funcs
 					for i := len(cFns) - 1; i >= 0; i-- {
 						cFns[i]()
 					}
 					return nil, err
 				}
+				cFns = append(cFns, zstdReader.Close)
+				// Objects compressed with zstd have no index, apply
+				// the skipLen on the decompressed stream.
+				decReader = ioutil.NewSkipReader(zstdReader, decOff)
+			} else {
+				// Decompression reader, reading may start at an indexed block
+				// in the middle of the stream, so the stream identifier is
+				// prepended. A repeated stream identifier is ignored.
+				s2Reader := s2.NewReader(io.MultiReader(strings.NewReader(s2StreamIdentifier), inputReader))
+				// Apply the skipLen on the decompressed stream.
+				if decOff > 0 {
+					if err = s2Reader.Skip(decOff); err != nil {
+						// Call the cleanup funcs
+						for i := len(cFns) - 1; i >= 0; i-- {
+							cFns[i]()
+						}
+						return nil, err
+					}
+				}
+				decReader = s2Reader
 			}
 
-			decReader := io.LimitReader(s2Reader, decLength)
+			decReader = io.LimitReader(decReader, decLength)
 			if decLength > compReadAheadSize {
 				rah, err := readahead.NewReaderSize(decReader, compReadAheadBuffers, compReadAheadBufSize)
 				if err == nil {
@@ -907,37 +961,77 @@ func CleanMinioInternalMetadataKeys(metadata map[string]string) map[string]strin
 	return newMeta
 }
 
+// compressionScheme returns the compression scheme recorded in the
+// metadata of objects compressed with the algorithm.
+func compressionScheme(algorithm string) string {
+	if algorithm == madmin.CompressionZstd {
+		return compressionAlgorithmZstd
+	}
+	return compressionAlgorithmV2
+}
+
+// newCompressReader compresses the data read from r with the algorithm,
+// see newS2CompressReader. No index is returned for zstd.
+func newCompressReader(r io.Reader, on int64, algorithm string) (rc io.ReadCloser, idx func() []byte) {
+	switch algorithm {
+	case madmin.CompressionZstd:
+		return newZstdCompressReader(r, on), nil
+	case madmin.CompressionS2Better:
+		return newS2CompressReader(r, on, s2.WriterBetterCompression())
+	case madmin.CompressionS2Best:
+		return newS2CompressReader(r, on, s2.WriterBestCompression())
+	}
+	return newS2CompressReader(r, on)
+}
+
 // newS2CompressReader will read data from r, compress it and return the compressed data as a Reader.
 // Use Close to ensure resources are released on incomplete streams.
+// The returned function gives the index of the compressed blocks
+// once the compressed data has been read entirely.
 //
 // input 'on' is always recommended such that this function works
 // properly, because we do not wish to create an object even if
 // client closed the stream prematurely.
-func newS2CompressReader(r io.Reader, on int64) io.ReadCloser {
+func newS2CompressReader(r io.Reader, on int64, opts ...s2.WriterOption) (rc io.ReadCloser, idx func() []byte) {
 	pr, pw := io.Pipe()
-	comp := s2.NewWriter(pw)
-	// Copy input to compressor
-	go func() {
-		cn, err := io.Copy(comp, r)
-		if err != nil {
-			comp.Close()
-			pw.CloseWithError(err)
-			return
-		}
-		if on > 0 && on != cn {
-			// if client didn't sent all data
-			// from the client verify here.
-			comp.Close()
-			pw.CloseWithError(IncompleteBody{})
-			return
-		}
-		// Close the stream.
-		if err = comp.Close(); err != nil {
-			pw.CloseWithError(err)
-			return
-		}
-		// Everything ok, do regular close.
-		pw.Close()
-	}()
+	iw := newCompressIndexWriter(pw)
+	go compressStream(s2.NewWriter(iw, opts...), pw, r, on)
+	return pr, iw.Index
+}
+
+// newZstdCompressReader will read data from r, compress it with zstd
+// and return the compressed data as a Reader, see newS2CompressReader.
+func newZstdCompressReader(r io.Reader, on int64) io.ReadCloser {
+	pr, pw := io.Pipe()
+	comp, err := zstd.NewWriter(pw, zstd.WithEncoderConcurrency(2))
+	if err != nil {
+		pw.CloseWithError(err)
+		return pr
+	}
+	go compressStream(comp, pw, r, on)
 	return pr
 }
+
+// compressStream copies r to the compressor writing to pw.
+func compressStream(comp io.WriteCloser, pw *io.PipeWriter, r io.Reader, on int64) {
+	cn, err := io.Copy(comp, r)
+	if err != nil {
+		comp.Close()
+		pw.CloseWithError(err)
+		return
+	}
+	if on > 0 && on != cn {
+		// if client didn't sent all data
+		// from the client verify here.
+		comp.Close()
+		pw.CloseWithError(IncompleteBody{})
+		return
+	}
+	// Close the stream.
+	if err = comp.Close(); err != nil {
+		pw.CloseWithError(err)
+		return
+	}
+	// Everything ok, do regular close.
+	pw.Close()
+}
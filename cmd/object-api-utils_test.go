@@ -520,6 +520,8 @@ func TestGetCompressedOffsets(t *testing.T) {
 		startOffset       int64
 		snappyStartOffset int64
 		firstPart         int
+		decryptSkip       int64
+		seqNumber         uint32
 	}{
 		0: {
 			objInfo: ObjectInfo{
@@ -573,9 +575,74 @@ func TestGetCompressedOffsets(t *testing.T) {
 			startOffset:       0,
 			snappyStartOffset: 0,
 		},
+		3: {
+			objInfo: ObjectInfo{
+				Parts: []ObjectPartInfo{
+					{
+						Size:       39235668,
+						ActualSize: 67108864,
+					},
+					{
+						Size:       19177372,
+						ActualSize: 32891137,
+						Index:      compressIndex{{1000000, 2 << 20}, {2000000, 4 << 20}}.marshal(),
+					},
+				},
+			},
+			offset:            67108864 + 3<<20 + 5,
+			startOffset:       39235668 + 1000000,
+			snappyStartOffset: 1<<20 + 5,
+			firstPart:         1,
+		},
+		4: {
+			objInfo: ObjectInfo{
+				UserDefined: map[string]string{crypto.MetaIV: ""},
+				Parts: []ObjectPartInfo{
+					{
+						Size:       39235668,
+						ActualSize: 67108864,
+					},
+					{
+						Size:       19177372,
+						ActualSize: 32891137,
+						Index:      compressIndex{{1000000, 2 << 20}, {2000000, 4 << 20}}.marshal(),
+					},
+				},
+			},
+			offset:            67108864 + 3<<20 + 5,
+			startOffset:       39235668 + 15*(SSEDAREPackageBlockSize+SSEDAREPackageMetaSize),
+			snappyStartOffset: 1<<20 + 5,
+			firstPart:         1,
+			decryptSkip:       1000000 - 15*SSEDAREPackageBlockSize,
+			seqNumber:         15,
+		},
+		5: {
+			objInfo: ObjectInfo{
+				UserDefined: map[string]string{crypto.MetaIV: "", crypto.MetaMultipart: ""},
+				Size:        3 * 100064,
+				Parts: []ObjectPartInfo{
+					{
+						Size:       100064,
+						ActualSize: 200000,
+					},
+					{
+						Size:       100064,
+						ActualSize: 200000,
+					},
+					{
+						Size:       100064,
+						ActualSize: 200000,
+					},
+				},
+			},
+			offset:            400005,
+			startOffset:       2*100064 + 2*100064,
+			snappyStartOffset: 5,
+			firstPart:         2,
+		},
 	}
 	for i, test := range testCases {
-		startOffset, snappyStartOffset, firstPart := getCompressedOffsets(test.objInfo, test.offset)
+		startOffset, snappyStartOffset, firstPart, decryptSkip, seqNumber := getCompressedOffsets(test.objInfo, test.offset)
 		if startOffset != test.startOffset {
 			t.Errorf("Test %d - expected startOffset %d but received %d",
 				i, test.startOffset, startOffset)
@@ -588,6 +655,14 @@ func TestGetCompressedOffsets(t *testing.T) {
 			t.Errorf("Test %d - expected firstPart %d but received %d",
 				i, test.firstPart, firstPart)
 		}
+		if decryptSkip != test.decryptSkip {
+			t.Errorf("Test %d - expected decryptSkip %d but received %d",
+				i, test.decryptSkip, decryptSkip)
+		}
+		if seqNumber != test.seqNumber {
+			t.Errorf("Test %d - expected seqNumber %d but received %d",
+				i, test.seqNumber, seqNumber)
+		}
 	}
 }
 
@@ -605,7 +680,7 @@ func TestS2CompressReader(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			buf := make([]byte, 100) // make small buffer to ensure multiple reads are required for large case
 
-			r := newS2CompressReader(bytes.NewReader(tt.data), int64(len(tt.data)))
+			r, _ := newS2CompressReader(bytes.NewReader(tt.data), int64(len(tt.data)))
 			defer r.Close()
 
 			var rdrBuf bytes.Buffer
@@ -0,0 +1,111 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// The additional checksum of an object, formatted by hash.Checksum.String.
+	objectChecksumKey = ReservedMetadataPrefix + "checksum"
+
+	// The checksum algorithm of a multipart upload, the checksum of the
+	// object is computed from the checksums of its parts on completion.
+	objectChecksumTypeKey = ReservedMetadataPrefix + "checksum-type"
+)
+
+// getObjectChecksum returns the additional checksum of an object, nil if
+// it has none.
+func getObjectChecksum(objInfo ObjectInfo) *hash.Checksum {
+	return hash.ParseChecksum(objInfo.UserDefined[objectChecksumKey])
+}
+
+// isCompositeChecksum returns true for the checksum of a multipart object,
+// computed from the checksums of its parts.
+func isCompositeChecksum(c *hash.Checksum) bool {
+	return c != nil && strings.Contains(c.Encoded, "-")
+}
+
+// setChecksumHeaders sets the x-amz-checksum-* response header of the
+// checksum, if any.
+func setChecksumHeaders(w http.ResponseWriter, c *hash.Checksum) {
+	if c == nil || c.Encoded == "" {
+		return
+	}
+	w.Header().Set(c.Type.Key(), c.Encoded)
+}
+
+// checksum returns the checksum sent for the part in the
+// CompleteMultipartUpload request, nil if none was sent.
+func (p CompletePart) checksum() *hash.Checksum {
+	switch {
+	case p.ChecksumCRC32 != "":
+		return &hash.Checksum{Type: hash.ChecksumCRC32, Encoded: p.ChecksumCRC32}
+	case p.ChecksumCRC32C != "":
+		return &hash.Checksum{Type: hash.ChecksumCRC32C, Encoded: p.ChecksumCRC32C}
+	case p.ChecksumSHA1 != "":
+		return &hash.Checksum{Type: hash.ChecksumSHA1, Encoded: p.ChecksumSHA1}
+	case p.ChecksumSHA256 != "":
+		return &hash.Checksum{Type: hash.ChecksumSHA256, Encoded: p.ChecksumSHA256}
+	}
+	return nil
+}
+
+// verifyPartChecksum returns false if a checksum was sent for the part in
+// the CompleteMultipartUpload request and the uploaded part does not have
+// this checksum.
+func verifyPartChecksum(part CompletePart, uploaded ObjectPartInfo) bool {
+	c := part.checksum()
+	if c == nil {
+		return true
+	}
+	got := hash.ParseChecksum(uploaded.Checksum)
+	return got != nil && *got == *c
+}
+
+// completeMultipartChecksum returns the checksum of a multipart object of
+// the checksum algorithm of the upload, computed from the checksums of its
+// parts, nil if the upload has no algorithm or a part has no checksum.
+func completeMultipartChecksum(metadata map[string]string, parts []ObjectPartInfo) *hash.Checksum {
+	t := hash.NewChecksumType(metadata[objectChecksumTypeKey])
+	if !t.IsSet() {
+		return nil
+	}
+	checksums := make([]*hash.Checksum, len(parts))
+	for i, part := range parts {
+		checksums[i] = hash.ParseChecksum(part.Checksum)
+	}
+	return hash.CompositeChecksum(t, checksums)
+}
+
+// setCompleteMultipartChecksum records the checksum of the object in the
+// metadata of a completed multipart upload. The checksum of an upload
+// without algorithm, such as one copied with its metadata, is unchanged.
+func setCompleteMultipartChecksum(metadata map[string]string, parts []ObjectPartInfo) {
+	if _, ok := metadata[objectChecksumTypeKey]; !ok {
+		return
+	}
+	delete(metadata, objectChecksumKey)
+	if c := completeMultipartChecksum(metadata, parts); c != nil {
+		metadata[objectChecksumKey] = c.String()
+	}
+	delete(metadata, objectChecksumTypeKey)
+}
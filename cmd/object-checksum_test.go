@@ -0,0 +1,121 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/hash"
+)
+
+func newChecksumPutObjReader(t *testing.T, data []byte, checksum *hash.Checksum) (*PutObjReader, ObjectOptions) {
+	t.Helper()
+	hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = hr.AddChecksum(checksum); err != nil {
+		t.Fatal(err)
+	}
+	return NewPutObjReader(hr, nil, nil), ObjectOptions{ChecksumCB: hr.ContentChecksum}
+}
+
+func TestObjectChecksum(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("123456789")
+	want := &hash.Checksum{Type: hash.ChecksumCRC32C, Encoded: "4waSgw=="}
+
+	r, opts := newChecksumPutObjReader(t, data, want)
+	if _, err = obj.PutObject(ctx, bucket, "object", r, opts); err != nil {
+		t.Fatal(err)
+	}
+	oi, err := obj.GetObjectInfo(ctx, bucket, "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getObjectChecksum(oi); got == nil || *got != *want {
+		t.Fatalf("expected checksum %v, got %v", want, got)
+	}
+
+	r, opts = newChecksumPutObjReader(t, data, &hash.Checksum{Type: hash.ChecksumCRC32C, Encoded: "y/Q5Jg=="})
+	if _, err = obj.PutObject(ctx, bucket, "mismatch", r, opts); err == nil {
+		t.Fatal("expected the checksum mismatch to fail the upload")
+	}
+
+	// The checksum of a multipart object is computed from its parts.
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{
+		UserDefined: map[string]string{objectChecksumTypeKey: string(hash.ChecksumCRC32C)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	partsData := [][]byte{bytes.Repeat([]byte("a"), 5*humanize.MiByte), data}
+	var completeParts []CompletePart
+	var partChecksums []*hash.Checksum
+	for i, partData := range partsData {
+		r, opts = newChecksumPutObjReader(t, partData, &hash.Checksum{Type: hash.ChecksumCRC32C})
+		pi, err := obj.PutObjectPart(ctx, bucket, "multipart", uploadID, i+1, r, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := opts.ChecksumCB()
+		partChecksums = append(partChecksums, c)
+		completeParts = append(completeParts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag, ChecksumCRC32C: c.Encoded})
+	}
+
+	invalidParts := append([]CompletePart(nil), completeParts...)
+	invalidParts[1].ChecksumCRC32C = "y/Q5Jg=="
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, invalidParts, ObjectOptions{}); err == nil {
+		t.Fatal("expected a part checksum mismatch to fail the completion")
+	} else if _, ok := err.(InvalidPart); !ok {
+		t.Fatalf("expected InvalidPart, got %v", err)
+	}
+
+	oi, err = obj.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, completeParts, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = hash.CompositeChecksum(hash.ChecksumCRC32C, partChecksums)
+	if got := getObjectChecksum(oi); got == nil || *got != *want || want.Encoded[len(want.Encoded)-2:] != "-2" {
+		t.Fatalf("expected checksum %v, got %v", want, got)
+	}
+	if _, ok := oi.UserDefined[objectChecksumTypeKey]; ok {
+		t.Fatal("expected the checksum algorithm of the upload to be removed")
+	}
+	for i, part := range oi.Parts {
+		if part.Checksum != partChecksums[i].String() {
+			t.Fatalf("expected part %d checksum %v, got %s", part.Number, partChecksums[i], part.Checksum)
+		}
+	}
+}
@@ -70,6 +70,9 @@ var supportedHeadGetReqParams = map[string]string{
 const (
 	compressionAlgorithmV1 = "golang/snappy/LZ77"
 	compressionAlgorithmV2 = "klauspost/compress/s2"
+	// Objects compressed with zstd, a bucket compression configuration
+	// may choose it instead of S2.
+	compressionAlgorithmZstd = "klauspost/compress/zstd"
 
 	// When an upload exceeds encryptBufferThreshold ...
 	encryptBufferThreshold = 1 << 20
@@ -697,6 +700,159 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	})
 }
 
+// GetObjectAttributesHandler - GET Object?attributes
+// ----------
+// This implementation of the GET operation returns the attributes of an
+// object requested in the x-amz-object-attributes header, such as its
+// checksum and its parts, without returning the object itself.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if crypto.S3.IsRequested(r.Header) || crypto.S3KMS.IsRequested(r.Header) { // If SSE-S3 or SSE-KMS present -> AWS fails with undefined error
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := url.PathUnescape(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	attributes := make(map[string]bool)
+	for _, v := range r.Header.Values(xhttp.AmzObjectAttributes) {
+		for _, attr := range strings.Split(v, ",") {
+			switch attr = strings.TrimSpace(attr); attr {
+			case "ETag", "Checksum", "ObjectParts", "StorageClass", "ObjectSize":
+				attributes[attr] = true
+			default:
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidObjectAttributes), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidObjectAttributes), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	maxParts, partNumberMarker := maxPartsList, 0
+	if v := r.Header.Get(xhttp.AmzMaxParts); v != "" {
+		if maxParts, err = strconv.Atoi(v); err != nil || maxParts < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxParts), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if maxParts > maxPartsList {
+			maxParts = maxPartsList
+		}
+	}
+	if v := r.Header.Get(xhttp.AmzPartNumberMarker); v != "" {
+		if partNumberMarker, err = strconv.Atoi(v); err != nil || partNumberMarker < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidPartNumberMarker), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		if globalBucketVersioningSys.Enabled(bucket) {
+			// Versioning enabled quite possibly object is deleted might be delete-marker
+			// if present set the headers, no idea why AWS S3 sets these headers.
+			if objInfo.VersionID != "" && objInfo.DeleteMarker {
+				w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+				w.Header()[xhttp.AmzDeleteMarker] = []string{strconv.FormatBool(objInfo.DeleteMarker)}
+			}
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
+	var response GetObjectAttributesResponse
+	if attributes["ETag"] {
+		response.ETag = objInfo.ETag
+	}
+	if attributes["Checksum"] {
+		if c := getObjectChecksum(objInfo); c != nil {
+			checksum := generateChecksumResponse(c)
+			response.Checksum = &checksum
+		}
+	}
+	if attributes["StorageClass"] {
+		response.StorageClass = objInfo.StorageClass
+	}
+	if attributes["ObjectSize"] {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		response.ObjectSize = &size
+	}
+	// Only multipart objects have parts.
+	if attributes["ObjectParts"] && strings.Contains(objInfo.ETag, "-") && len(objInfo.Parts) > 0 {
+		parts := &ObjectAttributesParts{
+			MaxParts:         maxParts,
+			PartNumberMarker: partNumberMarker,
+			PartsCount:       len(objInfo.Parts),
+		}
+		for _, part := range objInfo.Parts {
+			if part.Number <= partNumberMarker {
+				continue
+			}
+			if len(parts.Parts) == maxParts {
+				parts.IsTruncated = true
+				break
+			}
+			parts.Parts = append(parts.Parts, ObjectAttributesPart{
+				Checksum:   generateChecksumResponse(hash.ParseChecksum(part.Checksum)),
+				PartNumber: part.Number,
+				Size:       part.ActualSize,
+			})
+			parts.NextPartNumberMarker = part.Number
+		}
+		response.ObjectParts = parts
+	}
+
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
 // Extract metadata relevant for an CopyObject operation based on conditional
 // header values specified in X-Amz-Metadata-Directive.
 func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta map[string]string) (map[string]string, error) {
@@ -1007,18 +1163,20 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	// No need to compress for remote etcd calls
 	// Pass the decompressed stream to such calls.
 	isDstCompressed := objectAPI.IsCompressionSupported() &&
-		isCompressible(r.Header, dstObject) &&
+		isCompressible(r.Header, dstBucket, dstObject) &&
 		!isRemoteCopyRequired(ctx, srcBucket, dstBucket, objectAPI) && !cpSrcDstSame
 	if isDstCompressed {
+		algorithm := bucketCompressionAlgorithm(dstBucket)
 		compressMetadata = make(map[string]string, 2)
 		// Preserving the compression metadata.
-		compressMetadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		compressMetadata[ReservedMetadataPrefix+"compression"] = compressionScheme(algorithm)
 		compressMetadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(actualSize, 10)
 		// Remove all source encrypted related metadata to
 		// avoid copying them in target object.
 		crypto.RemoveInternalEntries(srcInfo.UserDefined)
 
-		s2c := newS2CompressReader(gr, actualSize)
+		s2c, cb := newCompressReader(gr, actualSize, algorithm)
+		dstOpts.IndexCB = cb
 		defer s2c.Close()
 		reader = s2c
 		length = -1
@@ -1152,12 +1310,20 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	srcInfo.PutObjReader = pReader
 
+	srcChecksum := getObjectChecksum(srcInfo)
 	srcInfo.UserDefined, err = getCpObjMetadataFromHeader(ctx, r, srcInfo.UserDefined)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	// A copy writing new data does not inherit the storage class of the
+	// source, it gets the requested one or the one configured for the
+	// destination bucket.
+	if !srcInfo.metadataOnly && !chStorageClass && r.URL.Query().Get(xhttp.AmzStorageClass) == "" {
+		delete(srcInfo.UserDefined, xhttp.AmzStorageClass)
+	}
+
 	objTags := srcInfo.UserTags
 	// If x-amz-tagging-directive header is REPLACE, get passed tags.
 	if isDirectiveReplace(r.Header.Get(xhttp.AmzTagDirective)) {
@@ -1208,6 +1374,13 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		srcInfo.UserDefined[k] = v
 	}
 
+	// Preserve the checksum of the content, the checksum of a multipart
+	// object does not apply once it is copied into a single part.
+	delete(srcInfo.UserDefined, objectChecksumKey)
+	if srcChecksum != nil && (srcInfo.metadataOnly || !isCompositeChecksum(srcChecksum)) {
+		srcInfo.UserDefined[objectChecksumKey] = srcChecksum.String()
+	}
+
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
@@ -1253,6 +1426,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		// Remove the metadata for remote calls.
 		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"compression")
 		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"actual-size")
+		delete(srcInfo.UserDefined, objectChecksumKey)
 		opts := miniogo.PutObjectOptions{
 			UserMetadata:         srcInfo.UserDefined,
 			ServerSideEncryption: dstOpts.ServerSideEncryption,
@@ -1400,6 +1574,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	checksum, err := hash.GetContentChecksum(r.Header)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	if objTags := r.Header.Get(xhttp.AmzObjectTagging); objTags != "" {
 		if !objectAPI.IsTaggingSupported() {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
@@ -1467,10 +1647,15 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	actualSize := size
+	var idxCb func() []byte
 
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+	// The additional checksum is computed on the content sent by the client.
+	var checksumReader *hash.Reader
+
+	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, bucket, object) && size > 0 {
+		algorithm := bucketCompressionAlgorithm(bucket)
 		// Storing the compression metadata.
-		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		metadata[ReservedMetadataPrefix+"compression"] = compressionScheme(algorithm)
 		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
 
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
@@ -1479,8 +1664,11 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			return
 		}
 
+		checksumReader = actualReader
+
 		// Set compression metrics.
-		s2c := newS2CompressReader(actualReader, actualSize)
+		var s2c io.ReadCloser
+		s2c, idxCb = newCompressReader(actualReader, actualSize, algorithm)
 		defer s2c.Close()
 		reader = s2c
 		size = -1   // Since compressed size is un-predictable.
@@ -1493,6 +1681,13 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	if checksumReader == nil {
+		checksumReader = hashReader
+	}
+	if err = checksumReader.AddChecksum(checksum); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
 
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader, nil, nil)
@@ -1504,6 +1699,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	opts.IndexCB = idxCb
+	if checksum != nil {
+		opts.ChecksumCB = checksumReader.ContentChecksum
+	}
 
 	if api.CacheAPI() != nil {
 		putObject = api.CacheAPI().PutObject
@@ -1601,6 +1800,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		scheduleReplication(ctx, objInfo.Clone(), objectAPI, sync)
 	}
 	setPutObjHeaders(w, objInfo, false)
+	setChecksumHeaders(w, checksumReader.ContentChecksum())
 
 	writeSuccessResponseHeadersOnly(w)
 
@@ -1726,9 +1926,19 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	// Ensure that metadata does not contain sensitive information
 	crypto.RemoveSensitiveEntries(metadata)
 
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) {
+	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, bucket, object) {
 		// Storing the compression metadata.
-		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		metadata[ReservedMetadataPrefix+"compression"] = compressionScheme(bucketCompressionAlgorithm(bucket))
+	}
+
+	// Storing the checksum algorithm of the parts.
+	var checksumType hash.ChecksumType
+	if alg := r.Header.Get(hash.AmzChecksumAlgorithm); alg != "" {
+		if checksumType = hash.NewChecksumType(alg); !checksumType.IsSet() {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidChecksum), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		metadata[objectChecksumTypeKey] = string(checksumType)
 	}
 
 	opts, err := putOpts(ctx, r, bucket, object, metadata)
@@ -1744,6 +1954,10 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if checksumType.IsSet() {
+		w.Header().Set(hash.AmzChecksumAlgorithm, string(checksumType))
+	}
+
 	response := generateInitiateMultipartUploadResponse(bucket, object, uploadID)
 	encodedSuccessResponse := encodeResponse(response)
 
@@ -1978,10 +2192,12 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	// Read compression metadata preserved in the init multipart for the decision.
-	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
+	scheme, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
 	// Compress only if the compression is enabled during initial multipart.
+	var idxCb func() []byte
 	if isCompressed {
-		s2c := newS2CompressReader(gr, actualPartSize)
+		var s2c io.ReadCloser
+		s2c, idxCb = newCompressReader(gr, actualPartSize, multipartCompressionAlgorithm(dstBucket, scheme))
 		defer s2c.Close()
 		reader = s2c
 		length = -1
@@ -2000,6 +2216,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	dstOpts.IndexCB = idxCb
 
 	rawReader := srcInfo.Reader
 	pReader := NewPutObjReader(rawReader, nil, nil)
@@ -2217,9 +2434,29 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// The checksum of the part has to be of the algorithm of the upload,
+	// it is computed if not sent.
+	checksum, err := hash.GetContentChecksum(r.Header)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if t := hash.NewChecksumType(mi.UserDefined[objectChecksumTypeKey]); t.IsSet() {
+		if checksum == nil {
+			checksum = &hash.Checksum{Type: t}
+		} else if checksum.Type != t {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidChecksum), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	// Read compression metadata preserved in the init multipart for the decision.
-	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
+	scheme, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
+
+	// The additional checksum is computed on the content sent by the client.
+	var checksumReader *hash.Reader
 
+	var idxCb func() []byte
 	if objectAPI.IsCompressionSupported() && isCompressed {
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
 		if err != nil {
@@ -2227,8 +2464,11 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 			return
 		}
 
+		checksumReader = actualReader
+
 		// Set compression metrics.
-		s2c := newS2CompressReader(actualReader, actualSize)
+		var s2c io.ReadCloser
+		s2c, idxCb = newCompressReader(actualReader, actualSize, multipartCompressionAlgorithm(bucket, scheme))
 		defer s2c.Close()
 		reader = s2c
 		size = -1   // Since compressed size is un-predictable.
@@ -2241,6 +2481,13 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	if checksumReader == nil {
+		checksumReader = hashReader
+	}
+	if err = checksumReader.AddChecksum(checksum); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader, nil, nil)
 
@@ -2303,6 +2550,10 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 
 	putObjectPart := objectAPI.PutObjectPart
 
+	opts.IndexCB = idxCb
+	if checksum != nil {
+		opts.ChecksumCB = checksumReader.ContentChecksum
+	}
 	partInfo, err := putObjectPart(ctx, bucket, object, uploadID, partID, pReader, opts)
 	if err != nil {
 		// Verify if the underlying error is signature mismatch.
@@ -2319,6 +2570,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	// clients expect the ETag header key to be literally "ETag" - not "Etag" (case-sensitive).
 	// Therefore, we have to set the ETag directly as map entry.
 	w.Header()[xhttp.ETag] = []string{"\"" + etag + "\""}
+	setChecksumHeaders(w, checksumReader.ContentChecksum())
 
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -2664,6 +2916,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
 	// Generate complete multipart response.
 	response := generateCompleteMultpartUploadResponse(bucket, object, location, objInfo.ETag)
+	response.Checksum = generateChecksumResponse(getObjectChecksum(objInfo))
 	var encodedSuccessResponse []byte
 	if !headerWritten {
 		encodedSuccessResponse = encodeResponse(response)
@@ -2833,15 +3086,9 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		scheduleReplicationDelete(ctx, dobj, objectAPI, replicateSync)
 	}
 
-	if goi.TransitionStatus == lifecycle.TransitionComplete { // clean up transitioned tier
-		deleteTransitionedObject(ctx, newObjectLayerFn(), bucket, object, lifecycle.ObjectOpts{
-			Name:             object,
-			UserTags:         goi.UserTags,
-			VersionID:        goi.VersionID,
-			DeleteMarker:     goi.DeleteMarker,
-			TransitionStatus: goi.TransitionStatus,
-			IsLatest:         goi.IsLatest,
-		}, false, true)
+	// clean up transitioned tier, unless the version is kept behind a delete marker
+	if err == nil && goi.TransitionStatus == lifecycle.TransitionComplete && isTransitionedVersionDeleted(bucket, vID, goi.VersionID) {
+		deleteTransitionedObject(ctx, newObjectLayerFn(), goi, false, true)
 	}
 
 	setPutObjHeaders(w, objInfo, true)
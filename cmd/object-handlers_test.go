@@ -39,6 +39,7 @@ import (
 	humanize "github.com/dustin/go-humanize"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/versioning"
 	ioutilx "github.com/minio/minio/pkg/ioutil"
 )
 
@@ -200,6 +201,152 @@ func testAPIHeadObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling PutObject and GetObjectAttributes tests with additional checksums for both Erasure multiple disks and single node setup.
+func TestAPIObjectChecksumHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIObjectChecksumHandler, []string{"GetObjectAttributes", "PutObject"})
+}
+
+func testAPIObjectChecksumHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "test-object"
+	data := []byte("123456789")
+
+	testCases := []struct {
+		headers            map[string]string
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// Checksum of the content.
+		{map[string]string{"X-Amz-Checksum-Crc32c": "4waSgw=="}, http.StatusOK},
+		// Test case - 2.
+		// Checksum which does not match the content.
+		{map[string]string{"X-Amz-Checksum-Crc32c": "y/Q5Jg=="}, http.StatusBadRequest},
+		// Test case - 3.
+		// Malformed checksum.
+		{map[string]string{"X-Amz-Checksum-Sha256": "4waSgw=="}, http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, testCase.headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if rec.Code == http.StatusOK && rec.Header().Get("X-Amz-Checksum-Crc32c") != "4waSgw==" {
+			t.Fatalf("Test %d: %s: Expected the checksum to be returned, got %v", i+1, instanceType, rec.Header())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodGet, makeTestTargetURL("", bucketName, objectName, url.Values{"attributes": {""}}),
+		0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzObjectAttributes: "ETag,Checksum,ObjectSize"})
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for GetObjectAttributes: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var attrs GetObjectAttributesResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &attrs); err != nil {
+		t.Fatalf("%s: Failed to parse GetObjectAttributes response: <ERROR> %v", instanceType, err)
+	}
+	if attrs.ETag == "" || attrs.Checksum == nil || attrs.Checksum.ChecksumCRC32C != "4waSgw==" ||
+		attrs.ObjectSize == nil || *attrs.ObjectSize != int64(len(data)) || attrs.ObjectParts != nil {
+		t.Fatalf("%s: Unexpected object attributes %s", instanceType, rec.Body.String())
+	}
+
+	// The attributes have to be valid.
+	rec = httptest.NewRecorder()
+	req, err = newTestSignedRequestV4(http.MethodGet, makeTestTargetURL("", bucketName, objectName, url.Values{"attributes": {""}}),
+		0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzObjectAttributes: "Size"})
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for GetObjectAttributes: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+}
+
+// Wrapper for calling GetObjectAttributes tests of a delete marker for Erasure multiple disks.
+func TestAPIGetObjectAttributesDeleteMarker(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIGetObjectAttributesDeleteMarker, []string{"GetObjectAttributes"})
+}
+
+func testAPIGetObjectAttributesDeleteMarker(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	if _, ok := obj.(*erasureServerPools); !ok {
+		// Versioning is only supported by erasure coded backends.
+		return
+	}
+
+	ctx := context.Background()
+	bucketName = getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(ctx, bucketName, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatalf("%s: Failed to create versioned bucket: <ERROR> %v", instanceType, err)
+	}
+	meta, err := globalBucketMetadataSys.Get(bucketName)
+	if err != nil {
+		t.Fatalf("%s: Failed to get bucket metadata: <ERROR> %v", instanceType, err)
+	}
+	if meta.versioningConfig, err = versioning.ParseConfig(bytes.NewReader(enabledBucketVersioningConfig)); err != nil {
+		t.Fatalf("%s: Failed to enable versioning: <ERROR> %v", instanceType, err)
+	}
+	globalBucketMetadataSys.Set(bucketName, meta)
+	objectName := "test-object"
+	data := []byte("123456789")
+	oi, err := obj.PutObject(ctx, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+	dm, err := obj.DeleteObject(ctx, bucketName, objectName, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatalf("%s: Failed to delete object: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		versionID          string
+		expectedRespStatus int
+		expectDeleteMarker bool
+	}{
+		// Test case - 1.
+		// Latest version is a delete marker.
+		{"", http.StatusNotFound, true},
+		// Test case - 2.
+		// Version before the delete marker.
+		{oi.VersionID, http.StatusOK, false},
+	}
+	for i, testCase := range testCases {
+		query := url.Values{"attributes": {""}}
+		if testCase.versionID != "" {
+			query.Set("versionId", testCase.versionID)
+		}
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodGet, makeTestTargetURL("", bucketName, objectName, query),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzObjectAttributes: "ETag"})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for GetObjectAttributes: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.expectDeleteMarker {
+			// The headers are set with the casing of AWS S3.
+			if strings.Join(rec.Header()[xhttp.AmzDeleteMarker], ",") != "true" || strings.Join(rec.Header()[xhttp.AmzVersionID], ",") != dm.VersionID {
+				t.Fatalf("Test %d: %s: Expected the delete marker %s in the headers, got %v", i+1, instanceType, dm.VersionID, rec.Header())
+			}
+		} else if _, ok := rec.Header()[xhttp.AmzDeleteMarker]; ok {
+			t.Fatalf("Test %d: %s: Unexpected delete marker in the headers %v", i+1, instanceType, rec.Header())
+		}
+	}
+}
+
 func TestAPIHeadObjectHandlerWithEncryption(t *testing.T) {
 	globalPolicySys = NewPolicySys()
 	defer func() { globalPolicySys = nil }()
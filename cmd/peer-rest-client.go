@@ -907,6 +907,39 @@ func (client *peerRESTClient) MonitorBandwidth(ctx context.Context, buckets []st
 	return &bandwidthReport, err
 }
 
+// GetReplicationMetrics - returns the live replication metrics of the bucket on the peer.
+func (client *peerRESTClient) GetReplicationMetrics(ctx context.Context, bucket string) (metrics madmin.BucketReplicationMetrics, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetReplicationMetrics, values, nil, -1)
+	if err != nil {
+		return metrics, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&metrics)
+	return metrics, err
+}
+
+// LoadTransitionTierConfig - reloads the remote tiers on the peer.
+func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadTierConfig, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ReloadPoolMeta - reloads the status of the server pools on the peer.
+func (client *peerRESTClient) ReloadPoolMeta(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadPoolMeta, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 func (client *peerRESTClient) GetPeerMetrics(ctx context.Context) (<-chan Metric, error) {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodGetPeerMetrics, nil, nil, -1)
 	if err != nil {
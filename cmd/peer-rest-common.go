@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v12"
+	peerRESTVersion       = "v15" // Add ReloadPoolMeta
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -59,6 +59,9 @@ const (
 	peerRESTMethodGetMetacacheListing    = "/getmetacache"
 	peerRESTMethodUpdateMetacacheListing = "/updatemetacache"
 	peerRESTMethodGetPeerMetrics         = "/peermetrics"
+	peerRESTMethodGetReplicationMetrics  = "/replicationmetrics"
+	peerRESTMethodLoadTierConfig         = "/loadtierconfig"
+	peerRESTMethodReloadPoolMeta         = "/reloadpoolmeta"
 )
 
 const (
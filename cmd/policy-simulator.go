@@ -0,0 +1,170 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// simulatePolicy - evaluates the policies of the identity of the simulated
+// request like for an actual request, without performing it. Returns the
+// decision and the statements allowing or denying the request.
+func simulatePolicy(r *http.Request, req madmin.PolicySimulationRequest) (result madmin.PolicySimulationResult, err error) {
+	action := iampolicy.Action(req.Action)
+	if !action.IsValid() && !iampolicy.AdminAction(req.Action).IsValid() {
+		return result, errInvalidArgument
+	}
+
+	resource := strings.TrimPrefix(req.Resource, "arn:aws:s3:::")
+	bucket, object := resource, ""
+	if i := strings.Index(resource, "/"); i >= 0 {
+		bucket, object = resource[:i], resource[i+1:]
+	}
+
+	// Anonymous requests are only evaluated with the bucket policy.
+	if req.AccessKey == "" {
+		args := policy.Args{
+			Action:          policy.Action(action),
+			BucketName:      bucket,
+			ConditionValues: simulationConditionValues(req, "", nil),
+			ObjectName:      object,
+		}
+		result.Allowed = globalPolicySys.IsAllowed(args)
+		if p, err := globalPolicySys.Get(bucket); err == nil {
+			for _, statement := range p.MatchingStatements(args) {
+				if err = addSimulatedStatement(&result, madmin.PolicySourceBucket, bucket, "", string(statement.Effect), statement); err != nil {
+					return result, err
+				}
+			}
+		}
+		return result, nil
+	}
+
+	if req.AccessKey == globalActiveCred.AccessKey {
+		result.Allowed, result.Owner = true, true
+		return result, nil
+	}
+
+	cred, ok := globalIAMSys.GetUser(req.AccessKey)
+	if !ok {
+		return result, errNoSuchUser
+	}
+
+	var claims map[string]interface{}
+	if cred.SessionToken != "" {
+		if claims, err = getClaimsFromToken(r, cred.SessionToken); err != nil {
+			return result, err
+		}
+	}
+
+	args := iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Action:          action,
+		BucketName:      bucket,
+		ConditionValues: simulationConditionValues(req, cred.AccessKey, claims),
+		ObjectName:      object,
+		Claims:          claims,
+	}
+	result.Allowed = globalIAMSys.IsAllowed(args)
+
+	// Statements are unknown when policies are evaluated by OPA.
+	if globalPolicyOPA != nil {
+		return result, nil
+	}
+
+	policies := globalIAMSys.cannedPoliciesOf(cred, claims)
+	if spolicy, ok := claims[iampolicy.SessionPolicyName].(string); ok {
+		if p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(spolicy))); err == nil {
+			policies = append(policies, simulatedPolicy{source: madmin.PolicySourceSession, policy: *p})
+		}
+	}
+
+	for _, p := range policies {
+		for _, statement := range p.policy.MatchingStatements(args) {
+			if err = addSimulatedStatement(&result, p.source, p.name, p.policyName, string(statement.Effect), statement); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// simulationConditionValues - returns the condition values of a simulated
+// request, the values of the identity and its claims, overridden by the
+// condition values of the request.
+func simulationConditionValues(req madmin.PolicySimulationRequest, username string, claims map[string]interface{}) map[string][]string {
+	principalType := "Anonymous"
+	if username != "" {
+		principalType = "User"
+		if len(claims) > 0 {
+			principalType = "AssumedRole"
+		}
+	}
+
+	values := map[string][]string{
+		"principaltype": {principalType},
+		"userid":        {username},
+		"username":      {username},
+	}
+
+	for k, v := range claims {
+		if vStr, ok := v.(string); ok {
+			// Special case for AD/LDAP STS users
+			if k == ldapUser {
+				values["user"] = []string{vStr}
+			} else {
+				values[k] = []string{vStr}
+			}
+		}
+	}
+
+	for k, v := range req.Conditions {
+		if key := condition.Key(k); key.IsValid() {
+			k = key.Name()
+		}
+		values[k] = v
+	}
+
+	return values
+}
+
+// addSimulatedStatement - adds a statement matching the simulated request to
+// the result.
+func addSimulatedStatement(result *madmin.PolicySimulationResult, source, name, policyName, effect string, statement interface{}) error {
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+
+	result.Statements = append(result.Statements, madmin.PolicySimulationStatement{
+		Source:    source,
+		Name:      name,
+		Policy:    policyName,
+		Effect:    effect,
+		Statement: data,
+	})
+	return nil
+}
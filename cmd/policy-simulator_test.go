@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestSimulatePolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminTestBed.TearDown()
+
+	globalIAMSys.Init(ctx, adminTestBed.objLayer)
+
+	readBucket := iampolicy.Policy{
+		Version: iampolicy.DefaultVersion,
+		Statements: []iampolicy.Statement{
+			iampolicy.NewStatement(policy.Allow,
+				iampolicy.NewActionSet(iampolicy.GetObjectAction),
+				iampolicy.NewResourceSet(iampolicy.NewResource("bucket", "*")),
+				condition.NewFunctions()),
+		},
+	}
+	denySecret := iampolicy.Policy{
+		Version: iampolicy.DefaultVersion,
+		Statements: []iampolicy.Statement{
+			iampolicy.NewStatement(policy.Deny,
+				iampolicy.NewActionSet(iampolicy.GetObjectAction),
+				iampolicy.NewResourceSet(iampolicy.NewResource("bucket", "secret/*")),
+				condition.NewFunctions()),
+		},
+	}
+	if err = globalIAMSys.SetPolicy("read-bucket", readBucket); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("deny-secret", denySecret); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.CreateUser("testuser", madmin.UserInfo{SecretKey: "testuser-secret", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet("testuser", "read-bucket", false); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.AddUsersToGroup("testgroup", []string{"testuser"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet("testgroup", "deny-secret", true); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		req     madmin.PolicySimulationRequest
+		allowed bool
+		owner   bool
+		sources []string
+		err     error
+	}{
+		{madmin.PolicySimulationRequest{AccessKey: "testuser", Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket/object"},
+			true, false, []string{madmin.PolicySourceUser}, nil},
+		{madmin.PolicySimulationRequest{AccessKey: "testuser", Action: "s3:GetObject", Resource: "bucket/secret/object"},
+			false, false, []string{madmin.PolicySourceUser, madmin.PolicySourceGroup}, nil},
+		{madmin.PolicySimulationRequest{AccessKey: "testuser", Action: "s3:PutObject", Resource: "bucket/object"},
+			false, false, nil, nil},
+		{madmin.PolicySimulationRequest{AccessKey: globalActiveCred.AccessKey, Action: "s3:PutObject", Resource: "bucket/object"},
+			true, true, nil, nil},
+		{madmin.PolicySimulationRequest{Action: "s3:GetObject", Resource: "bucket/object"},
+			false, false, nil, nil},
+		{madmin.PolicySimulationRequest{AccessKey: "nouser", Action: "s3:GetObject", Resource: "bucket/object"},
+			false, false, nil, errNoSuchUser},
+		{madmin.PolicySimulationRequest{AccessKey: "testuser", Action: "s3:Unknown", Resource: "bucket/object"},
+			false, false, nil, errInvalidArgument},
+	}
+
+	r := httptest.NewRequest("POST", "/minio/admin/v3/simulate-policy", nil)
+	for i, testCase := range testCases {
+		result, err := simulatePolicy(r, testCase.req)
+		if err != testCase.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if result.Allowed != testCase.allowed || result.Owner != testCase.owner {
+			t.Fatalf("Test %d: expected allowed %v owner %v, got %v %v", i+1, testCase.allowed, testCase.owner, result.Allowed, result.Owner)
+		}
+		if len(result.Statements) != len(testCase.sources) {
+			t.Fatalf("Test %d: expected %d statements, got %d", i+1, len(testCase.sources), len(result.Statements))
+		}
+		for j, statement := range result.Statements {
+			if statement.Source != testCase.sources[j] {
+				t.Fatalf("Test %d: expected statement %d from %s, got %s", i+1, j+1, testCase.sources[j], statement.Source)
+			}
+		}
+	}
+}
@@ -234,6 +234,9 @@ func newAllSubsystems() {
 
 	// Create new bucket replication subsytem
 	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new bucket access logging subsystem
+	globalBucketLoggingSys = NewBucketLoggingSys()
 }
 
 func initServer(ctx context.Context, newObject ObjectLayer) error {
@@ -382,6 +385,20 @@ func initAllSubsystems(ctx context.Context, newObject ObjectLayer) (err error) {
 	// Initialize bucket targets sub-system.
 	globalBucketTargetSys.Init(ctx, buckets, newObject)
 
+	// Initialize status of the server pools, resuming any decommission.
+	if z, ok := newObject.(*erasureServerPools); ok {
+		if err = z.Init(ctx); err != nil {
+			return fmt.Errorf("Unable to initialize server pools: %w", err)
+		}
+	}
+
+	// Initialize remote tiers used by lifecycle transition.
+	if err = globalTierConfigMgr.Reload(ctx, newObject); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize remote tiers: %w", err))
+	} else if err = globalTierConfigMgr.migrateTransitionTargets(ctx, newObject, buckets); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to migrate lifecycle transition targets to remote tiers: %w", err))
+	}
+
 	return nil
 }
 
@@ -494,6 +511,7 @@ func serverMain(ctx *cli.Context) {
 		initAutoHeal(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
 		initBackgroundExpiry(GlobalContext, newObject)
+		initBitrotScrub(GlobalContext, newObject)
 	}
 
 	initDataCrawler(GlobalContext, newObject)
@@ -515,6 +533,8 @@ func serverMain(ctx *cli.Context) {
 	if globalIsErasure { // to be done after config init
 		initBackgroundReplication(GlobalContext, newObject)
 	}
+
+	initBucketLogging(GlobalContext, newObject)
 	if globalCacheConfig.Enabled {
 		// initialize the new disk cache objects.
 		var cacheAPI CacheObjectLayer
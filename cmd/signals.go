@@ -61,6 +61,11 @@ func handleSignals() {
 		}
 
 		if objAPI := newObjectLayerFn(); objAPI != nil {
+			// Stage the buffered access log records of buckets.
+			if globalBucketLoggingSys != nil {
+				globalBucketLoggingSys.stage(context.Background(), objAPI)
+			}
+
 			oerr = objAPI.Shutdown(context.Background())
 			logger.LogIf(context.Background(), oerr)
 		}
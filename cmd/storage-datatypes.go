@@ -123,6 +123,13 @@ type FileInfo struct {
 	// entries based on state of transition
 	TransitionStatus string
 
+	// TransitionedObjName is the name of the object on the remote tier
+	// holding the data of a transitioned version.
+	TransitionedObjName string
+
+	// TransitionTier is the name of the remote tier of a transitioned version.
+	TransitionTier string
+
 	// DataDir of the file
 	DataDir string
 
@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	storageRESTVersion       = "v27" // Add force-delete-marker to DeleteVersion
+	storageRESTVersion       = "v28" // Add transition tier to FileInfo
 	storageRESTVersionPrefix = SlashSeparator + storageRESTVersion
 	storageRESTPrefix        = minioReservedBucketPath + "/storage"
 )
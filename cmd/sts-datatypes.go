@@ -190,3 +190,19 @@ type AssumeRoleWithLDAPResponse struct {
 type LDAPIdentityResult struct {
 	Credentials auth.Credentials `xml:",omitempty"`
 }
+
+// AssumeRoleWithCertificateResponse contains the result of successful
+// AssumeRoleWithCertificate request
+type AssumeRoleWithCertificateResponse struct {
+	XMLName          xml.Name                  `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithCertificateResponse" json:"-"`
+	Result           CertificateIdentityResult `xml:"AssumeRoleWithCertificateResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
+// CertificateIdentityResult - contains credentials for a successful
+// AssumeRoleWithCertificate request.
+type CertificateIdentityResult struct {
+	Credentials auth.Credentials `xml:",omitempty"`
+}
@@ -20,12 +20,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/config/identity/openid"
+	xtls "github.com/minio/minio/cmd/config/identity/tls"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
@@ -46,10 +48,11 @@ const (
 	stsLDAPPassword     = "LDAPPassword"
 
 	// STS API action constants
-	clientGrants = "AssumeRoleWithClientGrants"
-	webIdentity  = "AssumeRoleWithWebIdentity"
-	ldapIdentity = "AssumeRoleWithLDAPIdentity"
-	assumeRole   = "AssumeRole"
+	clientGrants        = "AssumeRoleWithClientGrants"
+	webIdentity         = "AssumeRoleWithWebIdentity"
+	ldapIdentity        = "AssumeRoleWithLDAPIdentity"
+	certificateIdentity = "AssumeRoleWithCertificate"
+	assumeRole          = "AssumeRole"
 
 	stsRequestBodyLimit = 10 * (1 << 20) // 10 MiB
 
@@ -108,6 +111,11 @@ func registerSTSRouter(router *mux.Router) {
 		Queries(stsVersion, stsAPIVersion).
 		Queries(stsLDAPUsername, "{LDAPUsername:.*}").
 		Queries(stsLDAPPassword, "{LDAPPassword:.*}")
+
+	// AssumeRoleWithCertificate
+	stsRouter.Methods(http.MethodPost).HandlerFunc(httpTraceAll(sts.AssumeRoleWithCertificate)).
+		Queries(stsAction, certificateIdentity).
+		Queries(stsVersion, stsAPIVersion)
 }
 
 func checkAssumeRoleAuth(ctx context.Context, r *http.Request) (user auth.Credentials, isErrCodeSTS bool, stsErr STSErrorCode) {
@@ -258,6 +266,22 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 	writeSuccessResponseXML(w, encodeResponse(assumeRoleResponse))
 }
 
+// getOpenIDPolicyName returns the policies of the claims of a token
+// validated by the OpenID provider. JWT has requested a custom claim with
+// policy value set, this is a MinIO STS API specific value which should be
+// set and configured on your identity provider as part of JWT custom claims.
+// Tokens without this claim get the role policy of the provider, if any.
+func getOpenIDPolicyName(p *openid.JWT, claims map[string]interface{}) string {
+	policySet, ok := iampolicy.GetPoliciesFromClaims(claims, p.PolicyClaimName())
+	if ok {
+		return globalIAMSys.CurrentPolicies(strings.Join(policySet.ToSlice(), ","))
+	}
+	if p.RolePolicy != "" {
+		return globalIAMSys.CurrentPolicies(p.RolePolicy)
+	}
+	return ""
+}
+
 func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AssumeRoleSSOCommon")
 
@@ -277,6 +301,9 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	case ldapIdentity:
 		sts.AssumeRoleWithLDAPIdentity(w, r)
 		return
+	case certificateIdentity:
+		sts.AssumeRoleWithCertificate(w, r)
+		return
 	case clientGrants, webIdentity:
 	default:
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Unsupported action %s", action))
@@ -291,17 +318,17 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	v, err := globalOpenIDValidators.Get("jwt")
-	if err != nil {
-		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
-		return
-	}
-
 	token := r.Form.Get(stsToken)
 	if token == "" {
 		token = r.Form.Get(stsWebIdentityToken)
 	}
 
+	v, err := globalOpenIDValidators.GetByToken(token)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
 	m, err := v.Validate(token, r.Form.Get(stsDurationSeconds))
 	if err != nil {
 		switch err {
@@ -321,19 +348,10 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// JWT has requested a custom claim with policy value set.
-	// This is a MinIO STS API specific value, this value should
-	// be set and configured on your identity provider as part of
-	// JWT custom claims.
-	var policyName string
-	policySet, ok := iampolicy.GetPoliciesFromClaims(m, iamPolicyClaimNameOpenID())
-	if ok {
-		policyName = globalIAMSys.CurrentPolicies(strings.Join(policySet.ToSlice(), ","))
-	}
-
+	policyName := getOpenIDPolicyName(v, m)
 	if policyName == "" && globalPolicyOPA == nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
-			fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", iamPolicyClaimNameOpenID()))
+			fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", v.PolicyClaimName()))
 		return
 	}
 	m[iamPolicyClaimNameOpenID()] = policyName
@@ -548,3 +566,138 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
+
+// AssumeRoleWithCertificate - implements user auth with the TLS client
+// certificate of the request, verified against the configured CAs. The
+// subject common name of the certificate is the name of the policy of the
+// temporary credentials.
+//
+// Eg:-
+//    $ curl --cert client.crt --key client.key -X POST "https://minio:9000/?Action=AssumeRoleWithCertificate&Version=2011-06-15"
+func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AssumeRoleWithCertificate")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	// Parse the incoming form data.
+	if err := r.ParseForm(); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	if r.Form.Get(stsVersion) != stsAPIVersion {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter,
+			fmt.Errorf("Invalid STS API version %s, expecting %s", r.Form.Get("Version"), stsAPIVersion))
+		return
+	}
+
+	action := r.Form.Get(stsAction)
+	switch action {
+	case certificateIdentity:
+	default:
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Unsupported action %s", action))
+		return
+	}
+
+	if !globalSTSTLSConfig.Enabled {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSNotInitialized, errors.New("STS API 'AssumeRoleWithCertificate' is disabled"))
+		return
+	}
+
+	// Temporary credentials of LDAP deployments are authorized
+	// by their LDAP user and groups.
+	if globalLDAPConfig.Enabled {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSNotInitialized, errors.New("STS API 'AssumeRoleWithCertificate' is not supported with LDAP"))
+		return
+	}
+
+	// The server only asks for client certificates when TLS is
+	// enabled, it does not verify them.
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, xtls.ErrNoCertificate)
+		return
+	}
+
+	certificate, err := globalSTSTLSConfig.Verify(r.TLS.PeerCertificates)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSAccessDenied, err)
+		return
+	}
+
+	// The common name is the name of a single policy,
+	// the certificate is rejected if it does not exist.
+	policyName := certificate.Subject.CommonName
+	if _, err = globalIAMSys.InfoPolicy(policyName); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSAccessDenied,
+			fmt.Errorf("No policy %s for the certificate subject common name", policyName))
+		return
+	}
+
+	sessionPolicyStr := r.Form.Get(stsPolicy)
+	// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+	// The plain text that you use for both inline and managed session
+	// policies shouldn't exceed 2048 characters.
+	if len(sessionPolicyStr) > 2048 {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Session policy should not exceed 2048 characters"))
+		return
+	}
+
+	if len(sessionPolicyStr) > 0 {
+		sessionPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(sessionPolicyStr)))
+		if err != nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+			return
+		}
+
+		// Version in policy must not be empty
+		if sessionPolicy.Version == "" {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Version needs to be specified in session policy"))
+			return
+		}
+	}
+
+	expiryDur, err := globalSTSTLSConfig.GetExpiryDuration(r.Form.Get(stsDurationSeconds), certificate)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	m := map[string]interface{}{
+		expClaim:                   UTCNow().Add(expiryDur).Unix(),
+		subClaim:                   certificate.Subject.CommonName,
+		iamPolicyClaimNameOpenID(): policyName,
+	}
+
+	if len(sessionPolicyStr) > 0 {
+		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
+	}
+
+	secret := globalActiveCred.SecretKey
+	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
+		return
+	}
+
+	// Set the newly generated credentials.
+	if err = globalIAMSys.SetTempUser(cred.AccessKey, cred, policyName); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
+		return
+	}
+
+	// Notify all other MinIO peers to reload temp users
+	for _, nerr := range globalNotificationSys.LoadUser(cred.AccessKey, true) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	certificateIdentityResponse := &AssumeRoleWithCertificateResponse{
+		Result: CertificateIdentityResult{
+			Credentials: cred,
+		},
+	}
+	certificateIdentityResponse.ResponseMetadata.RequestID = w.Header().Get(xhttp.AmzRequestID)
+	writeSuccessResponseXML(w, encodeResponse(certificateIdentityResponse))
+}
@@ -1441,6 +1441,27 @@ func getBucketLifecycleURL(endPoint, bucketName string) (ret string) {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL For set/get CORS configuration of the bucket.
+func getBucketCorsURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("cors", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL For set/get website configuration of the bucket.
+func getBucketWebsiteURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("website", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL For set/get logging configuration of the bucket.
+func getBucketLoggingURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("logging", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for listing objects in the bucket with V1 legacy API.
 func getListObjectsV1URL(endPoint, bucketName, prefix, maxKeys, encodingType string) string {
 	queryValue := url.Values{}
@@ -1596,6 +1617,32 @@ func initObjectLayer(ctx context.Context, endpointServerPools EndpointServerPool
 	return objLayer, formattedDisks, nil
 }
 
+// prepareErasurePools - Instantiates an object layer of two erasure pools of
+// 4 disks each, returns it with the disks to remove.
+func prepareErasurePools(ctx context.Context, t *testing.T) (*erasureServerPools, []string) {
+	var (
+		pools EndpointServerPools
+		disks []string
+	)
+	for _, cmdLine := range []string{"pool1", "pool2"} {
+		fsDirs, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		disks = append(disks, fsDirs...)
+		pool := mustGetPoolEndpoints(fsDirs...)[0]
+		pool.CmdLine = cmdLine
+		pools = append(pools, pool)
+	}
+
+	obj, _, err := initObjectLayer(ctx, pools)
+	if err != nil {
+		removeRoots(disks)
+		t.Fatal(err)
+	}
+	return obj.(*erasureServerPools), disks
+}
+
 // removeRoots - Cleans up initialized directories during tests.
 func removeRoots(roots []string) {
 	for _, root := range roots {
@@ -2022,6 +2069,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "HeadObject":
 			// Register HeadObject handler.
 			bucket.Methods("Head").Path("/{object:.+}").HandlerFunc(api.HeadObjectHandler)
+		case "GetObjectAttributes":
+			// Register GetObjectAttributes handler.
+			bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(api.GetObjectAttributesHandler).Queries("attributes", "")
 		case "GetObject":
 			// Register GetObject handler.
 			bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(api.GetObjectHandler)
@@ -2049,6 +2099,25 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketLifecycleHandler).Queries("lifecycle", "")
 		case "DeleteBucketLifecycle":
 			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketLifecycleHandler).Queries("lifecycle", "")
+		case "GetBucketCors":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketCorsHandler).Queries("cors", "")
+		case "PutBucketCors":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketCorsHandler).Queries("cors", "")
+		case "DeleteBucketCors":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketCorsHandler).Queries("cors", "")
+		case "GetBucketWebsite":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketWebsiteHandler).Queries("website", "")
+		case "PutBucketWebsite":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketWebsiteHandler).Queries("website", "")
+		case "DeleteBucketWebsite":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketWebsiteHandler).Queries("website", "")
+		case "GetBucketLogging":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLoggingHandler).Queries("logging", "")
+		case "PutBucketLogging":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketLoggingHandler).Queries("logging", "")
+		case "Website":
+			// Register the website endpoint, served on path-style URLs in tests.
+			bucket.Methods(http.MethodGet, http.MethodHead).Path("/{object:.*}").HandlerFunc(api.WebsiteHandler)
 		case "GetBucketLocation":
 			// Register GetBucketLocation handler.
 			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLocationHandler).Queries("location", "")
@@ -2400,7 +2469,7 @@ func uploadTestObject(t *testing.T, apiRouter http.Handler, creds auth.Credentia
 				if etag == "" {
 					t.Fatalf("Unexpected empty etag")
 				}
-				cp = append(cp, CompletePart{partID, etag[1 : len(etag)-1]})
+				cp = append(cp, CompletePart{PartNumber: partID, ETag: etag[1 : len(etag)-1]})
 			} else {
 				t.Fatalf("Missing etag header")
 			}
@@ -0,0 +1,198 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// decryptTierReq reads and decrypts the body of a tier request, the
+// body carries credentials of the remote tier and is encrypted with
+// the secret key of the admin.
+func decryptTierReq(ctx context.Context, w http.ResponseWriter, r *http.Request, secretKey string, v interface{}) bool {
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return false
+	}
+
+	data, err := madmin.DecryptData(secretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return false
+	}
+
+	if err = json.Unmarshal(data, v); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return false
+	}
+	return true
+}
+
+// notifyTierConfig notifies peers to reload the saved remote tiers.
+func notifyTierConfig(ctx context.Context) {
+	for _, nerr := range globalNotificationSys.LoadTransitionTierConfig(ctx) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+// AddTierHandler - PUT /minio/admin/v3/tier
+// ----------
+// Adds a remote tier which lifecycle rules of any bucket can transition
+// objects to by naming it as the transition storage class.
+func (a adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	var tier madmin.TierConfig
+	if !decryptTierReq(ctx, w, r, cred.SecretKey, &tier) {
+		return
+	}
+
+	if err := globalTierConfigMgr.Add(ctx, objAPI, tier); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	notifyTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
+
+// ListTierHandler - GET /minio/admin/v3/tier
+// ----------
+// Lists the remote tiers without their credentials.
+func (a adminAPIHandlers) ListTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	data, err := json.Marshal(globalTierConfigMgr.ListTiers())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// EditTierHandler - POST /minio/admin/v3/tier/{tier}
+// ----------
+// Replaces the credentials of a remote tier, e.g when they are rotated
+// on the remote storage.
+func (a adminAPIHandlers) EditTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "EditTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, cred := validateAdminUsersReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	tierName := vars["tier"]
+
+	var creds madmin.TierCreds
+	if !decryptTierReq(ctx, w, r, cred.SecretKey, &creds) {
+		return
+	}
+
+	if err := globalTierConfigMgr.Edit(ctx, objAPI, tierName, creds); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	notifyTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
+
+// RemoveTierHandler - DELETE /minio/admin/v3/tier/{tier}
+// ----------
+// Removes a remote tier which is neither used by lifecycle rules nor
+// holds transitioned objects.
+func (a adminAPIHandlers) RemoveTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	tierName := vars["tier"]
+
+	inUse, err := tierInUse(ctx, objAPI, tierName)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalTierConfigMgr.Remove(ctx, objAPI, tierName, inUse); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	notifyTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
@@ -0,0 +1,428 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Remote tiers are saved in .minio.sys/config/tier-config.json,
+	// encrypted like the rest of the server config.
+	tierConfigFile = "tier-config.json"
+)
+
+var (
+	tierConfigPath = path.Join(minioConfigPrefix, tierConfigFile)
+
+	// Changes of the remote tiers are serialized across the cluster
+	// with this lock.
+	tierConfigLockPath = path.Join(minioConfigPrefix, "tier.lock")
+)
+
+const (
+	tierConfigVersion1 = 1
+	// Version 2 - the ILM remote targets of the buckets are migrated to tiers.
+	tierConfigVersion2 = 2
+
+	tierConfigVersion = tierConfigVersion2
+)
+
+// tierConfig is the saved form of the remote tiers.
+type tierConfig struct {
+	Version int                          `json:"version"`
+	Tiers   map[string]madmin.TierConfig `json:"tiers"`
+}
+
+var (
+	errTierAlreadyExists = AdminError{
+		Code:       "XMinioAdminTierAlreadyExists",
+		Message:    "Specified remote tier already exists",
+		StatusCode: http.StatusConflict,
+	}
+	errTierNotFound = AdminError{
+		Code:       "XMinioAdminTierNotFound",
+		Message:    "Specified remote tier was not found",
+		StatusCode: http.StatusNotFound,
+	}
+	errTierNameReserved = AdminError{
+		Code:       "XMinioAdminTierNameReserved",
+		Message:    "Specified remote tier name is a reserved storage class",
+		StatusCode: http.StatusBadRequest,
+	}
+	errTierTypeUnsupported = AdminError{
+		Code:       "XMinioAdminTierTypeUnsupported",
+		Message:    "Specified tier type is unsupported",
+		StatusCode: http.StatusBadRequest,
+	}
+	errTierBackendInUse = AdminError{
+		Code:       "XMinioAdminTierBackendInUse",
+		Message:    "Specified remote tier bucket and prefix already hold objects",
+		StatusCode: http.StatusConflict,
+	}
+	errTierInUse = AdminError{
+		Code:       "XMinioAdminTierInUse",
+		Message:    "Specified remote tier is used by lifecycle rules or holds transitioned objects",
+		StatusCode: http.StatusConflict,
+	}
+)
+
+// tierInvalidErr returns an admin error for an invalid tier config.
+func tierInvalidErr(err error) error {
+	return AdminError{
+		Code:       "XMinioAdminTierInvalidConfig",
+		Message:    err.Error(),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// tierBackendErr returns an admin error for a remote tier which
+// could not be reached with its credentials.
+func tierBackendErr(err error) error {
+	return AdminError{
+		Code:       "XMinioAdminTierBackendError",
+		Message:    "Unable to access remote tier: " + err.Error(),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// TierConfigMgr holds the remote tiers of the cluster, lifecycle rules
+// transition objects to a tier by naming it as the transition storage class.
+type TierConfigMgr struct {
+	sync.RWMutex
+	drivercache map[string]WarmBackend
+	Tiers       map[string]madmin.TierConfig
+}
+
+func newTierConfigMgr() *TierConfigMgr {
+	return &TierConfigMgr{
+		drivercache: make(map[string]WarmBackend),
+		Tiers:       make(map[string]madmin.TierConfig),
+	}
+}
+
+var globalTierConfigMgr = newTierConfigMgr()
+
+// IsTierValid returns true if there is a remote tier with this name.
+func (config *TierConfigMgr) IsTierValid(tierName string) bool {
+	config.RLock()
+	defer config.RUnlock()
+	_, ok := config.Tiers[strings.ToUpper(tierName)]
+	return ok
+}
+
+// update applies fn to the remote tiers saved by the cluster and saves
+// them. The tiers are locked across the cluster from load to save, so
+// that concurrent changes on other servers are not lost, and the tiers
+// in memory are only replaced once saved.
+func (config *TierConfigMgr) update(ctx context.Context, objAPI ObjectLayer, fn func(cfg *tierConfig) error) error {
+	lk := objAPI.NewNSLock(minioMetaBucket, tierConfigLockPath)
+	if err := lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	cfg, err := loadTierConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if err = fn(cfg); err != nil {
+		return err
+	}
+	if err = saveTierConfig(ctx, objAPI, cfg.Tiers); err != nil {
+		return err
+	}
+
+	config.Lock()
+	defer config.Unlock()
+	config.Tiers = cfg.Tiers
+	// Drivers are created again with the saved credentials.
+	config.drivercache = make(map[string]WarmBackend)
+	return nil
+}
+
+// Add adds the remote tier once its remote storage is verified to be
+// reachable and unused.
+func (config *TierConfigMgr) Add(ctx context.Context, objAPI ObjectLayer, tier madmin.TierConfig) error {
+	if err := tier.Validate(); err != nil {
+		return tierInvalidErr(err)
+	}
+	if tier.Name == storageclass.STANDARD || tier.Name == storageclass.RRS {
+		return errTierNameReserved
+	}
+	if config.IsTierValid(tier.Name) {
+		return errTierAlreadyExists
+	}
+
+	d, err := newWarmBackend(GlobalContext, tier)
+	if err != nil {
+		return tierBackendErr(err)
+	}
+	if err = checkWarmBackend(ctx, d); err != nil {
+		return err
+	}
+
+	return config.update(ctx, objAPI, func(cfg *tierConfig) error {
+		if _, ok := cfg.Tiers[tier.Name]; ok {
+			return errTierAlreadyExists
+		}
+		cfg.Tiers[tier.Name] = tier
+		return nil
+	})
+}
+
+// Remove removes the remote tier, inUse reports whether lifecycle rules
+// still transition to the tier.
+func (config *TierConfigMgr) Remove(ctx context.Context, objAPI ObjectLayer, tierName string, inUse bool) error {
+	tierName = strings.ToUpper(tierName)
+	d, err := config.getDriver(tierName)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errTierInUse
+	}
+	// Transitioned versions are only readable while their tier exists.
+	hasObjects, err := d.InUse(ctx)
+	if err != nil {
+		return tierBackendErr(err)
+	}
+	if hasObjects {
+		return errTierInUse
+	}
+
+	return config.update(ctx, objAPI, func(cfg *tierConfig) error {
+		if _, ok := cfg.Tiers[tierName]; !ok {
+			return errTierNotFound
+		}
+		delete(cfg.Tiers, tierName)
+		return nil
+	})
+}
+
+// Edit replaces the credentials of the remote tier.
+func (config *TierConfigMgr) Edit(ctx context.Context, objAPI ObjectLayer, tierName string, creds madmin.TierCreds) error {
+	tierName = strings.ToUpper(tierName)
+	config.RLock()
+	tier, ok := config.Tiers[tierName]
+	config.RUnlock()
+	if !ok {
+		return errTierNotFound
+	}
+
+	switch tier.Type {
+	case madmin.TierTypeS3:
+		s3 := *tier.S3
+		s3.AccessKey, s3.SecretKey = creds.AccessKey, creds.SecretKey
+		tier.S3 = &s3
+	case madmin.TierTypeAzure:
+		az := *tier.Azure
+		az.AccountKey = creds.SecretKey
+		tier.Azure = &az
+	case madmin.TierTypeGCS:
+		gcs := *tier.GCS
+		gcs.Creds = string(creds.CredsJSON)
+		tier.GCS = &gcs
+	}
+
+	d, err := newWarmBackend(GlobalContext, tier)
+	if err != nil {
+		return tierBackendErr(err)
+	}
+	// The tier already holds transitioned objects, only
+	// check the new credentials give access to it.
+	if _, err = d.InUse(ctx); err != nil {
+		return tierBackendErr(err)
+	}
+
+	return config.update(ctx, objAPI, func(cfg *tierConfig) error {
+		if _, ok := cfg.Tiers[tierName]; !ok {
+			return errTierNotFound
+		}
+		cfg.Tiers[tierName] = tier
+		return nil
+	})
+}
+
+// ListTiers returns the remote tiers sorted by name, without their credentials.
+func (config *TierConfigMgr) ListTiers() []madmin.TierConfig {
+	config.RLock()
+	defer config.RUnlock()
+	tiers := make([]madmin.TierConfig, 0, len(config.Tiers))
+	for _, tier := range config.Tiers {
+		tiers = append(tiers, tier.Redacted())
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].Name < tiers[j].Name
+	})
+	return tiers
+}
+
+// getDriver returns the remote storage of the tier.
+func (config *TierConfigMgr) getDriver(tierName string) (WarmBackend, error) {
+	tierName = strings.ToUpper(tierName)
+	config.RLock()
+	d, ok := config.drivercache[tierName]
+	tier, valid := config.Tiers[tierName]
+	config.RUnlock()
+	if ok {
+		return d, nil
+	}
+	if !valid {
+		return nil, errTierNotFound
+	}
+
+	d, err := newWarmBackend(GlobalContext, tier)
+	if err != nil {
+		return nil, err
+	}
+	config.Lock()
+	config.drivercache[tierName] = d
+	config.Unlock()
+	return d, nil
+}
+
+// saveTierConfig persists the remote tiers, the config is encrypted with
+// the server credentials when the server config is.
+func saveTierConfig(ctx context.Context, objAPI ObjectLayer, tiers map[string]madmin.TierConfig) error {
+	data, err := json.Marshal(tierConfig{
+		Version: tierConfigVersion,
+		Tiers:   tiers,
+	})
+	if err != nil {
+		return err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, tierConfigPath, data)
+}
+
+// Reload reloads the remote tiers saved by any server of the cluster.
+func (config *TierConfigMgr) Reload(ctx context.Context, objAPI ObjectLayer) error {
+	cfg, err := loadTierConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	config.Lock()
+	defer config.Unlock()
+	config.Tiers = cfg.Tiers
+	// Drivers are created again with the reloaded credentials.
+	config.drivercache = make(map[string]WarmBackend)
+	return nil
+}
+
+func loadTierConfig(ctx context.Context, objAPI ObjectLayer) (*tierConfig, error) {
+	data, err := readConfig(ctx, objAPI, tierConfigPath)
+	if err != nil {
+		if err == errConfigNotFound {
+			return &tierConfig{Tiers: make(map[string]madmin.TierConfig)}, nil
+		}
+		return nil, err
+	}
+
+	if globalConfigEncrypted && !utf8.Valid(data) {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cfg tierConfig
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Tiers == nil {
+		cfg.Tiers = make(map[string]madmin.TierConfig)
+	}
+	return &cfg, nil
+}
+
+// migrateTransitionTargets adds a remote tier for the ILM remote targets of
+// the buckets, which lifecycle transition used before remote tiers. A tier is
+// named by the label of its target, so that the lifecycle rules naming the
+// label keep transitioning objects to the same remote bucket. The targets are
+// kept to read the versions transitioned to them before.
+func (config *TierConfigMgr) migrateTransitionTargets(ctx context.Context, objAPI ObjectLayer, buckets []BucketInfo) error {
+	cfg, err := loadTierConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if cfg.Version >= tierConfigVersion2 {
+		return nil
+	}
+
+	return config.update(ctx, objAPI, func(cfg *tierConfig) error {
+		// Another server may have migrated the targets since the
+		// config was read, before the lock was taken.
+		if cfg.Version >= tierConfigVersion2 {
+			return nil
+		}
+		for _, bucket := range buckets {
+			tgts, err := globalBucketMetadataSys.GetBucketTargetsConfig(bucket.Name)
+			if err != nil {
+				continue
+			}
+			for _, tgt := range tgts.Targets {
+				if tgt.Type != madmin.ILMService || tgt.Label == "" || tgt.Credentials == nil {
+					continue
+				}
+				name := strings.ToUpper(tgt.Label)
+				if _, ok := cfg.Tiers[name]; ok {
+					// Tiers are shared by all the buckets, a label
+					// of several buckets can only name one target.
+					logger.Info("Remote target %s of bucket %s is not migrated, remote tier %s already exists",
+						tgt.Arn, bucket.Name, name)
+					continue
+				}
+				scheme := "http"
+				if tgt.Secure {
+					scheme = "https"
+				}
+				cfg.Tiers[name] = madmin.TierConfig{
+					Name: name,
+					Type: madmin.TierTypeS3,
+					S3: &madmin.TierS3{
+						Endpoint:  scheme + "://" + tgt.Endpoint,
+						AccessKey: tgt.Credentials.AccessKey,
+						SecretKey: tgt.Credentials.SecretKey,
+						Bucket:    tgt.TargetBucket,
+						Region:    tgt.Region,
+					},
+				}
+			}
+		}
+		return nil
+	})
+}
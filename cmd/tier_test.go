@@ -0,0 +1,379 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// testTierRemote - an S3 remote tier listing objects to the
+// requests signed with its access key.
+type testTierRemote struct {
+	sync.Mutex
+	accessKey string
+	objects   []string
+}
+
+func (tr *testTierRemote) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+		return
+	}
+
+	tr.Lock()
+	defer tr.Unlock()
+	if !strings.Contains(r.Header.Get("Authorization"), "Credential="+tr.accessKey+"/") {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<Error><Code>InvalidAccessKeyId</Code><Message>The access key does not exist.</Message></Error>`))
+		return
+	}
+
+	var contents strings.Builder
+	for _, object := range tr.objects {
+		contents.WriteString(`<Contents><Key>` + object + `</Key><LastModified>2021-01-01T00:00:00.000Z</LastModified><ETag>"d41d8cd98f00b204e9800998ecf8427e"</ETag><Size>0</Size><StorageClass>STANDARD</StorageClass></Contents>`)
+	}
+	w.Write([]byte(`<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>tier</Name><Prefix></Prefix><MaxKeys>1</MaxKeys><IsTruncated>false</IsTruncated>` + contents.String() + `</ListBucketResult>`))
+}
+
+func (tr *testTierRemote) setAccessKey(accessKey string) {
+	tr.Lock()
+	defer tr.Unlock()
+	tr.accessKey = accessKey
+}
+
+func (tr *testTierRemote) setObjects(objects ...string) {
+	tr.Lock()
+	defer tr.Unlock()
+	tr.objects = objects
+}
+
+func newTestTierS3(name, endpoint, accessKey string) madmin.TierConfig {
+	return madmin.TierConfig{
+		Name: name,
+		Type: madmin.TierTypeS3,
+		S3: &madmin.TierS3{
+			Endpoint:  endpoint,
+			AccessKey: accessKey,
+			SecretKey: "secret" + accessKey,
+			Bucket:    "tier",
+			Region:    globalMinioDefaultRegion,
+		},
+	}
+}
+
+func TestTierConfigMgr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+	setObjectLayer(z)
+
+	remote := &testTierRemote{accessKey: "access1"}
+	ts := httptest.NewServer(remote)
+	defer ts.Close()
+
+	config := newTierConfigMgr()
+	tier := newTestTierS3("WARM", ts.URL, "access1")
+
+	// Add
+	invalid := newTestTierS3("warm", ts.URL, "access1")
+	if err := config.Add(ctx, z, invalid); err == nil || err.(AdminError).Code != "XMinioAdminTierInvalidConfig" {
+		t.Fatalf("expected an invalid config adding a tier with a lower case name, got %v", err)
+	}
+	if err := config.Add(ctx, z, newTestTierS3("STANDARD", ts.URL, "access1")); err != errTierNameReserved {
+		t.Fatalf("expected %v, got %v", errTierNameReserved, err)
+	}
+	if err := config.Add(ctx, z, newTestTierS3("WARM", ts.URL, "access2")); err == nil || err.(AdminError).Code != "XMinioAdminTierBackendError" {
+		t.Fatalf("expected a backend error adding a tier with invalid credentials, got %v", err)
+	}
+	remote.setObjects("object")
+	if err := config.Add(ctx, z, tier); err != errTierBackendInUse {
+		t.Fatalf("expected %v, got %v", errTierBackendInUse, err)
+	}
+	remote.setObjects()
+	if err := config.Add(ctx, z, tier); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Add(ctx, z, tier); err != errTierAlreadyExists {
+		t.Fatalf("expected %v, got %v", errTierAlreadyExists, err)
+	}
+	if !config.IsTierValid("warm") {
+		t.Fatal("expected the tier to be valid regardless of case")
+	}
+	tiers := config.ListTiers()
+	if len(tiers) != 1 || tiers[0].Name != "WARM" || tiers[0].S3.SecretKey == tier.S3.SecretKey {
+		t.Fatalf("expected the tier listed without its credentials, got %+v", tiers)
+	}
+
+	// Tiers added by another server are reloaded.
+	other := newTierConfigMgr()
+	if err := other.Reload(ctx, z); err != nil {
+		t.Fatal(err)
+	}
+	if !other.IsTierValid("WARM") {
+		t.Fatal("expected the saved tier to be reloaded")
+	}
+
+	// Edit
+	if err := config.Edit(ctx, z, "COLD", madmin.TierCreds{AccessKey: "access2", SecretKey: "secretaccess2"}); err != errTierNotFound {
+		t.Fatalf("expected %v, got %v", errTierNotFound, err)
+	}
+	if err := config.Edit(ctx, z, "warm", madmin.TierCreds{AccessKey: "access2", SecretKey: "secretaccess2"}); err == nil || err.(AdminError).Code != "XMinioAdminTierBackendError" {
+		t.Fatalf("expected a backend error editing a tier with invalid credentials, got %v", err)
+	}
+	remote.setAccessKey("access2")
+	// Editing a tier already holding objects is allowed.
+	remote.setObjects("object")
+	if err := config.Edit(ctx, z, "warm", madmin.TierCreds{AccessKey: "access2", SecretKey: "secretaccess2"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadTierConfig(ctx, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s3 := cfg.Tiers["WARM"].S3; s3.AccessKey != "access2" || s3.SecretKey != "secretaccess2" || s3.Bucket != tier.S3.Bucket {
+		t.Fatalf("expected the credentials of the tier to be saved, got %+v", s3)
+	}
+	if tier.S3.AccessKey != "access1" {
+		t.Fatal("expected the tier added not to be modified by the edit")
+	}
+
+	// Remove
+	if err = config.Remove(ctx, z, "COLD", false); err != errTierNotFound {
+		t.Fatalf("expected %v, got %v", errTierNotFound, err)
+	}
+	if err = config.Remove(ctx, z, "WARM", true); err != errTierInUse {
+		t.Fatalf("expected %v removing a tier used by lifecycle rules, got %v", errTierInUse, err)
+	}
+	if err = config.Remove(ctx, z, "WARM", false); err != errTierInUse {
+		t.Fatalf("expected %v removing a tier holding objects, got %v", errTierInUse, err)
+	}
+	remote.setObjects()
+	if err = config.Remove(ctx, z, "warm", false); err != nil {
+		t.Fatal(err)
+	}
+	if config.IsTierValid("WARM") {
+		t.Fatal("expected the tier to be removed")
+	}
+	if cfg, err = loadTierConfig(ctx, z); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Tiers) != 0 {
+		t.Fatalf("expected no saved tiers, got %+v", cfg.Tiers)
+	}
+}
+
+func TestTierConfigPersistence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The tier config is saved while the object layer is initialized.
+	prevEncrypted, prevCred := globalConfigEncrypted, globalActiveCred
+	defer func() { globalConfigEncrypted, globalActiveCred = prevEncrypted, prevCred }()
+	globalActiveCred = auth.Credentials{AccessKey: "minioadmin", SecretKey: "minioadmin"}
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+
+	// No tiers are added yet.
+	cfg, err := loadTierConfig(ctx, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Tiers == nil || len(cfg.Tiers) != 0 {
+		t.Fatalf("expected no tiers, got %+v", cfg.Tiers)
+	}
+
+	tiers := map[string]madmin.TierConfig{
+		"WARM": newTestTierS3("WARM", "https://s3.amazonaws.com", "access1"),
+	}
+	for _, encrypted := range []bool{false, true} {
+		globalConfigEncrypted = encrypted
+		if err = saveTierConfig(ctx, z, tiers); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := readConfig(ctx, z, tierConfigPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if encrypted == (utf8.Valid(data) && json.Valid(data)) {
+			t.Fatalf("Encrypted %t: unexpected saved tier config %q", encrypted, data)
+		}
+
+		cfg, err = loadTierConfig(ctx, z)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Version != tierConfigVersion || len(cfg.Tiers) != 1 || *cfg.Tiers["WARM"].S3 != *tiers["WARM"].S3 {
+			t.Fatalf("Encrypted %t: expected the saved tiers to be loaded, got %+v", encrypted, cfg)
+		}
+	}
+
+	// A config saved before the server config was encrypted is still read.
+	globalConfigEncrypted = false
+	if err = saveTierConfig(ctx, z, tiers); err != nil {
+		t.Fatal(err)
+	}
+	globalConfigEncrypted = true
+	if cfg, err = loadTierConfig(ctx, z); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Tiers) != 1 {
+		t.Fatalf("expected the plain tier config to be loaded, got %+v", cfg)
+	}
+
+	// The config can not be read with other credentials.
+	if err = saveTierConfig(ctx, z, tiers); err != nil {
+		t.Fatal(err)
+	}
+	globalActiveCred = auth.Credentials{AccessKey: "minioadmin", SecretKey: "othersecret"}
+	if _, err = loadTierConfig(ctx, z); err == nil {
+		t.Fatal("expected the tier config not to be decrypted with other credentials")
+	}
+}
+
+func TestMigrateTransitionTargets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, disks := prepareErasurePools(ctx, t)
+	defer removeRoots(disks)
+	setObjectLayer(z)
+
+	setTargets := func(bucket string, targets ...madmin.BucketTarget) {
+		meta := newBucketMetadata(bucket)
+		meta.bucketTargetConfig = &madmin.BucketTargets{Targets: targets}
+		globalBucketMetadataSys.Set(bucket, meta)
+	}
+	creds := &auth.Credentials{AccessKey: "access1", SecretKey: "secret1"}
+	setTargets("bucket1",
+		madmin.BucketTarget{Arn: "arn1", Type: madmin.ILMService, Label: "warm", Endpoint: "warm.example.com", Secure: true, Credentials: creds, TargetBucket: "warmbucket", Region: "us-east-1"},
+		// Replication targets are not tiers.
+		madmin.BucketTarget{Arn: "arn2", Type: madmin.ReplicationService, Label: "replica", Endpoint: "replica.example.com", Credentials: creds, TargetBucket: "replica"},
+		// Targets without a label can not be named by lifecycle rules.
+		madmin.BucketTarget{Arn: "arn3", Type: madmin.ILMService, Endpoint: "nolabel.example.com", Credentials: creds, TargetBucket: "nolabel"},
+	)
+	setTargets("bucket2",
+		// The label is already migrated from the first bucket.
+		madmin.BucketTarget{Arn: "arn4", Type: madmin.ILMService, Label: "WARM", Endpoint: "other.example.com", Credentials: creds, TargetBucket: "other"},
+		madmin.BucketTarget{Arn: "arn5", Type: madmin.ILMService, Label: "cold", Endpoint: "cold.example.com", Credentials: creds, TargetBucket: "coldbucket"},
+	)
+	// Buckets without targets are skipped.
+	globalBucketMetadataSys.Set("bucket3", newBucketMetadata("bucket3"))
+
+	// Tiers saved before the targets were migrated.
+	data, err := json.Marshal(tierConfig{Version: tierConfigVersion1, Tiers: map[string]madmin.TierConfig{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, z, tierConfigPath, data); err != nil {
+		t.Fatal(err)
+	}
+
+	buckets := []BucketInfo{{Name: "bucket1"}, {Name: "bucket2"}, {Name: "bucket3"}}
+	config := newTierConfigMgr()
+	if err = config.migrateTransitionTargets(ctx, z, buckets); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]madmin.TierS3{
+		"WARM": {Endpoint: "https://warm.example.com", AccessKey: "access1", SecretKey: "secret1", Bucket: "warmbucket", Region: "us-east-1"},
+		"COLD": {Endpoint: "http://cold.example.com", AccessKey: "access1", SecretKey: "secret1", Bucket: "coldbucket"},
+	}
+	cfg, err := loadTierConfig(ctx, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Version != tierConfigVersion2 || len(cfg.Tiers) != len(expected) {
+		t.Fatalf("expected the tiers %+v to be migrated, got %+v", expected, cfg)
+	}
+	for name, s3 := range expected {
+		tier, ok := cfg.Tiers[name]
+		if !ok || tier.Name != name || tier.Type != madmin.TierTypeS3 || *tier.S3 != s3 {
+			t.Fatalf("expected the tier %s migrated to %+v, got %+v", name, s3, tier.S3)
+		}
+		if !config.IsTierValid(name) {
+			t.Fatalf("expected the migrated tier %s to be valid", name)
+		}
+	}
+
+	// Targets are only migrated once.
+	setTargets("bucket3", madmin.BucketTarget{Arn: "arn6", Type: madmin.ILMService, Label: "hot", Endpoint: "hot.example.com", Credentials: creds, TargetBucket: "hot"})
+	if err = config.migrateTransitionTargets(ctx, z, buckets); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = loadTierConfig(ctx, z); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cfg.Tiers["HOT"]; ok || len(cfg.Tiers) != len(expected) {
+		t.Fatalf("expected the targets not to be migrated again, got %+v", cfg.Tiers)
+	}
+}
+
+func TestRemoveTransitionTarget(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	prevTargetSys := globalBucketTargetSys
+	globalBucketTargetSys = NewBucketTargetSys()
+	defer func() { globalBucketTargetSys = prevTargetSys }()
+
+	const (
+		warmArn = "arn:minio:ilm:us-east-1:id1:warmbucket"
+		coldArn = "arn:minio:ilm:us-east-1:id2:coldbucket"
+	)
+	globalBucketTargetSys.targetsMap["bucket"] = []madmin.BucketTarget{
+		{Arn: warmArn, Type: madmin.ILMService, Label: "warm", TargetBucket: "warmbucket"},
+		{Arn: coldArn, Type: madmin.ILMService, Label: "cold", TargetBucket: "coldbucket"},
+	}
+
+	lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration><Rule><ID>rule1</ID><Status>Enabled</Status><Filter></Filter><Transition><Days>1</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata("bucket")
+	meta.lifecycleConfig = lc
+	globalBucketMetadataSys.Set("bucket", meta)
+
+	// The versions transitioned to the target are read through the rule.
+	if err = globalBucketTargetSys.RemoveTarget(ctx, "bucket", warmArn); err == nil {
+		t.Fatal("expected the removal of a target used by lifecycle transition to be rejected")
+	}
+	if _, ok := err.(BucketRemoteRemoveDisallowed); !ok {
+		t.Fatalf("expected %T, got %v", BucketRemoteRemoveDisallowed{}, err)
+	}
+
+	if err = globalBucketTargetSys.RemoveTarget(ctx, "bucket", coldArn); err != nil {
+		t.Fatal(err)
+	}
+	if tgts := globalBucketTargetSys.targetsMap["bucket"]; len(tgts) != 1 || tgts[0].Arn != warmArn {
+		t.Fatalf("expected only %s to be left, got %+v", warmArn, tgts)
+	}
+}
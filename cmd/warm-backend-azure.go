@@ -0,0 +1,95 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+type warmBackendAzure struct {
+	serviceURL azblob.ServiceURL
+	Bucket     string
+	Prefix     string
+}
+
+func (az *warmBackendAzure) getBlob(object string) azblob.BlockBlobURL {
+	return az.serviceURL.NewContainerURL(az.Bucket).NewBlockBlobURL(tierObjectName(az.Prefix, object))
+}
+
+func (az *warmBackendAzure) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	_, err := azblob.UploadStreamToBlockBlob(ctx, r, az.getBlob(object), azblob.UploadStreamToBlockBlobOptions{})
+	return err
+}
+
+func (az *warmBackendAzure) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	offset, count := int64(0), int64(azblob.CountToEnd)
+	if opts.startOffset >= 0 && opts.length > 0 {
+		offset, count = opts.startOffset, opts.length
+	}
+	resp, err := az.getBlob(object).Download(ctx, offset, count, azblob.BlobAccessConditions{}, false)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(azblob.RetryReaderOptions{}), nil
+}
+
+func (az *warmBackendAzure) Remove(ctx context.Context, object string) error {
+	_, err := az.getBlob(object).Delete(ctx, azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{})
+	return err
+}
+
+func (az *warmBackendAzure) InUse(ctx context.Context) (bool, error) {
+	prefix := az.Prefix
+	if prefix != "" {
+		prefix += SlashSeparator
+	}
+	resp, err := az.serviceURL.NewContainerURL(az.Bucket).ListBlobsHierarchySegment(ctx, azblob.Marker{}, SlashSeparator, azblob.ListBlobsSegmentOptions{
+		Prefix:     prefix,
+		MaxResults: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(resp.Segment.BlobPrefixes) > 0 || len(resp.Segment.BlobItems) > 0, nil
+}
+
+func newWarmBackendAzure(conf madmin.TierAzure) (*warmBackendAzure, error) {
+	credential, err := azblob.NewSharedKeyCredential(conf.AccountName, conf.AccountKey)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := conf.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", conf.AccountName)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	p := azblob.NewPipeline(credential, azblob.PipelineOptions{})
+	return &warmBackendAzure{
+		serviceURL: azblob.NewServiceURL(*u, p),
+		Bucket:     conf.Bucket,
+		Prefix:     conf.Prefix,
+	}, nil
+}
@@ -0,0 +1,96 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/minio/minio/pkg/madmin"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+type warmBackendGCS struct {
+	client *storage.Client
+	Bucket string
+	Prefix string
+}
+
+func (gcs *warmBackendGCS) getObject(object string) *storage.ObjectHandle {
+	return gcs.client.Bucket(gcs.Bucket).Object(tierObjectName(gcs.Prefix, object))
+}
+
+func (gcs *warmBackendGCS) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	w := gcs.getObject(object).NewWriter(ctx)
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (gcs *warmBackendGCS) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	offset, length := int64(0), int64(-1)
+	if opts.startOffset >= 0 && opts.length > 0 {
+		offset, length = opts.startOffset, opts.length
+	}
+	return gcs.getObject(object).NewRangeReader(ctx, offset, length)
+}
+
+func (gcs *warmBackendGCS) Remove(ctx context.Context, object string) error {
+	return gcs.getObject(object).Delete(ctx)
+}
+
+func (gcs *warmBackendGCS) InUse(ctx context.Context) (bool, error) {
+	prefix := gcs.Prefix
+	if prefix != "" {
+		prefix += SlashSeparator
+	}
+	it := gcs.client.Bucket(gcs.Bucket).Objects(ctx, &storage.Query{
+		Prefix:    prefix,
+		Delimiter: SlashSeparator,
+	})
+	if _, err := it.Next(); err != nil {
+		if err == iterator.Done {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func newWarmBackendGCS(ctx context.Context, conf madmin.TierGCS) (*warmBackendGCS, error) {
+	opts := []option.ClientOption{
+		option.WithCredentialsJSON([]byte(conf.Creds)),
+		option.WithUserAgent(fmt.Sprintf("MinIO/%s (GPN:MinIO;)", Version)),
+	}
+	if conf.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(conf.Endpoint))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &warmBackendGCS{
+		client: client,
+		Bucket: conf.Bucket,
+		Prefix: conf.Prefix,
+	}, nil
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+type warmBackendS3 struct {
+	client       *miniogo.Client
+	core         *miniogo.Core
+	Bucket       string
+	Prefix       string
+	StorageClass string
+}
+
+func (s3 *warmBackendS3) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	_, err := s3.client.PutObject(ctx, s3.Bucket, tierObjectName(s3.Prefix, object), r, length, miniogo.PutObjectOptions{
+		StorageClass: s3.StorageClass,
+	})
+	return err
+}
+
+func (s3 *warmBackendS3) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	gopts := miniogo.GetObjectOptions{}
+	if opts.startOffset >= 0 && opts.length > 0 {
+		if err := gopts.SetRange(opts.startOffset, opts.startOffset+opts.length-1); err != nil {
+			return nil, err
+		}
+	}
+	r, _, _, err := s3.core.GetObject(ctx, s3.Bucket, tierObjectName(s3.Prefix, object), gopts)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s3 *warmBackendS3) Remove(ctx context.Context, object string) error {
+	return s3.client.RemoveObject(ctx, s3.Bucket, tierObjectName(s3.Prefix, object), miniogo.RemoveObjectOptions{})
+}
+
+func (s3 *warmBackendS3) InUse(ctx context.Context) (bool, error) {
+	prefix := s3.Prefix
+	if prefix != "" {
+		prefix += SlashSeparator
+	}
+	result, err := s3.core.ListObjectsV2(s3.Bucket, prefix, "", false, SlashSeparator, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(result.CommonPrefixes) > 0 || len(result.Contents) > 0, nil
+}
+
+// getRemoteTierTransport contains a singleton roundtripper shared by S3 tiers.
+var getRemoteTierTransport http.RoundTripper
+var getRemoteTierTransportOnce sync.Once
+
+func newWarmBackendS3(conf madmin.TierS3) (*warmBackendS3, error) {
+	u, err := url.Parse(conf.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	getRemoteTierTransportOnce.Do(func() {
+		getRemoteTierTransport = newGatewayHTTPTransport(10 * time.Minute)
+	})
+	opts := &miniogo.Options{
+		Creds:     credentials.NewStaticV4(conf.AccessKey, conf.SecretKey, ""),
+		Secure:    u.Scheme == "https",
+		Region:    conf.Region,
+		Transport: getRemoteTierTransport,
+	}
+	client, err := miniogo.New(u.Host, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &warmBackendS3{
+		client:       client,
+		core:         &miniogo.Core{Client: client},
+		Bucket:       conf.Bucket,
+		Prefix:       conf.Prefix,
+		StorageClass: conf.StorageClass,
+	}, nil
+}
@@ -0,0 +1,56 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	miniogo "github.com/minio/minio-go/v7"
+)
+
+// warmBackendTarget - the ILM remote target of a bucket, where versions
+// were transitioned before remote tiers. A version was saved on the remote
+// bucket under the object name and with its own version ID, transitioned
+// versions are only read and removed there.
+type warmBackendTarget struct {
+	client    *TargetClient
+	Bucket    string
+	VersionID string
+}
+
+func (t *warmBackendTarget) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	return NotImplemented{}
+}
+
+func (t *warmBackendTarget) Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	gopts := miniogo.GetObjectOptions{VersionID: t.VersionID}
+	if opts.startOffset >= 0 && opts.length > 0 {
+		if err := gopts.SetRange(opts.startOffset, opts.startOffset+opts.length-1); err != nil {
+			return nil, err
+		}
+	}
+	return t.client.GetObject(ctx, t.Bucket, object, gopts)
+}
+
+func (t *warmBackendTarget) Remove(ctx context.Context, object string) error {
+	return t.client.RemoveObject(ctx, t.Bucket, object, miniogo.RemoveObjectOptions{VersionID: t.VersionID})
+}
+
+func (t *warmBackendTarget) InUse(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// WarmBackendGetOpts - range of the remote object to read, the whole
+// object is read when length is negative.
+type WarmBackendGetOpts struct {
+	startOffset int64
+	length      int64
+}
+
+// WarmBackend - the remote storage of a tier, objects are addressed
+// by their name relative to the bucket and prefix of the tier.
+type WarmBackend interface {
+	Put(ctx context.Context, object string, r io.Reader, length int64) error
+	Get(ctx context.Context, object string, opts WarmBackendGetOpts) (io.ReadCloser, error)
+	Remove(ctx context.Context, object string) error
+	// InUse returns true if the bucket and prefix of the tier already
+	// hold objects.
+	InUse(ctx context.Context) (bool, error)
+}
+
+// newWarmBackend returns the remote storage of the tier.
+func newWarmBackend(ctx context.Context, tier madmin.TierConfig) (WarmBackend, error) {
+	switch tier.Type {
+	case madmin.TierTypeS3:
+		return newWarmBackendS3(*tier.S3)
+	case madmin.TierTypeAzure:
+		return newWarmBackendAzure(*tier.Azure)
+	case madmin.TierTypeGCS:
+		return newWarmBackendGCS(ctx, *tier.GCS)
+	}
+	return nil, errTierTypeUnsupported
+}
+
+// checkWarmBackend verifies the tier is reachable with its credentials and
+// that its bucket and prefix are not used by anything else.
+func checkWarmBackend(ctx context.Context, w WarmBackend) error {
+	inUse, err := w.InUse(ctx)
+	if err != nil {
+		return tierBackendErr(err)
+	}
+	if inUse {
+		return errTierBackendInUse
+	}
+	return nil
+}
+
+// tierObjectName returns the name of the object within the tier prefix.
+func tierObjectName(prefix, object string) string {
+	if prefix == "" {
+		return object
+	}
+	return pathJoin(prefix, object)
+}
@@ -776,14 +776,8 @@ next:
 				}
 				scheduleReplicationDelete(ctx, dobj, objectAPI, replicateSync)
 			}
-			if goi.TransitionStatus == lifecycle.TransitionComplete && err == nil && goi.VersionID == "" {
-				deleteTransitionedObject(ctx, newObjectLayerFn(), args.BucketName, objectName, lifecycle.ObjectOpts{
-					Name:         objectName,
-					UserTags:     goi.UserTags,
-					VersionID:    goi.VersionID,
-					DeleteMarker: goi.DeleteMarker,
-					IsLatest:     goi.IsLatest,
-				}, false, true)
+			if goi.TransitionStatus == lifecycle.TransitionComplete && err == nil && isTransitionedVersionDeleted(args.BucketName, "", goi.VersionID) {
+				deleteTransitionedObject(ctx, newObjectLayerFn(), goi, false, true)
 			}
 
 			logger.LogIf(ctx, err)
@@ -1200,6 +1194,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	var pReader *PutObjReader
 	var reader io.Reader = r.Body
 	actualSize := size
+	var idxCb func() []byte
 
 	hashReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
 	if err != nil {
@@ -1207,9 +1202,10 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, bucket, object) && size > 0 {
+		algorithm := bucketCompressionAlgorithm(bucket)
 		// Storing the compression metadata.
-		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		metadata[ReservedMetadataPrefix+"compression"] = compressionScheme(algorithm)
 		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(actualSize, 10)
 
 		actualReader, err := hash.NewReader(reader, actualSize, "", "", actualSize, globalCLIContext.StrictS3Compat)
@@ -1220,7 +1216,8 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 
 		// Set compression metrics.
 		size = -1 // Since compressed size is un-predictable.
-		s2c := newS2CompressReader(actualReader, actualSize)
+		var s2c io.ReadCloser
+		s2c, idxCb = newCompressReader(actualReader, actualSize, algorithm)
 		defer s2c.Close()
 		reader = s2c
 		hashReader, err = hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
@@ -1241,6 +1238,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	opts.IndexCB = idxCb
 
 	if objectAPI.IsEncryptionSupported() {
 		if _, ok := crypto.IsRequested(r.Header); ok && !HasSuffix(object, SlashSeparator) { // handle SSE requests
@@ -2205,7 +2203,7 @@ func (web *webAPIHandlers) LoginSTS(r *http.Request, args *LoginSTSArgs, reply *
 		return toJSONError(ctx, errSTSNotInitialized)
 	}
 
-	v, err := globalOpenIDValidators.Get("jwt")
+	v, err := globalOpenIDValidators.GetByToken(args.Token)
 	if err != nil {
 		logger.LogIf(ctx, err)
 		return toJSONError(ctx, errSTSNotInitialized)
@@ -2216,17 +2214,9 @@ func (web *webAPIHandlers) LoginSTS(r *http.Request, args *LoginSTSArgs, reply *
 		return toJSONError(ctx, err)
 	}
 
-	// JWT has requested a custom claim with policy value set.
-	// This is a MinIO STS API specific value, this value should
-	// be set and configured on your identity provider as part of
-	// JWT custom claims.
-	var policyName string
-	policySet, ok := iampolicy.GetPoliciesFromClaims(m, iamPolicyClaimNameOpenID())
-	if ok {
-		policyName = globalIAMSys.CurrentPolicies(strings.Join(policySet.ToSlice(), ","))
-	}
+	policyName := getOpenIDPolicyName(v, m)
 	if policyName == "" && globalPolicyOPA == nil {
-		return toJSONError(ctx, fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", iamPolicyClaimNameOpenID()))
+		return toJSONError(ctx, fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", v.PolicyClaimName()))
 	}
 	m[iamPolicyClaimNameOpenID()] = policyName
 
@@ -126,6 +126,8 @@ type ObjectPartInfo struct {
 	Number     int    `json:"number"`
 	Size       int64  `json:"size"`
 	ActualSize int64  `json:"actualSize"`
+	Index      []byte `json:"index,omitempty"`
+	Checksum   string `json:"checksum,omitempty"`
 }
 
 // ChecksumInfo - carries checksums of individual scattered parts per disk.
@@ -193,9 +195,13 @@ func (m *xlMetaV1Object) ToFileInfo(volume, path string) (FileInfo, error) {
 		VersionID: m.VersionID,
 		DataDir:   m.DataDir,
 	}
-	if st, ok := m.Meta[ReservedMetadataPrefixLower+"transition-status"]; ok {
+	if st, ok := m.Meta[ReservedMetadataPrefixLower+transitionStatus]; ok {
 		fi.TransitionStatus = st
 	}
+	if tier, ok := m.Meta[ReservedMetadataPrefixLower+transitionTier]; ok {
+		fi.TransitionTier = tier
+		fi.TransitionedObjName = m.Meta[ReservedMetadataPrefixLower+transitionedObjectName]
+	}
 	return fi, nil
 }
 
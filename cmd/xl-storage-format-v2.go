@@ -35,6 +35,10 @@ var (
 
 	// XLv2 version 1
 	xlVersionV1 = [4]byte{'1', ' ', ' ', ' '}
+
+	// XLv2 version 1.1, versions carry their erasure shard inline,
+	// older servers refuse it instead of missing the inlined data.
+	xlVersionV11 = [4]byte{'1', '.', '1', ' '}
 )
 
 func checkXL2V1(buf []byte) error {
@@ -46,8 +50,8 @@ func checkXL2V1(buf []byte) error {
 		return fmt.Errorf("xlMeta: unknown XLv2 header, expected %v, got %v", xlHeader[:4], buf[:4])
 	}
 
-	if !bytes.Equal(buf[4:8], xlVersionV1[:]) {
-		return fmt.Errorf("xlMeta: unknown XLv2 version, expected %v, got %v", xlVersionV1[:4], buf[4:8])
+	if !bytes.Equal(buf[4:8], xlVersionV1[:]) && !bytes.Equal(buf[4:8], xlVersionV11[:]) {
+		return fmt.Errorf("xlMeta: unknown XLv2 version, expected %v or %v, got %v", xlVersionV1[:4], xlVersionV11[:4], buf[4:8])
 	}
 
 	return nil
@@ -82,6 +86,9 @@ func isXL2V1Format(buf []byte) bool {
 //         ├── legacy
 //         │   └── part.1
 //         └── xl.meta
+//
+// Versions of small objects carry their erasure shard inline in `xl.meta`,
+// such versions have no data directory on disk.
 
 //go:generate msgp -file=$GOFILE -unexported
 
@@ -159,10 +166,13 @@ type xlMetaV2Object struct {
 	PartETags          []string          `json:"PartETags" msg:"PartETags"`                       // Part ETags
 	PartSizes          []int64           `json:"PartSizes" msg:"PartSizes"`                       // Part Sizes
 	PartActualSizes    []int64           `json:"PartASizes,omitempty" msg:"PartASizes,omitempty"` // Part ActualSizes (compression)
+	PartIndices        [][]byte          `json:"PartIdx,omitempty" msg:"PartIdx,omitempty"`       // Part indexes of compressed blocks (compression)
+	PartChecksums      []string          `json:"PartCksums,omitempty" msg:"PartCksums,omitempty"` // Part additional checksums
 	Size               int64             `json:"Size" msg:"Size"`                                 // Object version size
 	ModTime            int64             `json:"MTime" msg:"MTime"`                               // Object version modified time
 	MetaSys            map[string][]byte `json:"MetaSys,omitempty" msg:"MetaSys,omitempty"`       // Object version internal metadata
 	MetaUser           map[string]string `json:"MetaUsr,omitempty" msg:"MetaUsr,omitempty"`       // Object version metadata set by user
+	Data               []byte            `json:"-" msg:"Data,omitempty"`                          // Erasure shard of small objects inlined in xl.meta
 }
 
 // xlMetaV2Version describes the jouranal entry, Type defines
@@ -227,6 +237,20 @@ func (z *xlMetaV2) Load(buf []byte) error {
 	return err
 }
 
+// AppendTo appends the header and the serialized xl.meta to buf, the minor
+// version is only bumped when a version carries inlined data.
+func (z *xlMetaV2) AppendTo(buf []byte) ([]byte, error) {
+	version := xlVersionV1
+	for _, v := range z.Versions {
+		if v.Type == ObjectType && v.ObjectV2 != nil && len(v.ObjectV2.Data) > 0 {
+			version = xlVersionV11
+			break
+		}
+	}
+	buf = append(buf, xlHeader[:]...)
+	return z.MarshalMsg(append(buf, version[:]...))
+}
+
 // AddVersion adds a new version
 func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 	if fi.VersionID == "" {
@@ -295,6 +319,18 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 			}
 			ventry.ObjectV2.PartNumbers[i] = fi.Parts[i].Number
 			ventry.ObjectV2.PartActualSizes[i] = fi.Parts[i].ActualSize
+			if len(fi.Parts[i].Index) > 0 {
+				if ventry.ObjectV2.PartIndices == nil {
+					ventry.ObjectV2.PartIndices = make([][]byte, len(fi.Parts))
+				}
+				ventry.ObjectV2.PartIndices[i] = fi.Parts[i].Index
+			}
+			if fi.Parts[i].Checksum != "" {
+				if ventry.ObjectV2.PartChecksums == nil {
+					ventry.ObjectV2.PartChecksums = make([]string, len(fi.Parts))
+				}
+				ventry.ObjectV2.PartChecksums[i] = fi.Parts[i].Checksum
+			}
 		}
 
 		for k, v := range fi.Metadata {
@@ -304,12 +340,37 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 				ventry.ObjectV2.MetaUser[k] = v
 			}
 		}
+
+		// A transitioned version is added when it is moved to another pool.
+		if fi.TransitionStatus != "" {
+			ventry.ObjectV2.MetaSys[ReservedMetadataPrefixLower+transitionStatus] = []byte(fi.TransitionStatus)
+			if fi.TransitionTier != "" {
+				ventry.ObjectV2.MetaSys[ReservedMetadataPrefixLower+transitionedObjectName] = []byte(fi.TransitionedObjName)
+				ventry.ObjectV2.MetaSys[ReservedMetadataPrefixLower+transitionTier] = []byte(fi.TransitionTier)
+			}
+		}
+
+		if fi.InlineData() {
+			ventry.ObjectV2.Data = fi.Data
+		}
 	}
 
 	if !ventry.Valid() {
 		return errors.New("internal error: invalid version entry generated")
 	}
 
+	if ventry.Type == ObjectType && fi.InlineData() && len(fi.Data) == 0 {
+		// Metadata updates do not carry the inlined data, preserve
+		// it from the version sharing the same data directory.
+		for _, version := range z.Versions {
+			if version.Type == ObjectType && version.ObjectV2 != nil &&
+				bytes.Equal(version.ObjectV2.DataDir[:], dd[:]) && len(version.ObjectV2.Data) > 0 {
+				ventry.ObjectV2.Data = version.ObjectV2.Data
+				break
+			}
+		}
+	}
+
 	for i, version := range z.Versions {
 		if !version.Valid() {
 			return errFileCorrupt
@@ -393,6 +454,12 @@ func (j xlMetaV2Object) ToFileInfo(volume, path string) (FileInfo, error) {
 		fi.Parts[i].Size = j.PartSizes[i]
 		fi.Parts[i].ETag = j.PartETags[i]
 		fi.Parts[i].ActualSize = j.PartActualSizes[i]
+		if len(j.PartIndices) == len(fi.Parts) {
+			fi.Parts[i].Index = j.PartIndices[i]
+		}
+		if len(j.PartChecksums) == len(fi.Parts) {
+			fi.Parts[i].Checksum = j.PartChecksums[i]
+		}
 	}
 	fi.Erasure.Checksums = make([]ChecksumInfo, len(j.PartSizes))
 	for i := range fi.Parts {
@@ -416,8 +483,12 @@ func (j xlMetaV2Object) ToFileInfo(volume, path string) (FileInfo, error) {
 	}
 	for k, v := range j.MetaSys {
 		switch {
-		case equals(k, ReservedMetadataPrefixLower+"transition-status"):
+		case equals(k, ReservedMetadataPrefixLower+transitionStatus):
 			fi.TransitionStatus = string(v)
+		case equals(k, ReservedMetadataPrefixLower+transitionedObjectName):
+			fi.TransitionedObjName = string(v)
+		case equals(k, ReservedMetadataPrefixLower+transitionTier):
+			fi.TransitionTier = string(v)
 		case equals(k, VersionPurgeStatusKey):
 			fi.VersionPurgeStatus = VersionPurgeStatusType(string(v))
 		case strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower):
@@ -434,6 +505,7 @@ func (j xlMetaV2Object) ToFileInfo(volume, path string) (FileInfo, error) {
 		fi.Erasure.Distribution[i] = int(j.ErasureDist[i])
 	}
 	fi.DataDir = uuid.UUID(j.DataDir).String()
+	fi.Data = j.Data
 
 	return fi, nil
 }
@@ -505,7 +577,11 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 		case LegacyType:
 			if version.ObjectV1.VersionID == fi.VersionID {
 				if fi.TransitionStatus != "" {
-					z.Versions[i].ObjectV1.Meta[ReservedMetadataPrefixLower+"transition-status"] = fi.TransitionStatus
+					z.Versions[i].ObjectV1.Meta[ReservedMetadataPrefixLower+transitionStatus] = fi.TransitionStatus
+					if fi.TransitionTier != "" {
+						z.Versions[i].ObjectV1.Meta[ReservedMetadataPrefixLower+transitionedObjectName] = fi.TransitionedObjName
+						z.Versions[i].ObjectV1.Meta[ReservedMetadataPrefixLower+transitionTier] = fi.TransitionTier
+					}
 					return uuid.UUID(version.ObjectV2.DataDir).String(), len(z.Versions) == 0, nil
 				}
 
@@ -566,7 +642,13 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 		case ObjectType:
 			if bytes.Equal(version.ObjectV2.VersionID[:], uv[:]) {
 				if fi.TransitionStatus != "" {
-					z.Versions[i].ObjectV2.MetaSys[ReservedMetadataPrefixLower+"transition-status"] = []byte(fi.TransitionStatus)
+					z.Versions[i].ObjectV2.MetaSys[ReservedMetadataPrefixLower+transitionStatus] = []byte(fi.TransitionStatus)
+					if fi.TransitionTier != "" {
+						z.Versions[i].ObjectV2.MetaSys[ReservedMetadataPrefixLower+transitionedObjectName] = []byte(fi.TransitionedObjName)
+						z.Versions[i].ObjectV2.MetaSys[ReservedMetadataPrefixLower+transitionTier] = []byte(fi.TransitionTier)
+					}
+					// Transitioned data is not kept inline either.
+					z.Versions[i].ObjectV2.Data = nil
 					return uuid.UUID(version.ObjectV2.DataDir).String(), len(z.Versions) == 0, nil
 				}
 				z.Versions = append(z.Versions[:i], z.Versions[i+1:]...)
@@ -622,6 +704,8 @@ func (z xlMetaV2) ListVersions(volume, path string) (versions []FileInfo, modTim
 		switch version.Type {
 		case ObjectType:
 			fi, err = version.ObjectV2.ToFileInfo(volume, path)
+			// Listed versions do not carry inlined data.
+			fi.Data = nil
 		case DeleteType:
 			fi, err = version.DeleteMarker.ToFileInfo(volume, path)
 		case LegacyType:
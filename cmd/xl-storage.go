@@ -71,6 +71,10 @@ const (
 
 	// XL metadata file carries per object metadata.
 	xlStorageFormatFile = "xl.meta"
+
+	// Erasure shards are not inlined once xl.meta exceeds this size, they
+	// are written to the data directory of their version instead.
+	xlMetaInlineLimit = 1 * humanize.MiByte
 )
 
 // isValidVolname verifies a volname name in accordance with object
@@ -941,7 +945,7 @@ func (s *xlStorage) DeleteVersion(ctx context.Context, volume, path string, fi F
 		return err
 	}
 
-	buf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+	buf, err = xlMeta.AppendTo(nil)
 	if err != nil {
 		return err
 	}
@@ -994,7 +998,7 @@ func (s *xlStorage) WriteMetadata(ctx context.Context, volume, path string, fi F
 		if err != nil {
 			return err
 		}
-		buf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+		buf, err = xlMeta.AppendTo(nil)
 		if err != nil {
 			return err
 		}
@@ -1005,7 +1009,7 @@ func (s *xlStorage) WriteMetadata(ctx context.Context, volume, path string, fi F
 		if err = xlMeta.AddVersion(fi); err != nil {
 			return err
 		}
-		buf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+		buf, err = xlMeta.AppendTo(nil)
 		if err != nil {
 			return err
 		}
@@ -1071,7 +1075,7 @@ func (s *xlStorage) renameLegacyMetadata(volumeDir, path string) (err error) {
 
 // ReadVersion - reads metadata and returns FileInfo at path `xl.meta`
 // for all objects less than `32KiB` this call returns data as well
-// along with metadata.
+// along with metadata, including data inlined in `xl.meta`.
 func (s *xlStorage) ReadVersion(ctx context.Context, volume, path, versionID string, readData bool) (fi FileInfo, err error) {
 	volumeDir, err := s.getVolDir(volume)
 	if err != nil {
@@ -1117,7 +1121,9 @@ func (s *xlStorage) ReadVersion(ctx context.Context, volume, path, versionID str
 		return fi, err
 	}
 
-	if readData {
+	if !readData {
+		fi.Data = nil
+	} else if !fi.InlineData() {
 		// Reading data for small objects when
 		// - object has not yet transitioned
 		// - object size lesser than 32KiB
@@ -1747,6 +1753,20 @@ func (s *xlStorage) CheckParts(ctx context.Context, volume string, path string,
 		return err
 	}
 
+	if fi.InlineData() {
+		data, inlined, err := s.readInlineData(ctx, volume, path, fi)
+		if err != nil {
+			return err
+		}
+		if inlined {
+			// Check if shard is truncated.
+			if int64(len(data)) < fi.Erasure.ShardFileSize(fi.Size) {
+				return errFileCorrupt
+			}
+			return nil
+		}
+	}
+
 	for _, part := range fi.Parts {
 		partPath := pathJoin(path, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
 		if fi.XLV1 {
@@ -1772,6 +1792,25 @@ func (s *xlStorage) CheckParts(ctx context.Context, volume string, path string,
 	return nil
 }
 
+// readInlineData - returns the erasure shard inlined in xl.meta of the version,
+// or false if the drive wrote the shard to the data directory of the version
+// as xl.meta exceeded xlMetaInlineLimit.
+func (s *xlStorage) readInlineData(ctx context.Context, volume, path string, fi FileInfo) ([]byte, bool, error) {
+	versionID := fi.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	ifi, err := s.ReadVersion(ctx, volume, path, versionID, true)
+	if err != nil {
+		return nil, false, err
+	}
+	if ifi.DataDir != fi.DataDir {
+		// The version was overwritten in the meantime.
+		return nil, false, errFileNotFound
+	}
+	return ifi.Data, ifi.InlineData(), nil
+}
+
 // CheckFile check if path has necessary metadata.
 // This function does the following check, suppose
 // you are creating a metadata file at "a/b/c/d/xl.meta",
@@ -2169,7 +2208,7 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath, dataDir,
 	if fi.VersionID == "" {
 		// return the latest "null" versionId info
 		ofi, err := xlMeta.ToFileInfo(dstVolume, dstPath, nullVersionID)
-		if err == nil && !ofi.Deleted {
+		if err == nil && !ofi.Deleted && ofi.DataDir != fi.DataDir {
 			// Purge the destination path as we are not preserving anything
 			// versioned object was not requested.
 			oldDstDataPath = pathJoin(dstVolumeDir, dstPath, ofi.DataDir)
@@ -2180,18 +2219,38 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath, dataDir,
 		return err
 	}
 
-	dstBuf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+	dstBuf, err = xlMeta.AppendTo(nil)
 	if err != nil {
 		return errFileCorrupt
 	}
 
+	if fi.InlineData() && len(fi.Data) > 0 && len(dstBuf) > xlMetaInlineLimit {
+		// xl.meta grew too large, the inlined shard is written
+		// to the data directory of the version instead.
+		if err = s.WriteAll(ctx, srcVolume, pathJoin(srcPath, fi.DataDir, "part.1"), fi.Data); err != nil {
+			return err
+		}
+		delete(fi.Metadata, inlineDataKey)
+		fi.Data = nil
+		if err = xlMeta.AddVersion(fi); err != nil {
+			return err
+		}
+		if dstBuf, err = xlMeta.AppendTo(nil); err != nil {
+			return errFileCorrupt
+		}
+		srcDataPath = retainSlash(pathJoin(srcVolumeDir, srcPath, fi.DataDir))
+		dstDataPath = slashpath.Join(dstVolumeDir, dstPath, fi.DataDir)
+	}
+
 	if err = s.WriteAll(ctx, srcVolume, pathJoin(srcPath, xlStorageFormatFile), dstBuf); err != nil {
 		return err
 	}
 
 	// Commit data
-	if srcDataPath != "" {
+	if oldDstDataPath != "" {
 		removeAll(oldDstDataPath)
+	}
+	if srcDataPath != "" {
 		removeAll(dstDataPath)
 		if err = renameAll(srcDataPath, dstDataPath); err != nil {
 			return osErrToFileErr(err)
@@ -2313,21 +2372,6 @@ func (s *xlStorage) bitrotVerify(partPath string, partSize int64, algo BitrotAlg
 	// Close the file descriptor.
 	defer file.Close()
 
-	if algo != HighwayHash256S {
-		h := algo.New()
-		if _, err = io.Copy(h, file); err != nil {
-			// Premature failure in reading the object,file is corrupt.
-			return errFileCorrupt
-		}
-		if !bytes.Equal(h.Sum(nil), sum) {
-			return errFileCorrupt
-		}
-		return nil
-	}
-
-	buf := make([]byte, shardSize)
-	h := algo.New()
-	hashBuf := make([]byte, h.Size())
 	fi, err := file.Stat()
 	if err != nil {
 		// Unable to stat on the file, return an expected error
@@ -2335,40 +2379,7 @@ func (s *xlStorage) bitrotVerify(partPath string, partSize int64, algo BitrotAlg
 		return err
 	}
 
-	size := fi.Size()
-
-	// Calculate the size of the bitrot file and compare
-	// it with the actual file size.
-	if size != bitrotShardFileSize(partSize, shardSize, algo) {
-		return errFileCorrupt
-	}
-
-	var n int
-	for {
-		if size == 0 {
-			return nil
-		}
-		h.Reset()
-		n, err = file.Read(hashBuf)
-		if err != nil {
-			// Read's failed for object with right size, file is corrupt.
-			return err
-		}
-		size -= int64(n)
-		if size < int64(len(buf)) {
-			buf = buf[:size]
-		}
-		n, err = file.Read(buf)
-		if err != nil {
-			// Read's failed for object with right size, at different offsets.
-			return err
-		}
-		size -= int64(n)
-		h.Write(buf)
-		if !bytes.Equal(h.Sum(nil), hashBuf) {
-			return errFileCorrupt
-		}
-	}
+	return bitrotVerify(file, fi.Size(), partSize, algo, sum, shardSize)
 }
 
 func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (err error) {
@@ -2396,6 +2407,20 @@ func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi File
 	}
 
 	erasure := fi.Erasure
+	if fi.InlineData() {
+		data, inlined, err := s.readInlineData(ctx, volume, path, fi)
+		if err != nil {
+			return err
+		}
+		if inlined {
+			checksumInfo := erasure.GetChecksumInfo(1)
+			return bitrotVerify(bytes.NewReader(data), int64(len(data)),
+				erasure.ShardFileSize(fi.Size),
+				checksumInfo.Algorithm,
+				checksumInfo.Hash, erasure.ShardSize())
+		}
+	}
+
 	for _, part := range fi.Parts {
 		checksumInfo := erasure.GetChecksumInfo(part.Number)
 		partPath := pathJoin(volumeDir, path, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
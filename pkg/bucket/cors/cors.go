@@ -0,0 +1,108 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+)
+
+var (
+	errCORSTooManyRules = Errorf("CORS configuration allows a maximum of 100 rules")
+	errCORSNoRule       = Errorf("CORS configuration should have at least one rule")
+	errCORSDuplicateID  = Errorf("CORS configuration has rule with the same ID. Rule ID must be unique.")
+)
+
+// Maximum 64KiB size per CORS config, same as AWS S3.
+const maxCORSConfigSize = 64 << 10
+
+const xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+// Config - bucket CORS configuration as specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"CORSConfiguration"`
+	Rules   []Rule   `xml:"CORSRule"`
+}
+
+// ParseConfig parses CORSConfiguration from xml
+func ParseConfig(reader io.Reader) (*Config, error) {
+	config := Config{}
+	if err := xml.NewDecoder(io.LimitReader(reader, maxCORSConfigSize)).Decode(&config); err != nil {
+		return nil, err
+	}
+	if config.XMLNS == "" {
+		config.XMLNS = xmlNS
+	}
+	return &config, nil
+}
+
+// Validate - validates the CORS configuration
+func (c Config) Validate() error {
+	// CORS config can't have more than 100 rules
+	if len(c.Rules) > 100 {
+		return errCORSTooManyRules
+	}
+	// CORS config should have at least one rule
+	if len(c.Rules) == 0 {
+		return errCORSNoRule
+	}
+	ids := make(map[string]struct{}, len(c.Rules))
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if r.ID == "" {
+			continue
+		}
+		if _, ok := ids[r.ID]; ok {
+			return errCORSDuplicateID
+		}
+		ids[r.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Match returns the first rule which allows a request from origin
+// using method and sending the given headers. Rules are evaluated
+// in the order they are configured, the first matching rule wins.
+func (c Config) Match(origin, method string, headers []string) (Rule, bool) {
+	for _, r := range c.Rules {
+		if !r.MatchOrigin(origin) {
+			continue
+		}
+		if !r.MatchMethod(method) {
+			continue
+		}
+		if !r.MatchHeaders(headers) {
+			continue
+		}
+		return r, true
+	}
+	return Rule{}, false
+}
+
+// supportedMethods - list of HTTP methods allowed in a CORS rule.
+var supportedMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodPut:    {},
+	http.MethodHead:   {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+}
@@ -0,0 +1,122 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseAndValidateCORSConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		expectedErr error
+	}{
+		// 1. Valid config with single rule
+		{
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>http://www.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+			expectedErr: nil,
+		},
+		// 2. No rules
+		{
+			inputConfig: `<CORSConfiguration></CORSConfiguration>`,
+			expectedErr: errCORSNoRule,
+		},
+		// 3. Missing AllowedMethod
+		{
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			expectedErr: errMissingAllowedMethod,
+		},
+		// 4. Missing AllowedOrigin
+		{
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: errMissingAllowedOrigin,
+		},
+		// 5. Too many wildcards in origin
+		{
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>http://*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: errTooManyOriginWildcard,
+		},
+		// 6. Duplicate rule IDs
+		{
+			inputConfig: `<CORSConfiguration><CORSRule><ID>1</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule><CORSRule><ID>1</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PUT</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: errCORSDuplicateID,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected parse error %v", i+1, err)
+		}
+		if err = config.Validate(); err != tc.expectedErr {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.expectedErr, err)
+		}
+	}
+
+	// Unsupported methods are rejected.
+	config, err := ParseConfig(bytes.NewReader([]byte(`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = config.Validate(); err == nil {
+		t.Fatal("expected unsupported method to fail validation")
+	}
+}
+
+func TestCORSConfigMatch(t *testing.T) {
+	config := Config{
+		Rules: []Rule{
+			{
+				ID:             "upload",
+				AllowedOrigins: []string{"https://*.example.com"},
+				AllowedMethods: []string{"PUT", "POST", "DELETE"},
+				AllowedHeaders: []string{"Content-*", "x-amz-*"},
+			},
+			{
+				ID:             "read",
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET", "HEAD"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+		match      bool
+	}{
+		{"https://app.example.com", "PUT", []string{"Content-Type", "X-Amz-Date"}, "upload", true},
+		{"https://app.example.com", "PUT", []string{"Authorization"}, "", false},
+		{"https://app.example.org", "PUT", nil, "", false},
+		{"https://app.example.org", "GET", nil, "read", true},
+		{"https://app.example.org", "GET", []string{"Range"}, "", false},
+		{"https://app.example.com", "PATCH", nil, "", false},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.Match(tc.origin, tc.method, tc.headers)
+		if ok != tc.match {
+			t.Fatalf("Test %d: expected match %v, got %v", i+1, tc.match, ok)
+		}
+		if rule.ID != tc.expectedID {
+			t.Fatalf("Test %d: expected rule %q, got %q", i+1, tc.expectedID, rule.ID)
+		}
+	}
+}
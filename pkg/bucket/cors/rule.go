@@ -0,0 +1,130 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+var (
+	errInvalidRuleID         = Errorf("ID length is limited to 255 characters")
+	errMissingAllowedMethod  = Errorf("CORS rule should have at least one AllowedMethod")
+	errMissingAllowedOrigin  = Errorf("CORS rule should have at least one AllowedOrigin")
+	errInvalidMaxAgeSeconds  = Errorf("MaxAgeSeconds must not be negative")
+	errTooManyOriginWildcard = Errorf("AllowedOrigin can have at most one wildcard")
+	errTooManyHeaderWildcard = Errorf("AllowedHeader can have at most one wildcard")
+)
+
+// Rule - a CORS rule, describes the origins allowed to access
+// the bucket and the methods and headers they may use.
+type Rule struct {
+	XMLName        xml.Name `xml:"CORSRule"`
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the rule element
+func (r Rule) Validate() error {
+	if len(r.ID) > 255 {
+		return errInvalidRuleID
+	}
+	if len(r.AllowedMethods) == 0 {
+		return errMissingAllowedMethod
+	}
+	for _, method := range r.AllowedMethods {
+		if _, ok := supportedMethods[method]; !ok {
+			return Errorf("Found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return errMissingAllowedOrigin
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return errTooManyOriginWildcard
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return errTooManyHeaderWildcard
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return errInvalidMaxAgeSeconds
+	}
+	return nil
+}
+
+// MatchOrigin returns true if origin is allowed by this rule.
+func (r Rule) MatchOrigin(origin string) bool {
+	for _, allowed := range r.AllowedOrigins {
+		if wildcard.MatchSimple(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchMethod returns true if method is allowed by this rule.
+func (r Rule) MatchMethod(method string) bool {
+	for _, allowed := range r.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchHeaders returns true if all the headers are allowed by
+// this rule, header names are compared case-insensitively.
+func (r Rule) MatchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		var found bool
+		for _, allowed := range r.AllowedHeaders {
+			if wildcard.MatchSimple(strings.ToLower(allowed), header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsAnyOrigin returns true if the rule allows requests from
+// any origin, such rules are answered with a `*` origin.
+func (r Rule) AllowsAnyOrigin() bool {
+	for _, allowed := range r.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -58,6 +58,8 @@ const (
 	DeleteRestoredAction
 	// DeleteRestoredVersionAction deletes a particular version that was temporarily restored
 	DeleteRestoredVersionAction
+	// AbortMultipartUploadAction aborts an incomplete multipart upload after evaluating lifecycle rules
+	AbortMultipartUploadAction
 )
 
 // Lifecycle - Configuration for bucket lifecycle.
@@ -294,6 +296,41 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 	return action
 }
 
+// HasAbortIncompleteMultipartUpload returns true if an active rule
+// aborts incomplete multipart uploads.
+func (lc Lifecycle) HasAbortIncompleteMultipartUpload() bool {
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if !rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeMultipartAction returns the action to perform on an incomplete multipart
+// upload by evaluating all lifecycle rules against the object name and the time
+// the upload was initiated.
+func (lc Lifecycle) ComputeMultipartAction(object string, initiated time.Time) Action {
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+			continue
+		}
+		if !strings.HasPrefix(object, rule.GetPrefix()) {
+			continue
+		}
+		if time.Now().After(ExpectedExpiryTime(initiated, int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation))) {
+			return AbortMultipartUploadAction
+		}
+	}
+	return NoneAction
+}
+
 // ExpectedExpiryTime calculates the expiry, transition or restore date/time based on a object modtime.
 // The expected transition or restore time is always a midnight time following the the object
 // modification time plus the number of transition/restore days.
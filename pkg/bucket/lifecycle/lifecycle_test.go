@@ -358,6 +358,64 @@ func TestComputeActions(t *testing.T) {
 	}
 }
 
+func TestComputeMultipartAction(t *testing.T) {
+	testCases := []struct {
+		inputConfig    string
+		objectName     string
+		initiated      time.Time
+		expectedAction Action
+	}{
+		// Upload initiated within the configured days
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			initiated:      time.Now().UTC().Add(-2 * 24 * time.Hour), // Initiated 2 days ago
+			expectedAction: NoneAction,
+		},
+		// Upload initiated before the configured days
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			initiated:      time.Now().UTC().Add(-10 * 24 * time.Hour), // Initiated 10 days ago
+			expectedAction: AbortMultipartUploadAction,
+		},
+		// Prefix not matched
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:     "foxdir/fooobject",
+			initiated:      time.Now().UTC().Add(-10 * 24 * time.Hour), // Initiated 10 days ago
+			expectedAction: NoneAction,
+		},
+		// Disabled should always return NoneAction
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Disabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			initiated:      time.Now().UTC().Add(-10 * 24 * time.Hour), // Initiated 10 days ago
+			expectedAction: NoneAction,
+		},
+		// Object expiration does not abort uploads
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			initiated:      time.Now().UTC().Add(-10 * 24 * time.Hour), // Initiated 10 days ago
+			expectedAction: NoneAction,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run("", func(t *testing.T) {
+			lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if resultAction := lc.ComputeMultipartAction(tc.objectName, tc.initiated); resultAction != tc.expectedAction {
+				t.Fatalf("Expected action: `%v`, got: `%v`", tc.expectedAction, resultAction)
+			}
+		})
+	}
+}
+
 func TestHasActiveRules(t *testing.T) {
 	testCases := []struct {
 		inputConfig    string
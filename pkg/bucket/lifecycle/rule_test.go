@@ -60,6 +60,33 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule with AbortIncompleteMultipartUpload missing days
+			inputXML: ` <Rule>
+			                  <ID>rule with abort and no days</ID>
+			                  <Filter><Prefix>uploads/</Prefix></Filter>
+			                  <AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortMultipartUploadDays,
+		},
+		{ // Rule with AbortIncompleteMultipartUpload and a tag filter
+			inputXML: ` <Rule>
+			                  <ID>rule with abort and tags</ID>
+			                  <Filter><Tag><Key>key1</Key><Value>val1</Value></Tag></Filter>
+			                  <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortMultipartUploadTagFilter,
+		},
+		{ // Rule with only AbortIncompleteMultipartUpload
+			inputXML: ` <Rule>
+			                  <ID>rule with abort</ID>
+			                  <Filter><Prefix>uploads/</Prefix></Filter>
+			                  <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
@@ -0,0 +1,82 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+var (
+	errMissingTargetBucket = Errorf("TargetBucket must be specified when logging is enabled")
+	errInvalidTargetPrefix = Errorf("TargetPrefix cannot exceed 512 characters")
+)
+
+// Maximum length of the target prefix.
+const maxTargetPrefixLength = 512
+
+const xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+// LoggingEnabled - describes where access logs are delivered.
+type LoggingEnabled struct {
+	TargetBucket string `xml:"TargetBucket"`
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+// Config - bucket logging configuration as specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLogging.html
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+
+	// AccessKey of the account which enabled access logging, log objects
+	// are delivered with its permissions. Not part of the S3 API, only set
+	// in the stored configuration.
+	AccessKey string `xml:"AccessKey,omitempty"`
+}
+
+// ParseConfig - parses data in given reader to BucketLoggingStatus.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if c.XMLNS == "" {
+		c.XMLNS = xmlNS
+	}
+	return &c, nil
+}
+
+// Validate - validates the logging configuration
+func (c Config) Validate() error {
+	if c.LoggingEnabled == nil {
+		return nil
+	}
+	if c.LoggingEnabled.TargetBucket == "" {
+		return errMissingTargetBucket
+	}
+	if len(c.LoggingEnabled.TargetPrefix) > maxTargetPrefixLength {
+		return errInvalidTargetPrefix
+	}
+	return nil
+}
+
+// Enabled - returns true if access logging is enabled
+func (c Config) Enabled() bool {
+	return c.LoggingEnabled != nil
+}
@@ -0,0 +1,68 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAndValidateLoggingConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		enabled     bool
+		expectedErr error
+	}{
+		// 1. Logging disabled
+		{
+			inputConfig: `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`,
+			enabled:     false,
+			expectedErr: nil,
+		},
+		// 2. Logging enabled
+		{
+			inputConfig: `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			enabled:     true,
+			expectedErr: nil,
+		},
+		// 3. Missing target bucket
+		{
+			inputConfig: `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			enabled:     true,
+			expectedErr: errMissingTargetBucket,
+		},
+		// 4. Target prefix too long
+		{
+			inputConfig: `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>` + strings.Repeat("a", 513) + `</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			enabled:     true,
+			expectedErr: errInvalidTargetPrefix,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.inputConfig))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected parse error %v", i+1, err)
+		}
+		if config.Enabled() != tc.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, tc.enabled, config.Enabled())
+		}
+		if err = config.Validate(); err != tc.expectedErr {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.expectedErr, err)
+		}
+	}
+}
@@ -133,6 +133,23 @@ const (
 	// GetBucketEncryptionAction - GetBucketEncryption REST API action
 	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
 
+	// PutBucketCORSAction - PutBucketCors REST API action
+	PutBucketCORSAction = "s3:PutBucketCORS"
+	// GetBucketCORSAction - GetBucketCors REST API action
+	GetBucketCORSAction = "s3:GetBucketCORS"
+
+	// PutBucketWebsiteAction - PutBucketWebsite REST API action
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+	// GetBucketWebsiteAction - GetBucketWebsite REST API action
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite REST API action
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketLoggingAction - PutBucketLogging REST API action
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+	// GetBucketLoggingAction - GetBucketLogging REST API action
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
 	// PutBucketVersioningAction - PutBucketVersioning REST API action
 	PutBucketVersioningAction = "s3:PutBucketVersioning"
 	// GetBucketVersioningAction - GetBucketVersioning REST API action
@@ -251,6 +268,13 @@ var supportedActions = map[Action]struct{}{
 	DeleteObjectTaggingAction:              {},
 	PutBucketEncryptionAction:              {},
 	GetBucketEncryptionAction:              {},
+	PutBucketCORSAction:                    {},
+	GetBucketCORSAction:                    {},
+	PutBucketWebsiteAction:                 {},
+	GetBucketWebsiteAction:                 {},
+	DeleteBucketWebsiteAction:              {},
+	PutBucketLoggingAction:                 {},
+	GetBucketLoggingAction:                 {},
 	PutBucketVersioningAction:              {},
 	GetBucketVersioningAction:              {},
 	GetReplicationConfigurationAction:      {},
@@ -268,6 +292,12 @@ func (action Action) IsValid() bool {
 	return ok
 }
 
+// SupportsConditionKey - checks if the condition key is supported by the action.
+func (action Action) SupportsConditionKey(key condition.Key) bool {
+	_, ok := actionConditionKeyMap[action][key]
+	return ok
+}
+
 // MarshalJSON - encodes Action to JSON data.
 func (action Action) MarshalJSON() ([]byte, error) {
 	if action.IsValid() {
@@ -317,6 +347,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 
 	GetObjectAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 		}, condition.CommonKeys...)...),
@@ -357,23 +388,33 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3ObjectLockRetainUntilDate,
 			condition.S3ObjectLockMode,
 			condition.S3ObjectLockLegalHold,
+			condition.S3RequestObjectTag,
+			condition.S3RequestObjectTagKeys,
 		}, condition.CommonKeys...)...),
 
 	// https://docs.aws.amazon.com/AmazonS3/latest/dev/list_amazons3.html
 	// LockLegalHold is not supported with PutObjectRetentionAction
 	PutObjectRetentionAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3ObjectLockRemainingRetentionDays,
 			condition.S3ObjectLockRetainUntilDate,
 			condition.S3ObjectLockMode,
 		}, condition.CommonKeys...)...),
 
-	GetObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+	GetObjectRetentionAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 	PutObjectLegalHoldAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3ObjectLockLegalHold,
 		}, condition.CommonKeys...)...),
-	GetObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+	GetObjectLegalHoldAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 
 	// https://docs.aws.amazon.com/AmazonS3/latest/dev/list_amazons3.html
 	BypassGovernanceRetentionAction: condition.NewKeySet(
@@ -388,17 +429,31 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	PutBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
 	GetBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	PutBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	PutObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	GetObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
+	PutObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+	GetObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+	DeleteObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 
-	PutObjectVersionTaggingAction: condition.NewKeySet(condition.CommonKeys...),
+	PutObjectVersionTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 	GetObjectVersionAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
 	GetObjectVersionTaggingAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
 	DeleteObjectVersionAction: condition.NewKeySet(
@@ -407,8 +462,16 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 		}, condition.CommonKeys...)...),
 	DeleteObjectVersionTaggingAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
+	PutBucketCORSAction:                  condition.NewKeySet(condition.CommonKeys...),
+	GetBucketCORSAction:                  condition.NewKeySet(condition.CommonKeys...),
+	PutBucketWebsiteAction:               condition.NewKeySet(condition.CommonKeys...),
+	GetBucketWebsiteAction:               condition.NewKeySet(condition.CommonKeys...),
+	DeleteBucketWebsiteAction:            condition.NewKeySet(condition.CommonKeys...),
+	PutBucketLoggingAction:               condition.NewKeySet(condition.CommonKeys...),
+	GetBucketLoggingAction:               condition.NewKeySet(condition.CommonKeys...),
 	GetReplicationConfigurationAction:    condition.NewKeySet(condition.CommonKeys...),
 	PutReplicationConfigurationAction:    condition.NewKeySet(condition.CommonKeys...),
 	ReplicateObjectAction:                condition.NewKeySet(condition.CommonKeys...),
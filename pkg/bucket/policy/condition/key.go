@@ -84,6 +84,17 @@ const (
 	// Enables enforcement of the specified object legal hold status
 	S3ObjectLockLegalHold Key = "s3:object-lock-legal-hold"
 
+	// S3ExistingObjectTag - key representing the tags of the existing object, used with
+	// the tag key as suffix such as "s3:ExistingObjectTag/project".
+	S3ExistingObjectTag Key = "s3:ExistingObjectTag"
+
+	// S3RequestObjectTag - key representing the tags of x-amz-tagging HTTP header, used
+	// with the tag key as suffix such as "s3:RequestObjectTag/project".
+	S3RequestObjectTag Key = "s3:RequestObjectTag"
+
+	// S3RequestObjectTagKeys - key representing the tag keys of x-amz-tagging HTTP header.
+	S3RequestObjectTagKeys Key = "s3:RequestObjectTagKeys"
+
 	// AWSReferer - key representing Referer header of any API.
 	AWSReferer Key = "aws:Referer"
 
@@ -128,6 +139,9 @@ var AllSupportedKeys = append([]Key{
 	S3ObjectLockMode,
 	S3ObjectLockLegalHold,
 	S3ObjectLockRetainUntilDate,
+	S3ExistingObjectTag,
+	S3RequestObjectTag,
+	S3RequestObjectTagKeys,
 	AWSReferer,
 	AWSSourceIP,
 	AWSUserAgent,
@@ -168,8 +182,37 @@ func substFuncFromValues(values map[string][]string) func(string) string {
 	}
 }
 
+// objectTagKeys - keys of object tags, only valid with the tag key as suffix.
+var objectTagKeys = []Key{
+	S3ExistingObjectTag,
+	S3RequestObjectTag,
+}
+
+// ObjectTagKey - returns the key of object tags and true if the key names a
+// single tag, such as S3ExistingObjectTag for "s3:ExistingObjectTag/project".
+func (key Key) ObjectTagKey() (Key, bool) {
+	for _, tagKey := range objectTagKeys {
+		prefix := string(tagKey) + "/"
+		if strings.HasPrefix(string(key), prefix) && len(key) > len(prefix) {
+			return tagKey, true
+		}
+	}
+
+	return key, false
+}
+
 // IsValid - checks if key is valid or not.
 func (key Key) IsValid() bool {
+	if _, ok := key.ObjectTagKey(); ok {
+		return true
+	}
+
+	for _, tagKey := range objectTagKeys {
+		if tagKey == key {
+			return false
+		}
+	}
+
 	for _, supKey := range AllSupportedKeys {
 		if supKey == key {
 			return true
@@ -241,7 +284,8 @@ func (set KeySet) Add(key Key) {
 	set[key] = struct{}{}
 }
 
-// Difference - returns a key set contains difference of two keys.
+// Difference - returns a key set contains difference of two keys. Keys with
+// the tag key as suffix are contained by their key of object tags.
 // Example:
 //     keySet1 := ["one", "two", "three"]
 //     keySet2 := ["two", "four", "three"]
@@ -250,9 +294,15 @@ func (set KeySet) Difference(sset KeySet) KeySet {
 	nset := make(KeySet)
 
 	for k := range set {
-		if _, ok := sset[k]; !ok {
-			nset.Add(k)
+		if _, ok := sset[k]; ok {
+			continue
+		}
+		if tagKey, ok := k.ObjectTagKey(); ok {
+			if _, ok = sset[tagKey]; ok {
+				continue
+			}
 		}
+		nset.Add(k)
 	}
 
 	return nset
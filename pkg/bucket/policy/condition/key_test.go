@@ -38,6 +38,11 @@ func TestKeyIsValid(t *testing.T) {
 		{S3MaxKeys, true},
 		{AWSReferer, true},
 		{AWSSourceIP, true},
+		{S3RequestObjectTagKeys, true},
+		{Key("s3:ExistingObjectTag/project"), true},
+		{Key("s3:RequestObjectTag/project"), true},
+		{S3ExistingObjectTag, false},
+		{Key("s3:RequestObjectTag/"), false},
 		{Key("foo"), false},
 	}
 
@@ -83,6 +88,7 @@ func TestKeyName(t *testing.T) {
 	}{
 		{S3XAmzCopySource, "x-amz-copy-source"},
 		{AWSReferer, "Referer"},
+		{Key("s3:ExistingObjectTag/project"), "ExistingObjectTag/project"},
 	}
 
 	for i, testCase := range testCases {
@@ -148,6 +154,8 @@ func TestKeySetDifference(t *testing.T) {
 	}{
 		{NewKeySet(), NewKeySet(S3XAmzCopySource), NewKeySet()},
 		{NewKeySet(S3Prefix, S3Delimiter, S3MaxKeys), NewKeySet(S3Delimiter, S3MaxKeys), NewKeySet(S3Prefix)},
+		{NewKeySet(Key("s3:ExistingObjectTag/project"), Key("s3:RequestObjectTag/project")), NewKeySet(S3ExistingObjectTag),
+			NewKeySet(Key("s3:RequestObjectTag/project"))},
 	}
 
 	for i, testCase := range testCases {
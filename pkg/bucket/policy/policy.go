@@ -19,6 +19,8 @@ package policy
 import (
 	"encoding/json"
 	"io"
+
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 )
 
 // DefaultVersion - default policy version as per AWS S3 specification.
@@ -69,11 +71,43 @@ func (policy Policy) IsAllowed(args Args) bool {
 	return false
 }
 
+// MatchingStatements - returns the deny statements denying the given args
+// and the allow statements allowing them.
+func (policy Policy) MatchingStatements(args Args) []Statement {
+	var statements []Statement
+	for _, statement := range policy.Statements {
+		switch statement.Effect {
+		case Allow:
+			if statement.IsAllowed(args) {
+				statements = append(statements, statement)
+			}
+		case Deny:
+			if !statement.IsAllowed(args) {
+				statements = append(statements, statement)
+			}
+		}
+	}
+
+	return statements
+}
+
 // IsEmpty - returns whether policy is empty or not.
 func (policy Policy) IsEmpty() bool {
 	return len(policy.Statements) == 0
 }
 
+// ConditionKeys - returns the condition keys used in all statements.
+func (policy Policy) ConditionKeys() condition.KeySet {
+	keys := condition.NewKeySet()
+	for _, statement := range policy.Statements {
+		for key := range statement.Conditions.Keys() {
+			keys.Add(key)
+		}
+	}
+
+	return keys
+}
+
 // isValid - checks if Policy is valid or not.
 func (policy Policy) isValid() error {
 	if policy.Version != DefaultVersion && policy.Version != "" {
@@ -112,14 +146,26 @@ redo:
 				continue
 			}
 
+			if !policy.Statements[i].NotPrincipal.Equals(statement.NotPrincipal) {
+				continue
+			}
+
 			if !policy.Statements[i].Actions.Equals(statement.Actions) {
 				continue
 			}
 
+			if !policy.Statements[i].NotActions.Equals(statement.NotActions) {
+				continue
+			}
+
 			if !policy.Statements[i].Resources.Equals(statement.Resources) {
 				continue
 			}
 
+			if !policy.Statements[i].NotResources.Equals(statement.NotResources) {
+				continue
+			}
+
 			if policy.Statements[i].Conditions.String() != statement.Conditions.String() {
 				continue
 			}
@@ -25,22 +25,54 @@ import (
 
 // Statement - policy statement.
 type Statement struct {
-	SID        ID                  `json:"Sid,omitempty"`
-	Effect     Effect              `json:"Effect"`
-	Principal  Principal           `json:"Principal"`
-	Actions    ActionSet           `json:"Action"`
-	Resources  ResourceSet         `json:"Resource"`
-	Conditions condition.Functions `json:"Condition,omitempty"`
+	SID          ID                  `json:"Sid,omitempty"`
+	Effect       Effect              `json:"Effect"`
+	Principal    Principal           `json:"Principal"`
+	NotPrincipal Principal           `json:"NotPrincipal"`
+	Actions      ActionSet           `json:"Action,omitempty"`
+	NotActions   ActionSet           `json:"NotAction,omitempty"`
+	Resources    ResourceSet         `json:"Resource,omitempty"`
+	NotResources ResourceSet         `json:"NotResource,omitempty"`
+	Conditions   condition.Functions `json:"Condition,omitempty"`
+}
+
+// matchPrincipal - checks whether the principal is matched by Principal,
+// or not matched by NotPrincipal.
+func (statement Statement) matchPrincipal(principal string) bool {
+	if statement.NotPrincipal.IsValid() {
+		return !statement.NotPrincipal.Match(principal)
+	}
+
+	return statement.Principal.Match(principal)
+}
+
+// matchAction - checks whether the action is in Action, or not in NotAction.
+func (statement Statement) matchAction(action Action) bool {
+	if len(statement.NotActions) != 0 {
+		return !statement.NotActions.Contains(action)
+	}
+
+	return statement.Actions.Contains(action)
+}
+
+// matchResource - checks whether the resource is matched by Resource, or
+// not matched by NotResource.
+func (statement Statement) matchResource(resource string, conditionValues map[string][]string) bool {
+	if len(statement.NotResources) != 0 {
+		return !statement.NotResources.Match(resource, conditionValues)
+	}
+
+	return statement.Resources.Match(resource, conditionValues)
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
 	check := func() bool {
-		if !statement.Principal.Match(args.AccountName) {
+		if !statement.matchPrincipal(args.AccountName) {
 			return false
 		}
 
-		if !statement.Actions.Contains(args.Action) {
+		if !statement.matchAction(args.Action) {
 			return false
 		}
 
@@ -53,7 +85,7 @@ func (statement Statement) IsAllowed(args Args) bool {
 			resource += args.ObjectName
 		}
 
-		if !statement.Resources.Match(resource, args.ConditionValues) {
+		if !statement.matchResource(resource, args.ConditionValues) {
 			return false
 		}
 
@@ -69,26 +101,45 @@ func (statement Statement) isValid() error {
 		return Errorf("invalid Effect %v", statement.Effect)
 	}
 
-	if !statement.Principal.IsValid() {
+	if statement.Principal.IsValid() && statement.NotPrincipal.IsValid() {
+		return Errorf("Principal and NotPrincipal must not be used together")
+	}
+
+	if !statement.Principal.IsValid() && !statement.NotPrincipal.IsValid() {
 		return Errorf("invalid Principal %v", statement.Principal)
 	}
 
-	if len(statement.Actions) == 0 {
+	if len(statement.Actions) != 0 && len(statement.NotActions) != 0 {
+		return Errorf("Action and NotAction must not be used together")
+	}
+
+	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
 		return Errorf("Action must not be empty")
 	}
 
-	if len(statement.Resources) == 0 {
+	if len(statement.Resources) != 0 && len(statement.NotResources) != 0 {
+		return Errorf("Resource and NotResource must not be used together")
+	}
+
+	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
 		return Errorf("Resource must not be empty")
 	}
 
+	resources := statement.Resources
+	if len(statement.NotResources) != 0 {
+		resources = statement.NotResources
+	}
+
+	// Actions and condition keys of a statement with NotAction are
+	// validated when parsed, it applies to all actions but those.
 	for action := range statement.Actions {
 		if action.isObjectAction() {
-			if !statement.Resources.objectResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+			if !resources.objectResourceExists() {
+				return Errorf("unsupported Resource found %v for action %v", resources, action)
 			}
 		} else {
-			if !statement.Resources.bucketResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+			if !resources.bucketResourceExists() {
+				return Errorf("unsupported Resource found %v for action %v", resources, action)
 			}
 		}
 
@@ -108,9 +159,31 @@ func (statement Statement) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	// subtype to avoid recursive call to MarshalJSON()
-	type subStatement Statement
-	ss := subStatement(statement)
+	// Only one of Principal and NotPrincipal is encoded.
+	ss := struct {
+		SID          ID                  `json:"Sid,omitempty"`
+		Effect       Effect              `json:"Effect"`
+		Principal    *Principal          `json:"Principal,omitempty"`
+		NotPrincipal *Principal          `json:"NotPrincipal,omitempty"`
+		Actions      ActionSet           `json:"Action,omitempty"`
+		NotActions   ActionSet           `json:"NotAction,omitempty"`
+		Resources    ResourceSet         `json:"Resource,omitempty"`
+		NotResources ResourceSet         `json:"NotResource,omitempty"`
+		Conditions   condition.Functions `json:"Condition,omitempty"`
+	}{
+		SID:          statement.SID,
+		Effect:       statement.Effect,
+		Actions:      statement.Actions,
+		NotActions:   statement.NotActions,
+		Resources:    statement.Resources,
+		NotResources: statement.NotResources,
+		Conditions:   statement.Conditions,
+	}
+	if statement.NotPrincipal.IsValid() {
+		ss.NotPrincipal = &statement.NotPrincipal
+	} else {
+		ss.Principal = &statement.Principal
+	}
 	return json.Marshal(ss)
 }
 
@@ -140,7 +213,11 @@ func (statement Statement) Validate(bucketName string) error {
 		return err
 	}
 
-	return statement.Resources.Validate(bucketName)
+	if err := statement.Resources.Validate(bucketName); err != nil {
+		return err
+	}
+
+	return statement.NotResources.Validate(bucketName)
 }
 
 // NewStatement - creates new statement.
@@ -569,3 +569,63 @@ func TestStatementValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestStatementNotElements(t *testing.T) {
+	testCases := []struct {
+		data      string
+		args      Args
+		allowed   bool
+		expectErr bool
+	}{
+		// NotPrincipal matches all other principals.
+		{`{"Effect": "Allow", "NotPrincipal": {"AWS": ["Q3AM3UQ867SPQQA43P2F"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		{`{"Effect": "Allow", "NotPrincipal": {"AWS": ["Q3AM3UQ867SPQQA43P2F"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{AccountName: "", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true, false},
+		// NotAction matches all other actions.
+		{`{"Effect": "Allow", "Principal": "*", "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true, false},
+		{`{"Effect": "Allow", "Principal": "*", "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		// NotResource matches all other resources.
+		{`{"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/private/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "public/myobject"}, true, false},
+		{`{"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/private/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private/myobject"}, false, false},
+		// Positive and negative elements must not be used together.
+		{`{"Effect": "Allow", "Principal": "*", "NotPrincipal": "*", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`, Args{}, false, true},
+		{`{"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`, Args{}, false, true},
+		{`{"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"], "NotResource": ["arn:aws:s3:::mybucket/private/*"]}`, Args{}, false, true},
+		// Negative elements are validated like positive ones.
+		{`{"Effect": "Allow", "Principal": "*", "NotAction": ["s3:Foo"], "Resource": ["arn:aws:s3:::mybucket/*"]}`, Args{}, false, true},
+		{`{"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket"]}`, Args{}, false, true},
+	}
+
+	for i, testCase := range testCases {
+		var statement Statement
+		err := json.Unmarshal([]byte(testCase.data), &statement)
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if expectErr {
+			continue
+		}
+
+		if result := statement.IsAllowed(testCase.args); result != testCase.allowed {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.allowed, result)
+		}
+
+		data, err := json.Marshal(statement)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		var decoded Statement
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(statement, decoded) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, statement, decoded)
+		}
+	}
+}
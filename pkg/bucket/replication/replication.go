@@ -51,6 +51,57 @@ func (s StatusType) Empty() bool {
 	return string(s) == ""
 }
 
+// TargetStatuses - replication status of an object version on each
+// remote target, keyed by target ARN.
+type TargetStatuses map[string]StatusType
+
+// ParseTargetStatuses parses target statuses encoded as
+// "arn1=COMPLETED;arn2=FAILED;".
+func ParseTargetStatuses(s string) TargetStatuses {
+	statuses := make(TargetStatuses)
+	for _, kv := range strings.Split(s, ";") {
+		idx := strings.LastIndex(kv, "=")
+		if idx <= 0 {
+			continue
+		}
+		statuses[kv[:idx]] = StatusType(kv[idx+1:])
+	}
+	return statuses
+}
+
+// String returns target statuses encoded as "arn1=COMPLETED;arn2=FAILED;",
+// ordered by target ARN.
+func (t TargetStatuses) String() string {
+	arns := make([]string, 0, len(t))
+	for arn := range t {
+		arns = append(arns, arn)
+	}
+	sort.Strings(arns)
+	var sb strings.Builder
+	for _, arn := range arns {
+		sb.WriteString(arn + "=" + t[arn].String() + ";")
+	}
+	return sb.String()
+}
+
+// Status returns the overall replication status across all targets,
+// replication is complete only once it has completed on every target.
+func (t TargetStatuses) Status() StatusType {
+	if len(t) == 0 {
+		return ""
+	}
+	status := Completed
+	for _, s := range t {
+		switch s {
+		case Failed:
+			return Failed
+		case Pending:
+			status = Pending
+		}
+	}
+	return status
+}
+
 var (
 	errReplicationTooManyRules        = Errorf("Replication configuration allows a maximum of 1000 rules")
 	errReplicationNoRule              = Errorf("Replication configuration should have at least one rule")
@@ -90,18 +141,22 @@ func (c Config) Validate(bucket string, sameTarget bool) error {
 	if len(c.Rules) == 0 {
 		return errReplicationNoRule
 	}
-	if c.RoleArn == "" {
-		return errRoleArnMissing
-	}
 	// Validate all the rules in the replication config
 	targetMap := make(map[string]struct{})
 	priorityMap := make(map[string]struct{})
 	for _, r := range c.Rules {
-		if len(targetMap) == 0 {
-			targetMap[r.Destination.Bucket] = struct{}{}
-		}
-		if _, ok := targetMap[r.Destination.Bucket]; !ok {
-			return errReplicationDestinationMismatch
+		// Rules with a remote target ARN of their own may replicate
+		// anywhere, all others replicate to the target in Role.
+		if r.Destination.ARN == "" {
+			if c.RoleArn == "" {
+				return errRoleArnMissing
+			}
+			if len(targetMap) == 0 {
+				targetMap[r.Destination.Bucket] = struct{}{}
+			}
+			if _, ok := targetMap[r.Destination.Bucket]; !ok {
+				return errReplicationDestinationMismatch
+			}
 		}
 		if err := r.Validate(bucket, sameTarget); err != nil {
 			return err
@@ -123,6 +178,9 @@ type ObjectOpts struct {
 	IsLatest     bool
 	DeleteMarker bool
 	SSEC         bool
+	// TargetArn restricts rule evaluation to the rules
+	// replicating to this remote target when set.
+	TargetArn string
 }
 
 // FilterActionableRules returns the rules actions that need to be executed
@@ -136,6 +194,9 @@ func (c Config) FilterActionableRules(obj ObjectOpts) []Rule {
 		if rule.Status == Disabled {
 			continue
 		}
+		if obj.TargetArn != "" && c.ruleArn(rule) != obj.TargetArn {
+			continue
+		}
 		if !strings.HasPrefix(obj.Name, rule.Prefix()) {
 			continue
 		}
@@ -157,8 +218,61 @@ func (c Config) GetDestination() Destination {
 	return Destination{}
 }
 
-// Replicate returns true if the object should be replicated.
+// ruleArn returns the ARN of the remote target the rule replicates to.
+func (c Config) ruleArn(rule Rule) string {
+	if rule.Destination.ARN != "" {
+		return rule.Destination.ARN
+	}
+	return c.RoleArn
+}
+
+// TargetArns returns the ARNs of all remote targets in the configuration,
+// in the order of their first appearance in the rules.
+func (c Config) TargetArns() []string {
+	var arns []string
+	seen := make(map[string]struct{}, len(c.Rules))
+	for _, rule := range c.Rules {
+		arn := c.ruleArn(rule)
+		if _, ok := seen[arn]; ok || arn == "" {
+			continue
+		}
+		seen[arn] = struct{}{}
+		arns = append(arns, arn)
+	}
+	return arns
+}
+
+// GetTargetDestination returns destination bucket and storage class
+// of the remote target arn.
+func (c Config) GetTargetDestination(arn string) Destination {
+	for _, rule := range c.Rules {
+		if c.ruleArn(rule) == arn {
+			return rule.Destination
+		}
+	}
+	return Destination{}
+}
+
+// FilterTargetArns returns the ARNs of the remote targets the object
+// should be replicated to.
+func (c Config) FilterTargetArns(obj ObjectOpts) []string {
+	var arns []string
+	for _, arn := range c.TargetArns() {
+		opts := obj
+		opts.TargetArn = arn
+		if c.Replicate(opts) {
+			arns = append(arns, arn)
+		}
+	}
+	return arns
+}
+
+// Replicate returns true if the object should be replicated to any of
+// the remote targets, or to obj.TargetArn when it is set.
 func (c Config) Replicate(obj ObjectOpts) bool {
+	if obj.TargetArn == "" {
+		return len(c.FilterTargetArns(obj)) > 0
+	}
 
 	for _, rule := range c.FilterActionableRules(obj) {
 		// check MinIO extension for versioned deletes
@@ -0,0 +1,145 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+const (
+	testArn1 = "arn:minio:replication:us-east-1:id1:dst1"
+	testArn2 = "arn:minio:replication:us-east-1:id2:dst2"
+)
+
+func TestParseAndValidateReplicationConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		expectedErr error
+	}{
+		// 1. Single target in Role
+		{
+			inputConfig: `<ReplicationConfiguration><Role>` + testArn1 + `</Role><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:aws:s3:::dst1</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		// 2. Per rule targets without Role
+		{
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>` + testArn1 + `</Bucket></Destination></Rule><Rule><Status>Enabled</Status><Priority>2</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>` + testArn2 + `</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		// 3. Rule without target ARN and no Role
+		{
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:aws:s3:::dst1</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errRoleArnMissing,
+		},
+		// 4. Different destination buckets for the target in Role
+		{
+			inputConfig: `<ReplicationConfiguration><Role>` + testArn1 + `</Role><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:aws:s3:::dst1</Bucket></Destination></Rule><Rule><Status>Enabled</Status><Priority>2</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>arn:aws:s3:::dst2</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errReplicationDestinationMismatch,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected parse error %v", i+1, err)
+		}
+		if err = config.Validate("src", false); err != tc.expectedErr {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.expectedErr, err)
+		}
+	}
+}
+
+func TestFilterTargetArns(t *testing.T) {
+	config, err := ParseConfig(bytes.NewReader([]byte(`<ReplicationConfiguration>
+  <Role>` + testArn1 + `</Role>
+  <Rule>
+    <Status>Enabled</Status><Priority>1</Priority>
+    <DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication>
+    <Destination><Bucket>arn:aws:s3:::dst1</Bucket></Destination>
+  </Rule>
+  <Rule>
+    <Status>Enabled</Status><Priority>2</Priority>
+    <DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication>
+    <Filter><Prefix>docs/</Prefix></Filter>
+    <Destination><Bucket>` + testArn2 + `</Bucket><StorageClass>STANDARD</StorageClass></Destination>
+  </Rule>
+</ReplicationConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if arns := config.TargetArns(); !reflect.DeepEqual(arns, []string{testArn1, testArn2}) {
+		t.Fatalf("unexpected target arns %v", arns)
+	}
+	if dest := config.GetTargetDestination(testArn2); dest.Bucket != "dst2" || dest.StorageClass != "STANDARD" {
+		t.Fatalf("unexpected destination %v", dest)
+	}
+
+	testCases := []struct {
+		opts ObjectOpts
+		arns []string
+	}{
+		{ObjectOpts{Name: "a.txt"}, []string{testArn1}},
+		{ObjectOpts{Name: "docs/a.txt"}, []string{testArn1, testArn2}},
+		{ObjectOpts{Name: "docs/a.txt", DeleteMarker: true}, []string{testArn2}},
+		{ObjectOpts{Name: "docs/a.txt", SSEC: true}, nil},
+	}
+	for i, tc := range testCases {
+		if arns := config.FilterTargetArns(tc.opts); !reflect.DeepEqual(arns, tc.arns) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.arns, arns)
+		}
+		if replicate := config.Replicate(tc.opts); replicate != (len(tc.arns) > 0) {
+			t.Fatalf("Test %d: unexpected replicate %v", i+1, replicate)
+		}
+	}
+
+	// Target ARNs are preserved when the configuration is saved.
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`<Bucket>`+testArn2+`</Bucket>`)) {
+		t.Fatalf("target arn missing in %s", data)
+	}
+}
+
+func TestTargetStatuses(t *testing.T) {
+	statuses := ParseTargetStatuses(testArn2 + "=FAILED;" + testArn1 + "=COMPLETED;")
+	if statuses[testArn1] != Completed || statuses[testArn2] != Failed {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+	if s := statuses.String(); s != testArn1+"=COMPLETED;"+testArn2+"=FAILED;" {
+		t.Fatalf("unexpected encoding %s", s)
+	}
+	if s := statuses.Status(); s != Failed {
+		t.Fatalf("expected %s, got %s", Failed, s)
+	}
+	statuses[testArn2] = Pending
+	if s := statuses.Status(); s != Pending {
+		t.Fatalf("expected %s, got %s", Pending, s)
+	}
+	statuses[testArn2] = Completed
+	if s := statuses.Status(); s != Completed {
+		t.Fatalf("expected %s, got %s", Completed, s)
+	}
+	if s := ParseTargetStatuses("").Status(); !s.Empty() {
+		t.Fatalf("expected empty status, got %s", s)
+	}
+}
@@ -0,0 +1,268 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	errMissingIndexDocument    = Errorf("A value for IndexDocument Suffix must be provided if RedirectAllRequestsTo is empty")
+	errInvalidIndexSuffix      = Errorf("The IndexDocument Suffix is not well formed")
+	errMissingErrorDocumentKey = Errorf("The ErrorDocument Key must not be empty")
+	errRedirectAllWithOthers   = Errorf("RedirectAllRequestsTo cannot be provided in conjunction with other Routing/Redirect configurations")
+	errMissingRedirectHostName = Errorf("RedirectAllRequestsTo HostName must not be empty")
+	errInvalidProtocol         = Errorf("Invalid protocol, protocol can be http or https")
+	errTooManyRoutingRules     = Errorf("Website configuration allows a maximum of 50 routing rules")
+	errEmptyRedirect           = Errorf("A Redirect must have at least one of HostName, HttpRedirectCode, Protocol, ReplaceKeyPrefixWith or ReplaceKeyWith")
+	errEmptyCondition          = Errorf("A Condition must have at least one of HttpErrorCodeReturnedEquals or KeyPrefixEquals")
+	errReplaceKeyBoth          = Errorf("You can only define ReplaceKeyPrefixWith or ReplaceKeyWith but not both")
+	errInvalidRedirectCode     = Errorf("The provided HTTP redirect code is not valid. It should be a 3XX code")
+	errInvalidConditionCode    = Errorf("The provided HTTP error code is not valid. Valid codes are 4XX or 5XX")
+)
+
+// Maximum number of routing rules per website configuration, same as AWS S3.
+const maxRoutingRules = 50
+
+const xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+// IndexDocument - the object served for requests made to a
+// directory-like key, Suffix is appended to such keys.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the object served when a request results in
+// a 4XX class error.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects every request made to the
+// website endpoint of the bucket to another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - condition under which a routing rule is applied.
+type Condition struct {
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+}
+
+// Redirect - describes where and how a matching request is redirected.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - a single redirect rule with an optional condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+// Config - bucket website configuration as specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketWebsite.html
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+// ParseConfig - parses data in given reader to WebsiteConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if c.XMLNS == "" {
+		c.XMLNS = xmlNS
+	}
+	return &c, nil
+}
+
+func validProtocol(protocol string) bool {
+	switch protocol {
+	case "", "http", "https":
+		return true
+	}
+	return false
+}
+
+// validCode returns true if code is a number within [min, max].
+func validCode(code string, min, max int) bool {
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return false
+	}
+	return n >= min && n <= max
+}
+
+// Validate - validates the website configuration
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return errRedirectAllWithOthers
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return errMissingRedirectHostName
+		}
+		if !validProtocol(c.RedirectAllRequestsTo.Protocol) {
+			return errInvalidProtocol
+		}
+		return nil
+	}
+
+	if c.IndexDocument == nil || c.IndexDocument.Suffix == "" {
+		return errMissingIndexDocument
+	}
+	if strings.Contains(c.IndexDocument.Suffix, "/") {
+		return errInvalidIndexSuffix
+	}
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return errMissingErrorDocumentKey
+	}
+	if len(c.RoutingRules) > maxRoutingRules {
+		return errTooManyRoutingRules
+	}
+	for _, rule := range c.RoutingRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate - validates the routing rule
+func (r RoutingRule) Validate() error {
+	if r.Condition != nil {
+		cond := r.Condition
+		if cond.HTTPErrorCodeReturnedEquals == "" && cond.KeyPrefixEquals == "" {
+			return errEmptyCondition
+		}
+		if cond.HTTPErrorCodeReturnedEquals != "" && !validCode(cond.HTTPErrorCodeReturnedEquals, 400, 599) {
+			return errInvalidConditionCode
+		}
+	}
+	redirect := r.Redirect
+	if redirect == (Redirect{}) {
+		return errEmptyRedirect
+	}
+	if redirect.ReplaceKeyPrefixWith != "" && redirect.ReplaceKeyWith != "" {
+		return errReplaceKeyBoth
+	}
+	if !validProtocol(redirect.Protocol) {
+		return errInvalidProtocol
+	}
+	if redirect.HTTPRedirectCode != "" && !validCode(redirect.HTTPRedirectCode, 300, 399) {
+		return errInvalidRedirectCode
+	}
+	return nil
+}
+
+// IndexKey returns the key to be served for the requested key,
+// directory-like keys are suffixed with the index document.
+func (c Config) IndexKey(key string) string {
+	if c.IndexDocument == nil {
+		return key
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key + c.IndexDocument.Suffix
+	}
+	return key
+}
+
+// Match returns the first routing rule applicable to the key,
+// errCode is the HTTP error code the request would result in or
+// 0 if the request succeeds. Rules conditioned on an error code
+// only apply to requests which failed with that error code.
+func (c Config) Match(key string, errCode int) (RoutingRule, bool) {
+	for _, rule := range c.RoutingRules {
+		if rule.Condition == nil {
+			if errCode == 0 {
+				return rule, true
+			}
+			continue
+		}
+		if rule.Condition.HTTPErrorCodeReturnedEquals != "" {
+			if errCode == 0 || rule.Condition.HTTPErrorCodeReturnedEquals != strconv.Itoa(errCode) {
+				continue
+			}
+		} else if errCode != 0 {
+			continue
+		}
+		if !strings.HasPrefix(key, rule.Condition.KeyPrefixEquals) {
+			continue
+		}
+		return rule, true
+	}
+	return RoutingRule{}, false
+}
+
+// StatusCode returns the HTTP status code used for the redirect,
+// defaults to 301 (Moved Permanently).
+func (r RoutingRule) StatusCode() int {
+	code, err := strconv.Atoi(r.Redirect.HTTPRedirectCode)
+	if err != nil {
+		return http.StatusMovedPermanently
+	}
+	return code
+}
+
+// Location returns the redirect location for the key, host and
+// protocol are those of the incoming request and are used when
+// the redirect does not override them.
+func (r RoutingRule) Location(protocol, host, key string) string {
+	redirect := r.Redirect
+	if redirect.Protocol != "" {
+		protocol = redirect.Protocol
+	}
+	if redirect.HostName != "" {
+		host = redirect.HostName
+	}
+	switch {
+	case redirect.ReplaceKeyWith != "":
+		key = redirect.ReplaceKeyWith
+	case redirect.ReplaceKeyPrefixWith != "":
+		var prefix string
+		if r.Condition != nil {
+			prefix = r.Condition.KeyPrefixEquals
+		}
+		key = redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+	return protocol + "://" + host + "/" + key
+}
+
+// Location returns the redirect location for the key.
+func (r RedirectAllRequestsTo) Location(protocol, key string) string {
+	if r.Protocol != "" {
+		protocol = r.Protocol
+	}
+	return protocol + "://" + r.HostName + "/" + key
+}
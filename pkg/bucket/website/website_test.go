@@ -0,0 +1,145 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAndValidateWebsiteConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		expectedErr error
+	}{
+		// 1. Valid config with index and error documents
+		{
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectedErr: nil,
+		},
+		// 2. Valid redirect all requests
+		{
+			inputConfig: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: nil,
+		},
+		// 3. Missing index document
+		{
+			inputConfig: `<WebsiteConfiguration><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectedErr: errMissingIndexDocument,
+		},
+		// 4. Index suffix with a slash
+		{
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>a/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectedErr: errInvalidIndexSuffix,
+		},
+		// 5. Redirect all requests along with index document
+		{
+			inputConfig: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectedErr: errRedirectAllWithOthers,
+		},
+		// 6. Invalid protocol
+		{
+			inputConfig: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>ftp</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: errInvalidProtocol,
+		},
+		// 7. Both replace key elements
+		{
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><ReplaceKeyWith>a</ReplaceKeyWith><ReplaceKeyPrefixWith>b</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errReplaceKeyBoth,
+		},
+		// 8. Non 3XX redirect code
+		{
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errInvalidRedirectCode,
+		},
+		// 9. Empty condition
+		{
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition></Condition><Redirect><HostName>example.com</HostName></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errEmptyCondition,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.inputConfig))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected parse error %v", i+1, err)
+		}
+		if err = config.Validate(); err != tc.expectedErr {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.expectedErr, err)
+		}
+	}
+}
+
+func TestWebsiteConfigRouting(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration>
+  <IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+  <RoutingRules>
+    <RoutingRule>
+      <Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition>
+      <Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect>
+    </RoutingRule>
+    <RoutingRule>
+      <Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition>
+      <Redirect><HostName>fallback.example.com</HostName><HttpRedirectCode>302</HttpRedirectCode></Redirect>
+    </RoutingRule>
+  </RoutingRules>
+</WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if key := config.IndexKey(""); key != "index.html" {
+		t.Fatalf("expected index.html, got %s", key)
+	}
+	if key := config.IndexKey("blog/"); key != "blog/index.html" {
+		t.Fatalf("expected blog/index.html, got %s", key)
+	}
+	if key := config.IndexKey("blog/post.html"); key != "blog/post.html" {
+		t.Fatalf("expected blog/post.html, got %s", key)
+	}
+
+	testCases := []struct {
+		key        string
+		errCode    int
+		match      bool
+		location   string
+		statusCode int
+	}{
+		{"docs/a.html", 0, true, "http://site.example.com/documents/a.html", 301},
+		{"blog/a.html", 0, false, "", 0},
+		{"blog/a.html", 404, true, "http://fallback.example.com/blog/a.html", 302},
+		{"blog/a.html", 403, false, "", 0},
+	}
+	for i, tc := range testCases {
+		rule, ok := config.Match(tc.key, tc.errCode)
+		if ok != tc.match {
+			t.Fatalf("Test %d: expected match %v, got %v", i+1, tc.match, ok)
+		}
+		if !ok {
+			continue
+		}
+		if location := rule.Location("http", "site.example.com", tc.key); location != tc.location {
+			t.Fatalf("Test %d: expected location %s, got %s", i+1, tc.location, location)
+		}
+		if code := rule.StatusCode(); code != tc.statusCode {
+			t.Fatalf("Test %d: expected status %d, got %d", i+1, tc.statusCode, code)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dsync
+
+import (
+	"net/url"
+	"sort"
+	"sync"
+)
+
+// NewLockerFunc returns the locker of the lock server at the endpoint,
+// local is true for the lock server of the calling process.
+type NewLockerFunc func(endpoint *url.URL, local bool) NetLocker
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]NewLockerFunc)
+)
+
+// RegisterBackend makes a lock backend available by name, replacing
+// any backend previously registered with the same name.
+func RegisterBackend(name string, fn NewLockerFunc) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = fn
+}
+
+// Backend returns the lock backend registered with the name.
+func Backend(name string) (fn NewLockerFunc, ok bool) {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	fn, ok = backends[name]
+	return fn, ok
+}
+
+// Backends returns the sorted names of the registered lock backends.
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
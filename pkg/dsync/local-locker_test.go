@@ -0,0 +1,290 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dsync_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/minio/minio/pkg/dsync"
+)
+
+// LocalLocker is an in-process lock server, it implements NetLocker
+// and LockLister for tests.
+type LocalLocker struct {
+	name    string
+	mutex   sync.Mutex
+	lockMap map[string][]LockInfo
+}
+
+// NewLocalLocker returns an empty in-process lock server named name.
+func NewLocalLocker(name string) *LocalLocker {
+	return &LocalLocker{
+		name:    name,
+		lockMap: make(map[string][]LockInfo),
+	}
+}
+
+func (l *LocalLocker) newLockInfo(resource string, writer bool, args LockArgs) LockInfo {
+	return LockInfo{
+		Resource:  resource,
+		Writer:    writer,
+		UID:       args.UID,
+		Owner:     args.Owner,
+		Source:    args.Source,
+		Quorum:    args.Quorum,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+func (l *LocalLocker) isWriteLocked(resource string) bool {
+	locks := l.lockMap[resource]
+	return len(locks) == 1 && locks[0].Writer
+}
+
+// removeLock removes the lock of the resource with the uid and owner of args.
+func (l *LocalLocker) removeLock(resource string, args LockArgs) {
+	locks := l.lockMap[resource]
+	for i, lock := range locks {
+		if lock.UID == args.UID && lock.Owner == args.Owner {
+			if len(locks) == 1 {
+				delete(l.lockMap, resource)
+			} else {
+				l.lockMap[resource] = append(locks[:i:i], locks[i+1:]...)
+			}
+			return
+		}
+	}
+}
+
+// Lock write locks all the resources of args, or none of them.
+func (l *LocalLocker) Lock(ctx context.Context, args LockArgs) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, resource := range args.Resources {
+		if _, ok := l.lockMap[resource]; ok {
+			return false, nil
+		}
+	}
+	for _, resource := range args.Resources {
+		l.lockMap[resource] = []LockInfo{l.newLockInfo(resource, true, args)}
+	}
+	return true, nil
+}
+
+// Unlock releases the write locks of args.
+func (l *LocalLocker) Unlock(args LockArgs) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, resource := range args.Resources {
+		if _, ok := l.lockMap[resource]; ok && !l.isWriteLocked(resource) {
+			return false, fmt.Errorf("Unlock attempted on a read locked entity: %s", resource)
+		}
+	}
+	for _, resource := range args.Resources {
+		l.removeLock(resource, args)
+	}
+	return true, nil
+}
+
+// RLock read locks the resource of args.
+func (l *LocalLocker) RLock(ctx context.Context, args LockArgs) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	resource := args.Resources[0]
+	if l.isWriteLocked(resource) {
+		return false, nil
+	}
+	l.lockMap[resource] = append(l.lockMap[resource], l.newLockInfo(resource, false, args))
+	return true, nil
+}
+
+// RUnlock releases the read lock of args.
+func (l *LocalLocker) RUnlock(args LockArgs) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	resource := args.Resources[0]
+	if l.isWriteLocked(resource) {
+		return false, fmt.Errorf("RUnlock attempted on a write locked entity: %s", resource)
+	}
+	l.removeLock(resource, args)
+	return true, nil
+}
+
+// Expired returns true if the lock of args is not held anymore.
+func (l *LocalLocker) Expired(ctx context.Context, args LockArgs) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, lock := range l.lockMap[args.Resources[0]] {
+		if lock.UID == args.UID && lock.Owner == args.Owner {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ForceUnlock releases all the locks of the resources of args, or all
+// the locks of the owner of args when no resources are given.
+func (l *LocalLocker) ForceUnlock(ctx context.Context, args LockArgs) (bool, error) {
+	if len(args.UID) != 0 {
+		return false, fmt.Errorf("ForceUnlock called with non-empty UID: %s", args.UID)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(args.Resources) > 0 {
+		for _, resource := range args.Resources {
+			delete(l.lockMap, resource)
+		}
+		return true, nil
+	}
+	if args.Owner == "" {
+		return false, fmt.Errorf("ForceUnlock called without resources and owner")
+	}
+	for resource, locks := range l.lockMap {
+		for _, lock := range locks {
+			if lock.Owner == args.Owner {
+				l.removeLock(resource, LockArgs{UID: lock.UID, Owner: lock.Owner})
+			}
+		}
+	}
+	return true, nil
+}
+
+// ListLocks returns the locks held, sorted by resource.
+func (l *LocalLocker) ListLocks(ctx context.Context) ([]LockInfo, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var locks []LockInfo
+	for _, resourceLocks := range l.lockMap {
+		locks = append(locks, resourceLocks...)
+	}
+	sort.Slice(locks, func(i, j int) bool {
+		if locks[i].Resource != locks[j].Resource {
+			return locks[i].Resource < locks[j].Resource
+		}
+		return locks[i].Timestamp.Before(locks[j].Timestamp)
+	})
+	return locks, nil
+}
+
+// String returns the name of the lock server.
+func (l *LocalLocker) String() string {
+	return l.name
+}
+
+// Close is a no-op.
+func (l *LocalLocker) Close() error {
+	return nil
+}
+
+// IsOnline returns true, an in-process lock server is always online.
+func (l *LocalLocker) IsOnline() bool {
+	return true
+}
+
+// IsLocal returns true.
+func (l *LocalLocker) IsLocal() bool {
+	return true
+}
+
+func newLocalDsync(owner string, lockers []NetLocker) *Dsync {
+	return &Dsync{
+		GetLockers: func() ([]NetLocker, string) { return lockers, owner },
+	}
+}
+
+func TestLocalLocker(t *testing.T) {
+	var lockers []NetLocker
+	for i := 0; i < 4; i++ {
+		lockers = append(lockers, NewLocalLocker(fmt.Sprintf("locker-%d", i)))
+	}
+	node1 := newLocalDsync("node1", lockers)
+	node2 := newLocalDsync("node2", lockers)
+	opts := Options{Timeout: 100 * time.Millisecond}
+
+	dm := NewDRWMutex(node1, "bucket/object")
+	if !dm.GetLock(context.Background(), id, source, opts) {
+		t.Fatal("expected the lock to be granted")
+	}
+	if NewDRWMutex(node2, "bucket/object").GetRLock(context.Background(), id, source, opts) {
+		t.Fatal("expected the read lock to be refused while write locked")
+	}
+
+	for _, locker := range lockers {
+		locks, err := locker.(LockLister).ListLocks(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(locks) != 1 || locks[0].Resource != "bucket/object" || !locks[0].Writer ||
+			locks[0].Owner != "node1" || locks[0].Source != source {
+			t.Fatalf("unexpected locks on %s: %+v", locker, locks)
+		}
+	}
+
+	// node1 is gone, its locks are released on all lock servers.
+	for _, locker := range lockers {
+		if _, err := locker.ForceUnlock(context.Background(), LockArgs{Owner: "node1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dm2 := NewDRWMutex(node2, "bucket/object")
+	if !dm2.GetRLock(context.Background(), id, source, opts) {
+		t.Fatal("expected the read lock to be granted after releasing the owner locks")
+	}
+	if !NewDRWMutex(node1, "bucket/object").GetRLock(context.Background(), id, source, opts) {
+		t.Fatal("expected a second read lock to be granted")
+	}
+	locks, _ := lockers[0].(LockLister).ListLocks(context.Background())
+	if len(locks) != 2 || locks[0].Writer || locks[1].Writer {
+		t.Fatalf("expected 2 read locks, got %+v", locks)
+	}
+	dm2.RUnlock()
+	locks, _ = lockers[0].(LockLister).ListLocks(context.Background())
+	if len(locks) != 1 || locks[0].Owner != "node1" {
+		t.Fatalf("expected the read lock of node1, got %+v", locks)
+	}
+}
+
+func TestLockBackend(t *testing.T) {
+	RegisterBackend("test", func(endpoint *url.URL, local bool) NetLocker {
+		return NewLocalLocker(endpoint.Host)
+	})
+	fn, ok := Backend("test")
+	if !ok {
+		t.Fatal("expected the backend to be registered")
+	}
+	if locker := fn(&url.URL{Host: "server1:9000"}, true); locker.String() != "server1:9000" {
+		t.Fatalf("unexpected locker %s", locker)
+	}
+	if _, ok = Backend("unknown"); ok {
+		t.Fatal("expected the backend to be unknown")
+	}
+}
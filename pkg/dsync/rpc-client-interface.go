@@ -16,7 +16,10 @@
 
 package dsync
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // LockArgs is minimal required values for any dsync compatible lock operation.
 type LockArgs struct {
@@ -63,7 +66,8 @@ type NetLocker interface {
 	// Expired returns if current lock args has expired.
 	Expired(ctx context.Context, args LockArgs) (bool, error)
 
-	// Unlock (read/write) forcefully for given LockArgs. It should return
+	// Unlock (read/write) forcefully for given LockArgs, when no resources
+	// are given all the locks of the owner are unlocked. It should return
 	// * a boolean to indicate success/failure of the operation
 	// * an error on failure of unlock request operation.
 	ForceUnlock(ctx context.Context, args LockArgs) (bool, error)
@@ -80,3 +84,37 @@ type NetLocker interface {
 	// Is the underlying locker local to this server?
 	IsLocal() bool
 }
+
+// LockInfo describes a lock granted by a lock server.
+type LockInfo struct {
+	// Resource that is locked.
+	Resource string
+
+	// Writer is true for a write lock, false for a read lock.
+	Writer bool
+
+	// UID of the lock request.
+	UID string
+
+	// Owner that requested the lock.
+	Owner string
+
+	// Source contains the line number, function and file name of the code
+	// on the client node that requested the lock.
+	Source string
+
+	// Quorum is the number of lock servers required to hold the lock.
+	Quorum int
+
+	// Timestamp is the time the lock was granted.
+	Timestamp time.Time
+}
+
+// LockLister is implemented by lockers which can report the locks granted
+// by their lock server, used to inspect the locks held in a cluster.
+type LockLister interface {
+	NetLocker
+
+	// ListLocks returns the locks currently granted by the lock server.
+	ListLocks(ctx context.Context) ([]LockInfo, error)
+}
@@ -0,0 +1,240 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// ChecksumType is the algorithm of an additional checksum of an object.
+type ChecksumType string
+
+// Additional checksum algorithms.
+const (
+	ChecksumNone   ChecksumType = ""
+	ChecksumCRC32  ChecksumType = "CRC32"
+	ChecksumCRC32C ChecksumType = "CRC32C"
+	ChecksumSHA1   ChecksumType = "SHA1"
+	ChecksumSHA256 ChecksumType = "SHA256"
+)
+
+const (
+	// AmzChecksumAlgorithm is the algorithm of the checksum of a multipart upload.
+	AmzChecksumAlgorithm = "X-Amz-Checksum-Algorithm"
+
+	// AmzSDKChecksumAlgorithm is the algorithm of the checksum sent by SDKs.
+	AmzSDKChecksumAlgorithm = "X-Amz-Sdk-Checksum-Algorithm"
+)
+
+// ChecksumTypes are the supported additional checksum algorithms.
+var ChecksumTypes = []ChecksumType{ChecksumCRC32, ChecksumCRC32C, ChecksumSHA1, ChecksumSHA256}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrInvalidChecksum is returned for checksum headers which are malformed,
+// conflicting or not of a supported algorithm.
+var ErrInvalidChecksum = errors.New("invalid checksum")
+
+// NewChecksumType returns the checksum type of the algorithm name, such as
+// "crc32c", ChecksumNone if the algorithm is not supported.
+func NewChecksumType(alg string) ChecksumType {
+	t := ChecksumType(strings.ToUpper(alg))
+	for _, ct := range ChecksumTypes {
+		if t == ct {
+			return t
+		}
+	}
+	return ChecksumNone
+}
+
+// IsSet returns true for a supported checksum type.
+func (t ChecksumType) IsSet() bool {
+	return t != ChecksumNone
+}
+
+// Key returns the header carrying checksums of the type.
+func (t ChecksumType) Key() string {
+	if !t.IsSet() {
+		return ""
+	}
+	return "X-Amz-Checksum-" + string(t[:1]) + strings.ToLower(string(t[1:]))
+}
+
+// RawByteLen returns the length of checksums of the type.
+func (t ChecksumType) RawByteLen() int {
+	switch t {
+	case ChecksumCRC32, ChecksumCRC32C:
+		return crc32.Size
+	case ChecksumSHA1:
+		return sha1.Size
+	case ChecksumSHA256:
+		return sha256.Size
+	}
+	return 0
+}
+
+// Hasher returns a hash computing checksums of the type.
+func (t ChecksumType) Hasher() hash.Hash {
+	switch t {
+	case ChecksumCRC32:
+		return crc32.NewIEEE()
+	case ChecksumCRC32C:
+		return crc32.New(crc32cTable)
+	case ChecksumSHA1:
+		return sha1.New()
+	case ChecksumSHA256:
+		return sha256.New()
+	}
+	return nil
+}
+
+// Checksum is an additional checksum of the content of an object or of a
+// part, base64 encoded. The checksum of a multipart object is the checksum
+// of the checksums of its parts, followed by the number of parts.
+type Checksum struct {
+	Type    ChecksumType
+	Encoded string
+}
+
+// NewChecksum returns the checksum of the type with the raw value.
+func NewChecksum(t ChecksumType, raw []byte) *Checksum {
+	return &Checksum{Type: t, Encoded: base64.StdEncoding.EncodeToString(raw)}
+}
+
+// NewChecksumString returns the checksum of the type with the encoded value,
+// nil if the value is malformed.
+func NewChecksumString(t ChecksumType, encoded string) *Checksum {
+	c := &Checksum{Type: t, Encoded: encoded}
+	if !c.Valid() {
+		return nil
+	}
+	return c
+}
+
+// Valid returns true if the checksum is of a supported type, and its value
+// has the length of the type.
+func (c *Checksum) Valid() bool {
+	if c == nil || !c.Type.IsSet() {
+		return false
+	}
+	encoded := c.Encoded
+	if i := strings.LastIndexByte(encoded, '-'); i >= 0 {
+		// Checksum of a multipart object.
+		if n, err := strconv.Atoi(encoded[i+1:]); err != nil || n <= 0 {
+			return false
+		}
+		encoded = encoded[:i]
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	return err == nil && len(raw) == c.Type.RawByteLen()
+}
+
+// Raw returns the decoded value of the checksum, nil if it is malformed.
+func (c *Checksum) Raw() []byte {
+	if c == nil {
+		return nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(c.Encoded)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+// String returns the checksum as its type and encoded value, such as
+// "CRC32C:yZRlqg==", the reverse of ParseChecksum.
+func (c *Checksum) String() string {
+	if c == nil {
+		return ""
+	}
+	return string(c.Type) + ":" + c.Encoded
+}
+
+// ParseChecksum parses a checksum formatted by Checksum.String,
+// nil if it is malformed.
+func ParseChecksum(s string) *Checksum {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil
+	}
+	return NewChecksumString(NewChecksumType(s[:i]), s[i+1:])
+}
+
+// CompositeChecksum returns the checksum of a multipart object from the
+// checksums of its parts, nil if a part has no checksum of the type.
+func CompositeChecksum(t ChecksumType, parts []*Checksum) *Checksum {
+	h := t.Hasher()
+	if h == nil || len(parts) == 0 {
+		return nil
+	}
+	for _, part := range parts {
+		if part == nil || part.Type != t {
+			return nil
+		}
+		raw := part.Raw()
+		if len(raw) != t.RawByteLen() {
+			return nil
+		}
+		h.Write(raw)
+	}
+	return &Checksum{
+		Type:    t,
+		Encoded: base64.StdEncoding.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(parts)),
+	}
+}
+
+// GetContentChecksum returns the checksum sent in the x-amz-checksum-*
+// headers, nil if none was sent. When only an algorithm is sent, in the
+// x-amz-sdk-checksum-algorithm header, the checksum has no value and is
+// computed from the content.
+func GetContentChecksum(h http.Header) (*Checksum, error) {
+	var c *Checksum
+	for _, t := range ChecksumTypes {
+		value := h.Get(t.Key())
+		if value == "" {
+			continue
+		}
+		if c != nil {
+			// Only one checksum can be sent.
+			return nil, ErrInvalidChecksum
+		}
+		c = &Checksum{Type: t, Encoded: value}
+		if !c.Valid() || strings.Contains(value, "-") {
+			return nil, ErrInvalidChecksum
+		}
+	}
+
+	if alg := h.Get(AmzSDKChecksumAlgorithm); alg != "" {
+		t := NewChecksumType(alg)
+		if !t.IsSet() || (c != nil && c.Type != t) {
+			return nil, ErrInvalidChecksum
+		}
+		if c == nil {
+			c = &Checksum{Type: t}
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,113 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGetContentChecksum(t *testing.T) {
+	testCases := []struct {
+		header http.Header
+		want   *Checksum
+		err    error
+	}{
+		{http.Header{}, nil, nil},
+		{http.Header{"X-Amz-Checksum-Crc32c": {"4waSgw=="}}, &Checksum{ChecksumCRC32C, "4waSgw=="}, nil},
+		{http.Header{"X-Amz-Checksum-Sha256": {"FeKw08M4keuw8e9gnsQZQgwg4yDOlMZfvIwzEkSOsiU="}},
+			&Checksum{ChecksumSHA256, "FeKw08M4keuw8e9gnsQZQgwg4yDOlMZfvIwzEkSOsiU="}, nil},
+		{http.Header{"X-Amz-Sdk-Checksum-Algorithm": {"crc32"}}, &Checksum{ChecksumCRC32, ""}, nil},
+		{http.Header{"X-Amz-Sdk-Checksum-Algorithm": {"CRC32C"}, "X-Amz-Checksum-Crc32c": {"4waSgw=="}},
+			&Checksum{ChecksumCRC32C, "4waSgw=="}, nil},
+		// Invalid length, invalid encoding, multiple and conflicting checksums.
+		{http.Header{"X-Amz-Checksum-Crc32c": {"FeKw08M4keuw8e9gnsQZQgwg4yDOlMZfvIwzEkSOsiU="}}, nil, ErrInvalidChecksum},
+		{http.Header{"X-Amz-Checksum-Crc32": {"not base64"}}, nil, ErrInvalidChecksum},
+		{http.Header{"X-Amz-Checksum-Crc32": {"y/Q5Jg=="}, "X-Amz-Checksum-Crc32c": {"4waSgw=="}}, nil, ErrInvalidChecksum},
+		{http.Header{"X-Amz-Sdk-Checksum-Algorithm": {"SHA1"}, "X-Amz-Checksum-Crc32c": {"4waSgw=="}}, nil, ErrInvalidChecksum},
+		{http.Header{"X-Amz-Sdk-Checksum-Algorithm": {"MD5"}}, nil, ErrInvalidChecksum},
+	}
+	for i, testCase := range testCases {
+		got, err := GetContentChecksum(testCase.header)
+		if err != testCase.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if (got == nil) != (testCase.want == nil) || (got != nil && *got != *testCase.want) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.want, got)
+		}
+	}
+}
+
+func TestReaderChecksum(t *testing.T) {
+	data := []byte("123456789")
+	testCases := []struct {
+		checksum *Checksum
+		want     string
+		err      error
+	}{
+		{&Checksum{ChecksumCRC32, "y/Q5Jg=="}, "y/Q5Jg==", nil},
+		{&Checksum{ChecksumCRC32C, "4waSgw=="}, "4waSgw==", nil},
+		{&Checksum{ChecksumSHA256, ""}, "FeKw08M4keuw8e9gnsQZQgwg4yDOlMZfvIwzEkSOsiU=", nil},
+		{&Checksum{ChecksumCRC32C, "y/Q5Jg=="}, "", ChecksumMismatch{Want: "y/Q5Jg==", Got: "4waSgw=="}},
+	}
+	for i, testCase := range testCases {
+		r, err := NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = r.AddChecksum(testCase.checksum); err != nil {
+			t.Fatal(err)
+		}
+		_, err = io.Copy(ioutil.Discard, r)
+		if err != testCase.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if got := r.ContentChecksum(); got == nil || got.Encoded != testCase.want {
+			t.Fatalf("Test %d: expected checksum %s, got %v", i+1, testCase.want, got)
+		}
+	}
+}
+
+func TestCompositeChecksum(t *testing.T) {
+	parts := []*Checksum{
+		{ChecksumCRC32C, "4waSgw=="},
+		{ChecksumCRC32C, "4waSgw=="},
+	}
+	h := ChecksumCRC32C.Hasher()
+	h.Write([]byte{0xe3, 0x06, 0x92, 0x83, 0xe3, 0x06, 0x92, 0x83})
+	want := base64.StdEncoding.EncodeToString(h.Sum(nil)) + "-2"
+
+	c := CompositeChecksum(ChecksumCRC32C, parts)
+	if c == nil || c.Encoded != want || !c.Valid() {
+		t.Fatalf("expected composite checksum %s, got %v", want, c)
+	}
+	if p := ParseChecksum(c.String()); p == nil || *p != *c {
+		t.Fatalf("expected %v to be parsed, got %v", c, p)
+	}
+
+	parts[1] = &Checksum{ChecksumCRC32, "y/Q5Jg=="}
+	if c = CompositeChecksum(ChecksumCRC32C, parts); c != nil {
+		t.Fatalf("expected no composite checksum for parts of different types, got %v", c)
+	}
+}
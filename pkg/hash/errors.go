@@ -47,3 +47,13 @@ type ErrSizeMismatch struct {
 func (e ErrSizeMismatch) Error() string {
 	return fmt.Sprintf("Size mismatch: got %d, want %d", e.Got, e.Want)
 }
+
+// ChecksumMismatch - when the content checksum does not match what was sent from client.
+type ChecksumMismatch struct {
+	Want string
+	Got  string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return "Bad checksum: Want " + e.Want + " does not match calculated " + e.Got
+}
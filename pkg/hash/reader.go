@@ -38,6 +38,10 @@ type Reader struct {
 
 	md5sum, sha256sum   []byte // Byte values of md5sum, sha256sum of client sent values.
 	md5Hash, sha256Hash hash.Hash
+
+	checksum     *Checksum // Additional checksum sent by the client.
+	contentHash  hash.Hash
+	contentFinal *Checksum // Additional checksum of the content read.
 }
 
 // NewReader returns a new hash Reader which computes the MD5 sum and
@@ -62,6 +66,9 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 		if r.sha256Hash != nil {
 			r.sha256Hash.Write(p[:n])
 		}
+		if r.contentHash != nil {
+			r.contentHash.Write(p[:n])
+		}
 	}
 	r.bytesRead += int64(n)
 
@@ -120,9 +127,47 @@ func (r *Reader) SHA256HexString() string {
 	return hex.EncodeToString(r.sha256sum)
 }
 
+// AddChecksum adds an additional checksum of the content, verified at
+// EOF. A checksum without value is only computed.
+func (r *Reader) AddChecksum(c *Checksum) error {
+	if c == nil {
+		return nil
+	}
+	if r.bytesRead > 0 {
+		return errors.New("internal error: Already read from hash reader")
+	}
+	if r.checksum != nil {
+		if r.checksum.Type != c.Type || (r.checksum.Encoded != "" && c.Encoded != "" && r.checksum.Encoded != c.Encoded) {
+			return ErrInvalidChecksum
+		}
+		if c.Encoded == "" {
+			return nil
+		}
+	}
+	if c.Encoded != "" && !c.Valid() {
+		return ErrInvalidChecksum
+	}
+	r.checksum = c
+	r.contentHash = c.Type.Hasher()
+	return nil
+}
+
+// ContentChecksum returns the additional checksum of the content, once
+// read to EOF, nil if no checksum was added.
+func (r *Reader) ContentChecksum() *Checksum {
+	return r.contentFinal
+}
+
 // verify verifies if the computed MD5 sum and SHA256 sum are
 // equal to the ones specified when creating the Reader.
 func (r *Reader) verify() error {
+	if r.contentHash != nil {
+		got := NewChecksum(r.checksum.Type, r.contentHash.Sum(nil))
+		if r.checksum.Encoded != "" && r.checksum.Encoded != got.Encoded {
+			return ChecksumMismatch{Want: r.checksum.Encoded, Got: got.Encoded}
+		}
+		r.contentFinal = got
+	}
 	if r.sha256Hash != nil && len(r.sha256sum) > 0 {
 		if sum := r.sha256Hash.Sum(nil); !bytes.Equal(r.sha256sum, sum) {
 			return SHA256Mismatch{hex.EncodeToString(r.sha256sum), hex.EncodeToString(sum)}
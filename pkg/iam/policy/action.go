@@ -156,6 +156,27 @@ const (
 	// GetBucketEncryptionAction - GetBucketEncryption REST API action
 	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
 
+	// PutBucketCORSAction - PutBucketCors REST API action
+	PutBucketCORSAction = "s3:PutBucketCORS"
+
+	// GetBucketCORSAction - GetBucketCors REST API action
+	GetBucketCORSAction = "s3:GetBucketCORS"
+
+	// PutBucketWebsiteAction - PutBucketWebsite REST API action
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+
+	// GetBucketWebsiteAction - GetBucketWebsite REST API action
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite REST API action
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketLoggingAction - PutBucketLogging REST API action
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+
+	// GetBucketLoggingAction - GetBucketLogging REST API action
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
 	// PutBucketVersioningAction - PutBucketVersioning REST API action
 	PutBucketVersioningAction = "s3:PutBucketVersioning"
 
@@ -226,6 +247,13 @@ var supportedActions = map[Action]struct{}{
 	DeleteObjectTaggingAction:              {},
 	PutBucketEncryptionAction:              {},
 	GetBucketEncryptionAction:              {},
+	PutBucketCORSAction:                    {},
+	GetBucketCORSAction:                    {},
+	PutBucketWebsiteAction:                 {},
+	GetBucketWebsiteAction:                 {},
+	DeleteBucketWebsiteAction:              {},
+	PutBucketLoggingAction:                 {},
+	GetBucketLoggingAction:                 {},
 	PutBucketVersioningAction:              {},
 	GetBucketVersioningAction:              {},
 	GetReplicationConfigurationAction:      {},
@@ -301,6 +329,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 
 	GetObjectAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 		}, condition.CommonKeys...)...),
@@ -345,23 +374,33 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3ObjectLockRetainUntilDate,
 			condition.S3ObjectLockMode,
 			condition.S3ObjectLockLegalHold,
+			condition.S3RequestObjectTag,
+			condition.S3RequestObjectTagKeys,
 		}, condition.CommonKeys...)...),
 
 	// https://docs.aws.amazon.com/AmazonS3/latest/dev/list_amazons3.html
 	// LockLegalHold is not supported with PutObjectRetentionAction
 	PutObjectRetentionAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3ObjectLockRemainingRetentionDays,
 			condition.S3ObjectLockRetainUntilDate,
 			condition.S3ObjectLockMode,
 		}, condition.CommonKeys...)...),
 
-	GetObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+	GetObjectRetentionAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 	PutObjectLegalHoldAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3ObjectLockLegalHold,
 		}, condition.CommonKeys...)...),
-	GetObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+	GetObjectLegalHoldAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 
 	// https://docs.aws.amazon.com/AmazonS3/latest/dev/list_amazons3.html
 	BypassGovernanceRetentionAction: condition.NewKeySet(
@@ -376,17 +415,31 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	PutBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
 	GetBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	PutBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	PutObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	GetObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
+	PutObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+	GetObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+	DeleteObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 
-	PutObjectVersionTaggingAction: condition.NewKeySet(condition.CommonKeys...),
+	PutObjectVersionTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 	GetObjectVersionAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
 	GetObjectVersionTaggingAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
 	DeleteObjectVersionAction: condition.NewKeySet(
@@ -395,8 +448,16 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 		}, condition.CommonKeys...)...),
 	DeleteObjectVersionTaggingAction: condition.NewKeySet(
 		append([]condition.Key{
+			condition.S3ExistingObjectTag,
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
+	PutBucketCORSAction:                  condition.NewKeySet(condition.CommonKeys...),
+	GetBucketCORSAction:                  condition.NewKeySet(condition.CommonKeys...),
+	PutBucketWebsiteAction:               condition.NewKeySet(condition.CommonKeys...),
+	GetBucketWebsiteAction:               condition.NewKeySet(condition.CommonKeys...),
+	DeleteBucketWebsiteAction:            condition.NewKeySet(condition.CommonKeys...),
+	PutBucketLoggingAction:               condition.NewKeySet(condition.CommonKeys...),
+	GetBucketLoggingAction:               condition.NewKeySet(condition.CommonKeys...),
 	GetReplicationConfigurationAction:    condition.NewKeySet(condition.CommonKeys...),
 	PutReplicationConfigurationAction:    condition.NewKeySet(condition.CommonKeys...),
 	ReplicateObjectAction:                condition.NewKeySet(condition.CommonKeys...),
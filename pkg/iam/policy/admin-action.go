@@ -60,6 +60,12 @@ const (
 	ServiceRestartAdminAction = "admin:ServiceRestart"
 	// ServiceStopAdminAction - allow stopping MinIO service.
 	ServiceStopAdminAction = "admin:ServiceStop"
+	// DecommissionAdminAction - allow decommissioning server pools
+	DecommissionAdminAction = "admin:Decommission"
+	// ReplaceDriveAdminAction - allow marking drives offline and replacing them
+	ReplaceDriveAdminAction = "admin:ReplaceDrive"
+	// RebalanceAdminAction - allow rebalancing server pools
+	RebalanceAdminAction = "admin:Rebalance"
 
 	// ConfigUpdateAdminAction - allow MinIO config management
 	ConfigUpdateAdminAction = "admin:ConfigUpdate"
@@ -112,6 +118,20 @@ const (
 	// GetBucketQuotaAdminAction - allow getting bucket quota
 	GetBucketQuotaAdminAction = "admin:GetBucketQuota"
 
+	// Bucket storage class Actions
+
+	// SetBucketStorageClassAdminAction - allow setting bucket storage class
+	SetBucketStorageClassAdminAction = "admin:SetBucketStorageClass"
+	// GetBucketStorageClassAdminAction - allow getting bucket storage class
+	GetBucketStorageClassAdminAction = "admin:GetBucketStorageClass"
+
+	// Bucket compression Actions
+
+	// SetBucketCompressionAdminAction - allow setting bucket compression
+	SetBucketCompressionAdminAction = "admin:SetBucketCompression"
+	// GetBucketCompressionAdminAction - allow getting bucket compression
+	GetBucketCompressionAdminAction = "admin:GetBucketCompression"
+
 	// Bucket Target admin Actions
 
 	// SetBucketTargetAction - allow setting bucket target
@@ -119,49 +139,65 @@ const (
 	// GetBucketTargetAction - allow getting bucket targets
 	GetBucketTargetAction = "admin:GetBucketTarget"
 
+	// Remote Tier admin Actions
+
+	// SetTierAction - allow adding, editing and removing remote tiers
+	SetTierAction = "admin:SetTier"
+	// ListTierAction - allow listing remote tiers
+	ListTierAction = "admin:ListTier"
+
 	// AllAdminActions - provides all admin permissions
 	AllAdminActions = "admin:*"
 )
 
 // List of all supported admin actions.
 var supportedAdminActions = map[AdminAction]struct{}{
-	HealAdminAction:                {},
-	StorageInfoAdminAction:         {},
-	DataUsageInfoAdminAction:       {},
-	TopLocksAdminAction:            {},
-	ProfilingAdminAction:           {},
-	TraceAdminAction:               {},
-	ConsoleLogAdminAction:          {},
-	KMSKeyStatusAdminAction:        {},
-	ServerInfoAdminAction:          {},
-	HealthInfoAdminAction:          {},
-	BandwidthMonitorAction:         {},
-	ServerUpdateAdminAction:        {},
-	ServiceRestartAdminAction:      {},
-	ServiceStopAdminAction:         {},
-	ConfigUpdateAdminAction:        {},
-	CreateUserAdminAction:          {},
-	DeleteUserAdminAction:          {},
-	ListUsersAdminAction:           {},
-	EnableUserAdminAction:          {},
-	DisableUserAdminAction:         {},
-	GetUserAdminAction:             {},
-	AddUserToGroupAdminAction:      {},
-	RemoveUserFromGroupAdminAction: {},
-	GetGroupAdminAction:            {},
-	ListGroupsAdminAction:          {},
-	EnableGroupAdminAction:         {},
-	DisableGroupAdminAction:        {},
-	CreatePolicyAdminAction:        {},
-	DeletePolicyAdminAction:        {},
-	GetPolicyAdminAction:           {},
-	AttachPolicyAdminAction:        {},
-	ListUserPoliciesAdminAction:    {},
-	SetBucketQuotaAdminAction:      {},
-	GetBucketQuotaAdminAction:      {},
-	SetBucketTargetAction:          {},
-	GetBucketTargetAction:          {},
-	AllAdminActions:                {},
+	HealAdminAction:                  {},
+	StorageInfoAdminAction:           {},
+	DataUsageInfoAdminAction:         {},
+	TopLocksAdminAction:              {},
+	ProfilingAdminAction:             {},
+	TraceAdminAction:                 {},
+	ConsoleLogAdminAction:            {},
+	KMSKeyStatusAdminAction:          {},
+	ServerInfoAdminAction:            {},
+	HealthInfoAdminAction:            {},
+	BandwidthMonitorAction:           {},
+	ServerUpdateAdminAction:          {},
+	ServiceRestartAdminAction:        {},
+	ServiceStopAdminAction:           {},
+	DecommissionAdminAction:          {},
+	ReplaceDriveAdminAction:          {},
+	RebalanceAdminAction:             {},
+	ConfigUpdateAdminAction:          {},
+	CreateUserAdminAction:            {},
+	DeleteUserAdminAction:            {},
+	ListUsersAdminAction:             {},
+	EnableUserAdminAction:            {},
+	DisableUserAdminAction:           {},
+	GetUserAdminAction:               {},
+	AddUserToGroupAdminAction:        {},
+	RemoveUserFromGroupAdminAction:   {},
+	GetGroupAdminAction:              {},
+	ListGroupsAdminAction:            {},
+	EnableGroupAdminAction:           {},
+	DisableGroupAdminAction:          {},
+	CreatePolicyAdminAction:          {},
+	DeletePolicyAdminAction:          {},
+	GetPolicyAdminAction:             {},
+	AttachPolicyAdminAction:          {},
+	ListUserPoliciesAdminAction:      {},
+	SetBucketQuotaAdminAction:        {},
+	GetBucketQuotaAdminAction:        {},
+	SetBucketStorageClassAdminAction: {},
+	GetBucketStorageClassAdminAction: {},
+	SetBucketCompressionAdminAction:  {},
+	GetBucketCompressionAdminAction:  {},
+	SetBucketTargetAction:            {},
+	GetBucketTargetAction:            {},
+	SetTierAction:                    {},
+	ListTierAction:                   {},
+	AllAdminActions:                  {},
 }
 
 // IsValid - checks if action is valid or not.
@@ -172,40 +208,49 @@ func (action AdminAction) IsValid() bool {
 
 // adminActionConditionKeyMap - holds mapping of supported condition key for an action.
 var adminActionConditionKeyMap = map[Action]condition.KeySet{
-	AllAdminActions:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	HealAdminAction:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	StorageInfoAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DataUsageInfoAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	HealthInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	BandwidthMonitorAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TopLocksAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ProfilingAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TraceAdminAction:               condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConsoleLogAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	KMSKeyStatusAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServiceRestartAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServiceStopAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConfigUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreateUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeleteUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUsersAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableUserAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetUserAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AddUserToGroupAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	RemoveUserFromGroupAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListGroupsAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableGroupAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableGroupAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreatePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeletePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetPolicyAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AttachPolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUserPoliciesAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	SetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	SetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AllAdminActions:                  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	HealAdminAction:                  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	StorageInfoAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerInfoAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DataUsageInfoAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	HealthInfoAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	BandwidthMonitorAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TopLocksAdminAction:              condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ProfilingAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TraceAdminAction:                 condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConsoleLogAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	KMSKeyStatusAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerUpdateAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServiceRestartAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServiceStopAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DecommissionAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ReplaceDriveAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RebalanceAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConfigUpdateAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreateUserAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeleteUserAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUsersAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableUserAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetUserAdminAction:               condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AddUserToGroupAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RemoveUserFromGroupAdminAction:   condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListGroupsAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableGroupAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableGroupAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreatePolicyAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeletePolicyAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetPolicyAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AttachPolicyAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUserPoliciesAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketQuotaAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketQuotaAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketStorageClassAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketStorageClassAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketCompressionAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketCompressionAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketTargetAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketTargetAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetTierAction:                    condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListTierAction:                   condition.NewKeySet(condition.AllSupportedAdminKeys...),
 }
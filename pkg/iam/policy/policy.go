@@ -23,6 +23,7 @@ import (
 
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 )
 
 // DefaultVersion - default policy version as per AWS S3 specification.
@@ -122,11 +123,43 @@ func (iamp Policy) IsAllowed(args Args) bool {
 	return false
 }
 
+// MatchingStatements - returns the deny statements denying the given args
+// and the allow statements allowing them.
+func (iamp Policy) MatchingStatements(args Args) []Statement {
+	var statements []Statement
+	for _, statement := range iamp.Statements {
+		switch statement.Effect {
+		case policy.Allow:
+			if statement.IsAllowed(args) {
+				statements = append(statements, statement)
+			}
+		case policy.Deny:
+			if !statement.IsAllowed(args) {
+				statements = append(statements, statement)
+			}
+		}
+	}
+
+	return statements
+}
+
 // IsEmpty - returns whether policy is empty or not.
 func (iamp Policy) IsEmpty() bool {
 	return len(iamp.Statements) == 0
 }
 
+// ConditionKeys - returns the condition keys used in all statements.
+func (iamp Policy) ConditionKeys() condition.KeySet {
+	keys := condition.NewKeySet()
+	for _, statement := range iamp.Statements {
+		for key := range statement.Conditions.Keys() {
+			keys.Add(key)
+		}
+	}
+
+	return keys
+}
+
 // isValid - checks if Policy is valid or not.
 func (iamp Policy) isValid() error {
 	if iamp.Version != DefaultVersion && iamp.Version != "" {
@@ -153,10 +186,18 @@ redo:
 				continue
 			}
 
+			if !iamp.Statements[i].NotActions.Equals(statement.NotActions) {
+				continue
+			}
+
 			if !iamp.Statements[i].Resources.Equals(statement.Resources) {
 				continue
 			}
 
+			if !iamp.Statements[i].NotResources.Equals(statement.NotResources) {
+				continue
+			}
+
 			if iamp.Statements[i].Conditions.String() != statement.Conditions.String() {
 				continue
 			}
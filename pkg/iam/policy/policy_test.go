@@ -17,6 +17,7 @@
 package iampolicy
 
 import (
+	"bytes"
 	"encoding/json"
 	"net"
 	"reflect"
@@ -915,3 +916,75 @@ func TestPolicyValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyObjectTagConditions(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"StringEquals": {"s3:ExistingObjectTag/project": "alpha"}}
+        },
+        {
+            "Effect": "Allow",
+            "Action": ["s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {
+                "StringEquals": {"s3:RequestObjectTag/project": "alpha"},
+                "StringLike": {"s3:RequestObjectTagKeys": ["project", "owner"]}
+            }
+        }
+    ]
+}`)
+	p, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error. %v", err)
+	}
+	if keys := p.ConditionKeys(); len(keys) != 3 {
+		t.Fatalf("expected 3 condition keys, got %v", keys)
+	}
+
+	testCases := []struct {
+		action         Action
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{GetObjectAction, map[string][]string{"ExistingObjectTag/project": {"alpha"}}, true},
+		{GetObjectAction, map[string][]string{"ExistingObjectTag/project": {"beta"}}, false},
+		{GetObjectAction, map[string][]string{}, false},
+		{PutObjectAction, map[string][]string{"RequestObjectTag/project": {"alpha"}, "RequestObjectTagKeys": {"project"}}, true},
+		{PutObjectAction, map[string][]string{"RequestObjectTag/project": {"alpha"}}, false},
+		{PutObjectAction, map[string][]string{"RequestObjectTag/owner": {"alpha"}, "RequestObjectTagKeys": {"owner"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          testCase.action,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: testCase.values,
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Request tags are not supported for ListBucket.
+	data = []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:ListBucket"],
+            "Resource": ["arn:aws:s3:::mybucket"],
+            "Condition": {"StringEquals": {"s3:RequestObjectTag/project": "alpha"}}
+        }
+    ]
+}`)
+	if _, err = ParseConfig(bytes.NewReader(data)); err == nil {
+		t.Fatal("expected an error for a tag condition not supported by the action")
+	}
+}
@@ -25,17 +25,44 @@ import (
 
 // Statement - iam policy statement.
 type Statement struct {
-	SID        policy.ID           `json:"Sid,omitempty"`
-	Effect     policy.Effect       `json:"Effect"`
-	Actions    ActionSet           `json:"Action"`
-	Resources  ResourceSet         `json:"Resource,omitempty"`
-	Conditions condition.Functions `json:"Condition,omitempty"`
+	SID          policy.ID           `json:"Sid,omitempty"`
+	Effect       policy.Effect       `json:"Effect"`
+	Actions      ActionSet           `json:"Action,omitempty"`
+	NotActions   ActionSet           `json:"NotAction,omitempty"`
+	Resources    ResourceSet         `json:"Resource,omitempty"`
+	NotResources ResourceSet         `json:"NotResource,omitempty"`
+	Conditions   condition.Functions `json:"Condition,omitempty"`
+}
+
+// matchAction - checks whether the action is matched by Action, or not
+// matched by NotAction. NotAction of an Allow statement only applies to
+// the actions of its kind, either admin or S3 actions, while NotAction of
+// a Deny statement applies to all actions.
+func (statement Statement) matchAction(action Action) bool {
+	if !statement.NotActions.IsEmpty() {
+		if statement.Effect == policy.Allow && AdminAction(action).IsValid() != statement.isAdmin() {
+			return false
+		}
+		return !statement.NotActions.Match(action)
+	}
+
+	return statement.Actions.Match(action)
+}
+
+// matchResource - checks whether the resource is matched by Resource, or
+// not matched by NotResource.
+func (statement Statement) matchResource(resource string, conditionValues map[string][]string) bool {
+	if len(statement.NotResources) != 0 {
+		return !statement.NotResources.Match(resource, conditionValues)
+	}
+
+	return statement.Resources.Match(resource, conditionValues)
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
 	check := func() bool {
-		if !statement.Actions.Match(args.Action) {
+		if !statement.matchAction(args.Action) {
 			return false
 		}
 
@@ -50,8 +77,8 @@ func (statement Statement) IsAllowed(args Args) bool {
 			resource += "/"
 		}
 
-		// For admin statements, resource match can be ignored.
-		if !statement.Resources.Match(resource, args.ConditionValues) && !statement.isAdmin() {
+		// For admin actions, resource match can be ignored.
+		if !statement.matchResource(resource, args.ConditionValues) && !AdminAction(args.Action).IsValid() {
 			return false
 		}
 
@@ -60,8 +87,17 @@ func (statement Statement) IsAllowed(args Args) bool {
 
 	return statement.Effect.IsAllowed(check())
 }
+
+// actions - returns the actions of Action, or of NotAction if set.
+func (statement Statement) actions() ActionSet {
+	if !statement.NotActions.IsEmpty() {
+		return statement.NotActions
+	}
+	return statement.Actions
+}
+
 func (statement Statement) isAdmin() bool {
-	for action := range statement.Actions {
+	for action := range statement.actions() {
 		if AdminAction(action).IsValid() {
 			return true
 		}
@@ -75,16 +111,26 @@ func (statement Statement) isValid() error {
 		return Errorf("invalid Effect %v", statement.Effect)
 	}
 
-	if len(statement.Actions) == 0 {
+	if !statement.Actions.IsEmpty() && !statement.NotActions.IsEmpty() {
+		return Errorf("Action and NotAction must not be used together")
+	}
+
+	if statement.actions().IsEmpty() {
 		return Errorf("Action must not be empty")
 	}
 
 	if statement.isAdmin() {
-		if err := statement.Actions.ValidateAdmin(); err != nil {
+		if err := statement.actions().ValidateAdmin(); err != nil {
 			return err
 		}
+		keys := statement.Conditions.Keys()
+		if !statement.NotActions.IsEmpty() {
+			keyDiff := keys.Difference(condition.NewKeySet(condition.AllSupportedAdminKeys...))
+			if !keyDiff.IsEmpty() {
+				return Errorf("unsupported condition keys '%v' used for NotAction", keyDiff)
+			}
+		}
 		for action := range statement.Actions {
-			keys := statement.Conditions.Keys()
 			keyDiff := keys.Difference(adminActionConditionKeyMap[action])
 			if !keyDiff.IsEmpty() {
 				return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
@@ -97,23 +143,34 @@ func (statement Statement) isValid() error {
 		return Errorf("invalid SID %v", statement.SID)
 	}
 
-	if len(statement.Resources) == 0 {
+	if len(statement.Resources) != 0 && len(statement.NotResources) != 0 {
+		return Errorf("Resource and NotResource must not be used together")
+	}
+
+	resources := statement.Resources
+	if len(statement.NotResources) != 0 {
+		resources = statement.NotResources
+	}
+
+	if len(resources) == 0 {
 		return Errorf("Resource must not be empty")
 	}
 
-	if err := statement.Resources.Validate(); err != nil {
+	if err := resources.Validate(); err != nil {
 		return err
 	}
 
-	if err := statement.Actions.Validate(); err != nil {
+	if err := statement.actions().Validate(); err != nil {
 		return err
 	}
 
-	for action := range statement.Actions {
-		if !statement.Resources.objectResourceExists() && !statement.Resources.bucketResourceExists() {
-			return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
-		}
+	if !resources.objectResourceExists() && !resources.bucketResourceExists() {
+		return Errorf("unsupported Resource found %v for action %v", resources, statement.actions())
+	}
 
+	// Condition keys of a statement with NotAction are validated when
+	// parsed, it applies to all actions but those.
+	for action := range statement.Actions {
 		keys := statement.Conditions.Keys()
 		keyDiff := keys.Difference(actionConditionKeyMap[action])
 		if !keyDiff.IsEmpty() {
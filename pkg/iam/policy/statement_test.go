@@ -464,3 +464,74 @@ func TestStatementValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestStatementNotElements(t *testing.T) {
+	testCases := []struct {
+		data      string
+		args      Args
+		allowed   bool
+		expectErr bool
+	}{
+		// NotAction matches all other actions of its kind.
+		{`{"Effect": "Allow", "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true, false},
+		{`{"Effect": "Allow", "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		{`{"Effect": "Allow", "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::*"]}`,
+			Args{Action: Action(ServerInfoAdminAction)}, false, false},
+		{`{"Effect": "Allow", "NotAction": ["admin:ServerInfo"]}`,
+			Args{Action: Action(TraceAdminAction)}, true, false},
+		{`{"Effect": "Allow", "NotAction": ["admin:ServerInfo"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		// NotAction of a Deny statement applies to all actions.
+		{`{"Effect": "Deny", "NotAction": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*"]}`,
+			Args{Action: Action(ServerInfoAdminAction)}, false, false},
+		{`{"Effect": "Deny", "NotAction": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true, false},
+		{`{"Effect": "Deny", "NotAction": ["admin:ServerInfo"], "Resource": ["arn:aws:s3:::*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		{`{"Effect": "Deny", "NotAction": ["admin:ServerInfo"]}`,
+			Args{Action: Action(ServerInfoAdminAction)}, true, false},
+		// The Resource of a Deny statement with an admin NotAction still
+		// applies to S3 actions, it is only ignored for admin actions.
+		{`{"Effect": "Deny", "NotAction": ["admin:ServerInfo"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		{`{"Effect": "Deny", "NotAction": ["admin:ServerInfo"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "otherbucket", ObjectName: "myobject"}, true, false},
+		{`{"Effect": "Deny", "NotAction": ["admin:ServerInfo"], "Resource": ["arn:aws:s3:::mybucket/*"]}`,
+			Args{Action: Action(TraceAdminAction)}, false, false},
+		{`{"Effect": "Deny", "NotAction": ["admin:ServerInfo"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true, false},
+		// NotResource matches all other resources.
+		{`{"Effect": "Allow", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/private/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "public/myobject"}, true, false},
+		{`{"Effect": "Allow", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/private/*"]}`,
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private/myobject"}, false, false},
+		// Positive and negative elements must not be used together.
+		{`{"Effect": "Allow", "Action": ["s3:GetObject"], "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}`, Args{}, false, true},
+		{`{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"], "NotResource": ["arn:aws:s3:::mybucket/private/*"]}`, Args{}, false, true},
+		// Negative elements are validated like positive ones.
+		{`{"Effect": "Allow", "NotAction": ["s3:Foo"], "Resource": ["arn:aws:s3:::mybucket/*"]}`, Args{}, false, true},
+		{`{"Effect": "Allow", "NotAction": ["s3:GetObject"]}`, Args{}, false, true},
+		{`{"Effect": "Allow", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::"]}`, Args{}, false, true},
+	}
+
+	for i, testCase := range testCases {
+		var statement Statement
+		err := json.Unmarshal([]byte(testCase.data), &statement)
+		if err == nil {
+			err = statement.Validate()
+		}
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if expectErr {
+			continue
+		}
+
+		if result := statement.IsAllowed(testCase.args); result != testCase.allowed {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.allowed, result)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Compression algorithms of a bucket compression configuration.
+const (
+	// CompressionS2 compresses objects with S2, the default algorithm.
+	CompressionS2 = "s2"
+	// CompressionS2Better compresses objects with S2 trading speed for a better ratio.
+	CompressionS2Better = "s2-better"
+	// CompressionS2Best compresses objects with S2 at the best ratio, it is the slowest.
+	CompressionS2Best = "s2-best"
+	// CompressionZstd compresses objects with zstandard.
+	CompressionZstd = "zstd"
+)
+
+// BucketCompression holds the compression settings of a bucket, they
+// override the global compression settings for its objects. Objects are
+// compressed when they match one of the extensions or mime types, all
+// objects are compressed if none are set.
+type BucketCompression struct {
+	Enabled    bool     `json:"enabled"`
+	Algorithm  string   `json:"algorithm,omitempty"`
+	Extensions []string `json:"extensions,omitempty"`
+	MimeTypes  []string `json:"mimeTypes,omitempty"`
+}
+
+// IsValidCompressionAlgorithm returns true if the compression algorithm
+// is supported, an empty algorithm stands for the default algorithm.
+func IsValidCompressionAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case "", CompressionS2, CompressionS2Better, CompressionS2Best, CompressionZstd:
+		return true
+	}
+	return false
+}
+
+// GetBucketCompression - get the compression configuration of a bucket.
+func (adm *AdminClient) GetBucketCompression(ctx context.Context, bucket string) (c BucketCompression, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-compression",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-compression
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return c, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return c, err
+	}
+	if err = json.Unmarshal(b, &c); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// SetBucketCompression - sets the compression configuration of a bucket,
+// a nil configuration removes it so that the global settings apply again.
+func (adm *AdminClient) SetBucketCompression(ctx context.Context, bucket string, c *BucketCompression) error {
+	var data []byte
+	if c != nil {
+		var err error
+		data, err = json.Marshal(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-compression",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-compression to set the compression for a bucket.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PoolDecommissionInfo - progress of a pool being decommissioned.
+type PoolDecommissionInfo struct {
+	StartTime   time.Time `json:"startTime"`
+	StartSize   int64     `json:"startSize"`
+	TotalSize   int64     `json:"totalSize"`
+	CurrentSize int64     `json:"currentSize"`
+	Complete    bool      `json:"complete"`
+	Failed      bool      `json:"failed"`
+	Canceled    bool      `json:"canceled"`
+
+	// Bucket being decommissioned.
+	Bucket string `json:"bucket,omitempty"`
+
+	ObjectsDecommissioned     int64 `json:"objectsDecommissioned"`
+	ObjectsDecommissionFailed int64 `json:"objectsDecommissionFailed"`
+	BytesDone                 int64 `json:"bytesDecommissioned"`
+	BytesFailed               int64 `json:"bytesDecommissionFailed"`
+}
+
+// PoolStatus - status of a server pool, a pool is identified by
+// its command line argument.
+type PoolStatus struct {
+	ID           int                   `json:"id"`
+	CmdLine      string                `json:"cmdline"`
+	LastUpdate   time.Time             `json:"lastUpdate"`
+	Decommission *PoolDecommissionInfo `json:"decommissionInfo,omitempty"`
+
+	// Drives of the pool marked offline to be replaced.
+	OfflineDrives []string `json:"offlineDrives,omitempty"`
+}
+
+// DecommissionPool - starts moving all objects of the pool to the remaining
+// pools, the pool is not written to anymore. Once decommissioned the pool can
+// be removed from the command line arguments of all servers.
+func (adm *AdminClient) DecommissionPool(ctx context.Context, pool string) error {
+	return adm.poolAction(ctx, "/pools/decommission", pool)
+}
+
+// CancelDecommissionPool - cancels decommissioning the pool, the pool is
+// written to again.
+func (adm *AdminClient) CancelDecommissionPool(ctx context.Context, pool string) error {
+	return adm.poolAction(ctx, "/pools/cancel", pool)
+}
+
+func (adm *AdminClient) poolAction(ctx context.Context, path, pool string) error {
+	values := url.Values{}
+	values.Set("pool", pool)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + path,
+		queryValues: values,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// StatusPool - returns the status of the pool.
+func (adm *AdminClient) StatusPool(ctx context.Context, pool string) (PoolStatus, error) {
+	values := url.Values{}
+	values.Set("pool", pool)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/pools/status",
+		queryValues: values,
+	}
+
+	// Execute GET on /minio/admin/v3/pools/status to get the status of a pool.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return PoolStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return PoolStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	var status PoolStatus
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+	err = json.Unmarshal(b, &status)
+	return status, err
+}
+
+// ListPoolsStatus - returns the status of all pools.
+func (adm *AdminClient) ListPoolsStatus(ctx context.Context) ([]PoolStatus, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/pools/list",
+	}
+
+	// Execute GET on /minio/admin/v3/pools/list to get the status of all pools.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var pools []PoolStatus
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return pools, err
+	}
+	err = json.Unmarshal(b, &pools)
+	return pools, err
+}
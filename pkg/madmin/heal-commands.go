@@ -295,12 +295,44 @@ func (adm *AdminClient) Heal(ctx context.Context, bucket, prefix string,
 	return healStart, healTaskStatus, nil
 }
 
+// HealingDisk contains the progress of healing a drive, the
+// objects of its erasure set are healed onto it.
+type HealingDisk struct {
+	ID         string    `json:"id"`
+	Endpoint   string    `json:"endpoint"`
+	PoolIndex  int       `json:"poolIndex"`
+	SetIndex   int       `json:"setIndex"`
+	DiskIndex  int       `json:"diskIndex"`
+	Replaced   bool      `json:"replaced"`
+	Started    time.Time `json:"started"`
+	LastUpdate time.Time `json:"lastUpdate"`
+
+	// Bucket being healed.
+	Bucket string `json:"bucket,omitempty"`
+
+	ObjectsHealed uint64 `json:"objectsHealed"`
+	ObjectsFailed uint64 `json:"objectsFailed"`
+	BytesDone     uint64 `json:"bytesDone"`
+	BytesFailed   uint64 `json:"bytesFailed"`
+
+	// BytesTotal is an estimate of the bytes to heal, it is
+	// derived from the space used on the other drives of the set.
+	BytesTotal uint64 `json:"bytesTotal"`
+
+	// ETA is the estimated time the heal completes, it is
+	// zero until enough progress has been made.
+	ETA time.Time `json:"eta"`
+}
+
 // BgHealState represents the status of the background heal
 type BgHealState struct {
 	ScannedItemsCount int64
 	LastHealActivity  time.Time
 	NextHealRound     time.Time
 	HealDisks         []string
+
+	// Progress of the drives being healed.
+	HealingDisks []HealingDisk
 }
 
 // BackgroundHealStatus returns the background heal status of the
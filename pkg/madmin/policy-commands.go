@@ -175,3 +175,81 @@ func (adm *AdminClient) SetPolicy(ctx context.Context, policyName, entityName st
 	}
 	return nil
 }
+
+// Sources of the statements matching a simulated request.
+const (
+	// PolicySourceUser - a canned policy attached to the user.
+	PolicySourceUser = "user"
+	// PolicySourceGroup - a canned policy attached to a group of the user.
+	PolicySourceGroup = "group"
+	// PolicySourceSTS - a canned policy in the claims of temporary credentials.
+	PolicySourceSTS = "sts"
+	// PolicySourceSession - the session policy of temporary credentials or
+	// the policy of a service account.
+	PolicySourceSession = "session"
+	// PolicySourceBucket - the bucket policy, for anonymous requests.
+	PolicySourceBucket = "bucket"
+)
+
+// PolicySimulationRequest - an action on a resource to evaluate the policies
+// of an identity for, an empty access key is an anonymous request.
+type PolicySimulationRequest struct {
+	// Access key of a user, a service account or temporary credentials.
+	AccessKey string `json:"accessKey"`
+	// Action such as "s3:GetObject" or "admin:ServerInfo".
+	Action string `json:"action"`
+	// Resource such as "arn:aws:s3:::mybucket/myobject" or "mybucket/myobject".
+	Resource string `json:"resource,omitempty"`
+	// Condition values by condition key, such as "aws:SourceIp".
+	Conditions map[string][]string `json:"conditions,omitempty"`
+}
+
+// PolicySimulationStatement - a policy statement matching a simulated request.
+type PolicySimulationStatement struct {
+	// Source of the statement, one of the PolicySource constants.
+	Source string `json:"source"`
+	// Name of the user, group or bucket of the policy.
+	Name string `json:"name,omitempty"`
+	// Name of the canned policy, empty for session and bucket policies.
+	Policy    string          `json:"policy,omitempty"`
+	Effect    string          `json:"effect"`
+	Statement json.RawMessage `json:"statement"`
+}
+
+// PolicySimulationResult - the decision for a simulated request and the
+// statements allowing or denying it, no statement allows a denied request
+// without matching statements.
+type PolicySimulationResult struct {
+	Allowed bool `json:"allowed"`
+	// Owner is set for the root credentials, not subject to policies.
+	Owner      bool                        `json:"owner,omitempty"`
+	Statements []PolicySimulationStatement `json:"statements,omitempty"`
+}
+
+// SimulatePolicy - evaluates the policies of an identity for an action on a
+// resource, without performing the action.
+func (adm *AdminClient) SimulatePolicy(ctx context.Context, req PolicySimulationRequest) (result PolicySimulationResult, err error) {
+	buf, err := json.Marshal(req)
+	if err != nil {
+		return result, err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/simulate-policy",
+		content: buf,
+	}
+
+	// Execute POST on /minio/admin/v3/simulate-policy
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
@@ -0,0 +1,168 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Status of a pool taking part in a rebalance.
+const (
+	RebalanceStarted   = "Started"
+	RebalanceCompleted = "Completed"
+	RebalanceStopped   = "Stopped"
+	RebalanceFailed    = "Failed"
+)
+
+// RebalancePoolProgress - progress of the objects moved out of a pool.
+type RebalancePoolProgress struct {
+	// Bucket being rebalanced.
+	Bucket string `json:"bucket,omitempty"`
+
+	Objects       int64 `json:"objects"`
+	ObjectsFailed int64 `json:"objectsFailed"`
+	Bytes         int64 `json:"bytes"`
+	BytesFailed   int64 `json:"bytesFailed"`
+}
+
+// RebalancePoolStatus - status of a pool in a rebalance, pools with more
+// free space than the goal do not take part in it and have no status.
+type RebalancePoolStatus struct {
+	ID       int                   `json:"id"`
+	CmdLine  string                `json:"cmdline"`
+	Status   string                `json:"status,omitempty"`
+	Used     float64               `json:"used"`
+	Progress RebalancePoolProgress `json:"progress"`
+}
+
+// RebalanceStatus - status of a rebalance of the server pools, objects are
+// moved out of the pools taking part in it until their fraction of free
+// space reaches the goal.
+type RebalanceStatus struct {
+	ID        string                `json:"id"`
+	StartTime time.Time             `json:"startTime"`
+	StoppedAt time.Time             `json:"stoppedAt,omitempty"`
+	FreeGoal  float64               `json:"freeGoal"`
+	Pools     []RebalancePoolStatus `json:"pools"`
+}
+
+// RebalanceOpts - parameters of a rebalance, zero values are replaced by
+// the defaults of the server.
+type RebalanceOpts struct {
+	// Pools with a fraction of free space within the threshold of the
+	// goal do not take part in the rebalance, 0.05 by default.
+	Threshold float64
+
+	// Objects are moved at most 1/(1+SleepFactor) of the time, with
+	// pauses of at most MaxSleep, 2 and 1s by default.
+	SleepFactor float64
+	MaxSleep    time.Duration
+}
+
+// RebalanceStart - starts rebalancing the server pools, returns the ID of
+// the rebalance.
+func (adm *AdminClient) RebalanceStart(ctx context.Context, opts RebalanceOpts) (id string, err error) {
+	queryValues := url.Values{}
+	if opts.Threshold != 0 {
+		queryValues.Set("threshold", strconv.FormatFloat(opts.Threshold, 'f', -1, 64))
+	}
+	if opts.SleepFactor != 0 {
+		queryValues.Set("sleepFactor", strconv.FormatFloat(opts.SleepFactor, 'f', -1, 64))
+	}
+	if opts.MaxSleep != 0 {
+		queryValues.Set("maxSleep", opts.MaxSleep.String())
+	}
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/rebalance/start",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/rebalance/start to start a rebalance.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if err = json.Unmarshal(b, &result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// RebalanceStatus - returns the status of the last rebalance.
+func (adm *AdminClient) RebalanceStatus(ctx context.Context) (RebalanceStatus, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/rebalance/status",
+	}
+
+	// Execute GET on /minio/admin/v3/rebalance/status to get the status of the rebalance.
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return RebalanceStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return RebalanceStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	var status RebalanceStatus
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+	err = json.Unmarshal(b, &status)
+	return status, err
+}
+
+// RebalanceStop - stops the rebalance, objects already moved are not
+// moved back.
+func (adm *AdminClient) RebalanceStop(ctx context.Context) error {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/rebalance/stop",
+	}
+
+	// Execute POST on /minio/admin/v3/rebalance/stop to stop the rebalance.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// OfflineDrive - marks the drive offline so that it can be replaced, the
+// drive is not used until it is replaced. Drives are identified by their
+// endpoint, such as http://server1/disk1.
+func (adm *AdminClient) OfflineDrive(ctx context.Context, endpoint string) error {
+	return adm.driveAction(ctx, "/drives/offline", endpoint)
+}
+
+// ReplaceDrive - brings back a drive marked offline once it was swapped,
+// the erasure set of the drive is healed onto it right away. The progress
+// is reported by BackgroundHealStatus.
+func (adm *AdminClient) ReplaceDrive(ctx context.Context, endpoint string) error {
+	return adm.driveAction(ctx, "/drives/replace", endpoint)
+}
+
+func (adm *AdminClient) driveAction(ctx context.Context, path, endpoint string) error {
+	values := url.Values{}
+	values.Set("endpoint", endpoint)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + path,
+		queryValues: values,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
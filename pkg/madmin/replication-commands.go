@@ -0,0 +1,272 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ResyncState represents the state of a replication resync.
+type ResyncState string
+
+const (
+	// ResyncRunning - existing objects are being queued for replication.
+	ResyncRunning ResyncState = "Running"
+	// ResyncCompleted - all existing objects were queued for replication.
+	ResyncCompleted ResyncState = "Completed"
+	// ResyncFailed - the resync stopped on an error.
+	ResyncFailed ResyncState = "Failed"
+	// ResyncCanceled - the resync was canceled.
+	ResyncCanceled ResyncState = "Canceled"
+)
+
+// ReplicationResyncStatus - progress of replicating the existing
+// objects of a bucket to a remote target.
+type ReplicationResyncStatus struct {
+	Bucket string      `json:"bucket"`
+	Arn    string      `json:"arn"`
+	State  ResyncState `json:"state"`
+	// Node is the server running the resync.
+	Node       string    `json:"node"`
+	StartTime  time.Time `json:"startTime"`
+	LastUpdate time.Time `json:"lastUpdate"`
+	// Object and VersionID of the last version queued,
+	// an interrupted resync resumes after it.
+	Object    string `json:"object,omitempty"`
+	VersionID string `json:"versionId,omitempty"`
+	// ScannedCount is the number of versions listed, QueuedCount
+	// and QueuedSize the number and size of versions queued.
+	ScannedCount uint64 `json:"scannedCount"`
+	QueuedCount  uint64 `json:"queuedCount"`
+	QueuedSize   int64  `json:"queuedSize"`
+	Error        string `json:"error,omitempty"`
+}
+
+// StartReplicationResync starts replicating all existing objects
+// of the bucket to the remote target with this ARN.
+func (adm *AdminClient) StartReplicationResync(ctx context.Context, bucket, arn string) (status ReplicationResyncStatus, err error) {
+	return adm.replicationResync(ctx, http.MethodPost, bucket, arn)
+}
+
+// GetReplicationResyncStatus returns the progress of the replication
+// resync of the bucket to the remote target with this ARN.
+func (adm *AdminClient) GetReplicationResyncStatus(ctx context.Context, bucket, arn string) (status ReplicationResyncStatus, err error) {
+	return adm.replicationResync(ctx, http.MethodGet, bucket, arn)
+}
+
+// CancelReplicationResync cancels the replication resync of the
+// bucket to the remote target with this ARN.
+func (adm *AdminClient) CancelReplicationResync(ctx context.Context, bucket, arn string) (status ReplicationResyncStatus, err error) {
+	return adm.replicationResync(ctx, http.MethodDelete, bucket, arn)
+}
+
+func (adm *AdminClient) replicationResync(ctx context.Context, method, bucket, arn string) (status ReplicationResyncStatus, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("arn", arn)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/replication-resync",
+		queryValues: queryValues,
+	}
+
+	// Execute method on /minio/admin/v3/replication-resync
+	resp, err := adm.executeMethod(ctx, method, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+	if err = json.Unmarshal(b, &status); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+// TargetReplicationMetrics - live replication metrics of a bucket for
+// a remote target. Failed and completed counts are cumulative since the
+// servers started, the others are current values.
+type TargetReplicationMetrics struct {
+	Arn            string `json:"arn"`
+	QueuedCount    uint64 `json:"queuedCount"`
+	QueuedSize     uint64 `json:"queuedSize"`
+	InflightCount  uint64 `json:"inflightCount"`
+	InflightSize   uint64 `json:"inflightSize"`
+	FailedCount    uint64 `json:"failedCount"`
+	FailedSize     uint64 `json:"failedSize"`
+	CompletedCount uint64 `json:"completedCount"`
+	CompletedSize  uint64 `json:"completedSize"`
+	// LastReplicationTime is when a replication to the target last completed.
+	LastReplicationTime time.Time `json:"lastReplicationTime"`
+	// Latency is the moving average of the round trip time of requests to the target.
+	Latency time.Duration `json:"latency"`
+}
+
+// BucketReplicationMetrics - live replication metrics of a bucket summed up
+// across all servers.
+type BucketReplicationMetrics struct {
+	Bucket string `json:"bucket"`
+	// QueueDepth is the number of replication tasks of all buckets waiting
+	// in the queues of the servers.
+	QueueDepth uint64                              `json:"queueDepth"`
+	Targets    map[string]TargetReplicationMetrics `json:"targets"`
+}
+
+// BucketReplicationMetrics returns the live replication metrics of the bucket
+// for each of its remote targets.
+func (adm *AdminClient) BucketReplicationMetrics(ctx context.Context, bucket string) (metrics BucketReplicationMetrics, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/replication-metrics",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/replication-metrics
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return metrics, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return metrics, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return metrics, err
+	}
+	if err = json.Unmarshal(b, &metrics); err != nil {
+		return metrics, err
+	}
+	return metrics, nil
+}
+
+// ReplicationDiffStatus represents how a version differs on a remote target.
+type ReplicationDiffStatus string
+
+const (
+	// ReplicationDiffMissing - the version does not exist on the target.
+	ReplicationDiffMissing ReplicationDiffStatus = "Missing"
+	// ReplicationDiffStale - the version on the target has different content.
+	ReplicationDiffStale ReplicationDiffStatus = "Stale"
+	// ReplicationDiffMetadata - the version on the target has different metadata.
+	ReplicationDiffMetadata ReplicationDiffStatus = "MetadataDiverged"
+	// ReplicationDiffUnknown - the version could not be compared.
+	ReplicationDiffUnknown ReplicationDiffStatus = "Unknown"
+)
+
+// ReplicationDiffOpts - options of a replication diff.
+type ReplicationDiffOpts struct {
+	// Arn limits the diff to a remote target, all targets
+	// of the bucket are compared if empty.
+	Arn    string
+	Prefix string
+	// Requeue queues the versions not in sync for replication.
+	Requeue bool
+}
+
+// ReplicationDiffEntry - a version of the bucket which is not in sync
+// with a remote target.
+type ReplicationDiffEntry struct {
+	Object         string                `json:"object"`
+	VersionID      string                `json:"versionId"`
+	IsDeleteMarker bool                  `json:"isDeleteMarker,omitempty"`
+	Arn            string                `json:"arn"`
+	Status         ReplicationDiffStatus `json:"status"`
+	// ReplicationStatus is the replication status of the
+	// version to the target recorded on the source.
+	ReplicationStatus string `json:"replicationStatus,omitempty"`
+	// Requeued is set when the version was queued for replication.
+	Requeued bool `json:"requeued,omitempty"`
+	// Error is why the version could not be compared, or why
+	// the diff stopped when Object is empty.
+	Error string `json:"error,omitempty"`
+	Err   error  `json:"-"`
+}
+
+// BucketReplicationDiff compares the versions of the bucket against its remote
+// targets and streams back the versions which are missing or differ on a target.
+func (adm *AdminClient) BucketReplicationDiff(ctx context.Context, bucket string, opts ReplicationDiffOpts) <-chan ReplicationDiffEntry {
+	diffCh := make(chan ReplicationDiffEntry)
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("arn", opts.Arn)
+	queryValues.Set("prefix", opts.Prefix)
+	queryValues.Set("requeue", strconv.FormatBool(opts.Requeue))
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/replication-diff",
+		queryValues: queryValues,
+	}
+
+	go func() {
+		defer close(diffCh)
+
+		// Execute POST on /minio/admin/v3/replication-diff
+		resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+		defer closeResponse(resp)
+		if err != nil {
+			diffCh <- ReplicationDiffEntry{Err: err}
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			diffCh <- ReplicationDiffEntry{Err: httpRespToErrorResponse(resp)}
+			return
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var entry ReplicationDiffEntry
+			if err = dec.Decode(&entry); err != nil {
+				if err != io.EOF {
+					diffCh <- ReplicationDiffEntry{Err: err}
+				}
+				return
+			}
+			if entry.Object == "" && entry.Error != "" {
+				entry.Err = errors.New(entry.Error)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case diffCh <- entry:
+			}
+		}
+	}()
+	return diffCh
+}
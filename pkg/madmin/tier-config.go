@@ -0,0 +1,176 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"errors"
+	"strings"
+)
+
+// TierType represents the type of the remote storage of a tier.
+type TierType string
+
+const (
+	// TierTypeS3 - an S3 compatible object storage.
+	TierTypeS3 TierType = "s3"
+	// TierTypeAzure - Azure Blob Storage.
+	TierTypeAzure TierType = "azure"
+	// TierTypeGCS - Google Cloud Storage.
+	TierTypeGCS TierType = "gcs"
+)
+
+// TierS3 - remote tier on an S3 compatible object storage.
+type TierS3 struct {
+	Endpoint     string `json:"endpoint"`
+	AccessKey    string `json:"accessKey"`
+	SecretKey    string `json:"secretKey"`
+	Bucket       string `json:"bucket"`
+	Prefix       string `json:"prefix,omitempty"`
+	Region       string `json:"region,omitempty"`
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// TierAzure - remote tier on Azure Blob Storage, the bucket is the container.
+type TierAzure struct {
+	Endpoint    string `json:"endpoint"`
+	AccountName string `json:"accountName"`
+	AccountKey  string `json:"accountKey"`
+	Bucket      string `json:"bucket"`
+	Prefix      string `json:"prefix,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+// TierGCS - remote tier on Google Cloud Storage, Creds holds the
+// contents of the service account credentials file.
+type TierGCS struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	Creds    string `json:"creds"`
+	Bucket   string `json:"bucket"`
+	Prefix   string `json:"prefix,omitempty"`
+	Region   string `json:"region,omitempty"`
+}
+
+// TierConfig - a named remote tier lifecycle rules transition objects to,
+// rules refer to the tier by its name in Transition.StorageClass. Exactly
+// one of S3, Azure and GCS is set according to Type.
+type TierConfig struct {
+	Name  string     `json:"name"`
+	Type  TierType   `json:"type"`
+	S3    *TierS3    `json:"s3,omitempty"`
+	Azure *TierAzure `json:"azure,omitempty"`
+	GCS   *TierGCS   `json:"gcs,omitempty"`
+}
+
+// TierCreds - new credentials of a tier, AccessKey and SecretKey for S3
+// and Azure tiers, CredsJSON for GCS tiers.
+type TierCreds struct {
+	AccessKey string `json:"access,omitempty"`
+	SecretKey string `json:"secret,omitempty"`
+	CredsJSON []byte `json:"creds,omitempty"`
+}
+
+var (
+	// ErrTierNameEmpty - the tier has no name.
+	ErrTierNameEmpty = errors.New("tier name cannot be empty")
+	// ErrTierNameInvalid - the tier name is not upper case.
+	ErrTierNameInvalid = errors.New("tier name must be in upper case")
+	// ErrTierTypeInvalid - the tier type is unsupported or does
+	// not match the remote storage set.
+	ErrTierTypeInvalid = errors.New("unsupported tier type")
+	// ErrTierBucketEmpty - the tier has no remote bucket.
+	ErrTierBucketEmpty = errors.New("tier bucket cannot be empty")
+)
+
+// Validate checks the tier config is complete.
+func (cfg TierConfig) Validate() error {
+	if cfg.Name == "" {
+		return ErrTierNameEmpty
+	}
+	if cfg.Name != strings.ToUpper(cfg.Name) {
+		return ErrTierNameInvalid
+	}
+	switch {
+	case cfg.Type == TierTypeS3 && cfg.S3 != nil:
+	case cfg.Type == TierTypeAzure && cfg.Azure != nil:
+	case cfg.Type == TierTypeGCS && cfg.GCS != nil:
+	default:
+		return ErrTierTypeInvalid
+	}
+	if cfg.Bucket() == "" {
+		return ErrTierBucketEmpty
+	}
+	return nil
+}
+
+// Bucket returns the remote bucket of the tier.
+func (cfg TierConfig) Bucket() string {
+	switch cfg.Type {
+	case TierTypeS3:
+		return cfg.S3.Bucket
+	case TierTypeAzure:
+		return cfg.Azure.Bucket
+	case TierTypeGCS:
+		return cfg.GCS.Bucket
+	}
+	return ""
+}
+
+// Prefix returns the prefix of the tier in the remote bucket.
+func (cfg TierConfig) Prefix() string {
+	switch cfg.Type {
+	case TierTypeS3:
+		return cfg.S3.Prefix
+	case TierTypeAzure:
+		return cfg.Azure.Prefix
+	case TierTypeGCS:
+		return cfg.GCS.Prefix
+	}
+	return ""
+}
+
+// Endpoint returns the endpoint of the remote storage of the tier.
+func (cfg TierConfig) Endpoint() string {
+	switch cfg.Type {
+	case TierTypeS3:
+		return cfg.S3.Endpoint
+	case TierTypeAzure:
+		return cfg.Azure.Endpoint
+	case TierTypeGCS:
+		return cfg.GCS.Endpoint
+	}
+	return ""
+}
+
+// Redacted returns a copy of the tier config without its credentials.
+func (cfg TierConfig) Redacted() TierConfig {
+	switch cfg.Type {
+	case TierTypeS3:
+		s3 := *cfg.S3
+		s3.SecretKey = "REDACTED"
+		cfg.S3 = &s3
+	case TierTypeAzure:
+		az := *cfg.Azure
+		az.AccountKey = "REDACTED"
+		cfg.Azure = &az
+	case TierTypeGCS:
+		gcs := *cfg.GCS
+		gcs.Creds = "REDACTED"
+		cfg.GCS = &gcs
+	}
+	return cfg
+}
@@ -41,6 +41,8 @@ type LockEntry struct {
 	ID         string    `json:"id"`         // UID to uniquely identify request of client.
 	// Represents quorum number of servers required to hold this lock, used to look for stale locks.
 	Quorum int `json:"quorum"`
+	// Elapsed is the time since the lock was granted, as measured by the server.
+	Elapsed time.Duration `json:"elapsed"`
 }
 
 // LockEntries - To sort the locks
@@ -127,3 +129,84 @@ func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts)
 func (adm *AdminClient) TopLocks(ctx context.Context) (LockEntries, error) {
 	return adm.TopLocksWithOpts(ctx, TopLockOpts{Count: 10})
 }
+
+// ListLocksOpts selects the locks returned by ListLocks.
+type ListLocksOpts struct {
+	// Owner only returns the locks requested by the server, such as "server1:9000".
+	Owner string
+	// Prefix only returns the locks of resources with the prefix, such as "bucket/".
+	Prefix string
+	// OlderThan only returns the locks held for longer than the duration.
+	OlderThan time.Duration
+	// Count limits the number of locks returned, oldest first. All when 0.
+	Count int
+	// Stale also returns the locks held by less servers than their quorum.
+	Stale bool
+}
+
+// ListLocks - returns the locks held in the cluster, oldest first, with
+// their owner, source and age. Unlike TopLocks, the lock servers are asked
+// through the lock backend in use.
+func (adm *AdminClient) ListLocks(ctx context.Context, opts ListLocksOpts) (LockEntries, error) {
+	queryVals := make(url.Values)
+	if opts.Owner != "" {
+		queryVals.Set("owner", opts.Owner)
+	}
+	if opts.Prefix != "" {
+		queryVals.Set("prefix", opts.Prefix)
+	}
+	if opts.OlderThan > 0 {
+		queryVals.Set("older-than", opts.OlderThan.String())
+	}
+	if opts.Count > 0 {
+		queryVals.Set("count", strconv.Itoa(opts.Count))
+	}
+	queryVals.Set("stale", strconv.FormatBool(opts.Stale))
+
+	// Execute GET on /minio/admin/v3/locks
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/locks",
+			queryValues: queryVals,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var lockEntries LockEntries
+	err = json.NewDecoder(resp.Body).Decode(&lockEntries)
+	return lockEntries, err
+}
+
+// ReleaseLocks - forcibly releases all the locks requested by a server
+// which went down, such as "server1:9000", on all the lock servers. The
+// locks of a server which is still online are not released.
+func (adm *AdminClient) ReleaseLocks(ctx context.Context, owner string) error {
+	queryVals := make(url.Values)
+	queryVals.Set("owner", owner)
+
+	// Execute POST on /minio/admin/v3/locks/release
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPost,
+		requestData{
+			relPath:     adminAPIPrefix + "/locks/release",
+			queryValues: queryVals,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}